@@ -2,23 +2,85 @@ package configs
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	DBHost     string `mapstructure:"DB_HOST"`
-	DBName     string `mapstructure:"DB_DATABASE"`
-	DBUsername string `mapstructure:"DB_USERNAME"`
-	DBPassword string `mapstructure:"DB_PASSWORD"`
-	DBPort     string `mapstructure:"DB_PORT"`
-	JWTSecret  string `mapstructure:"JWT_SECRET"`
+	DBDriver              string        `mapstructure:"DB_DRIVER"`
+	DBPath                string        `mapstructure:"DB_PATH"`
+	DBHost                string        `mapstructure:"DB_HOST"`
+	DBName                string        `mapstructure:"DB_DATABASE"`
+	DBUsername            string        `mapstructure:"DB_USERNAME"`
+	DBPassword            string        `mapstructure:"DB_PASSWORD"`
+	DBPort                string        `mapstructure:"DB_PORT"`
+	DBAutoMigrate         bool          `mapstructure:"DB_AUTO_MIGRATE"`
+	DBMaxOpenConns        int           `mapstructure:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns        int           `mapstructure:"DB_MAX_IDLE_CONNS"`
+	DBConnMaxLifetime     time.Duration `mapstructure:"DB_CONN_MAX_LIFETIME"`
+	DBConnMaxIdleTime     time.Duration `mapstructure:"DB_CONN_MAX_IDLE_TIME"`
+	DBReplicas            []string      `mapstructure:"DB_REPLICAS"`
+	CacheDriver           string        `mapstructure:"CACHE_DRIVER"`
+	CacheTTL              time.Duration `mapstructure:"CACHE_TTL"`
+	CacheSize             int           `mapstructure:"CACHE_SIZE"`
+	RedisAddr             string        `mapstructure:"REDIS_ADDR"`
+	RedisPassword         string        `mapstructure:"REDIS_PASSWORD"`
+	RedisDB               int           `mapstructure:"REDIS_DB"`
+	JWTSecret             string        `mapstructure:"JWT_SECRET"`
+	RecurrenceJobInterval time.Duration `mapstructure:"RECURRENCE_JOB_INTERVAL"`
+	ReminderJobInterval   time.Duration `mapstructure:"REMINDER_JOB_INTERVAL"`
+	WebhookJobInterval    time.Duration `mapstructure:"WEBHOOK_JOB_INTERVAL"`
+	OutboxJobInterval     time.Duration `mapstructure:"OUTBOX_JOB_INTERVAL"`
+	OutboxRetention       time.Duration `mapstructure:"OUTBOX_RETENTION"`
+	OutboxPublisher       string        `mapstructure:"OUTBOX_PUBLISHER"`
+	OutboxHTTPURL         string        `mapstructure:"OUTBOX_HTTP_URL"`
+	NATSURL               string        `mapstructure:"NATS_URL"`
+	NATSSubject           string        `mapstructure:"NATS_SUBJECT"`
+	KafkaBrokers          []string      `mapstructure:"KAFKA_BROKERS"`
+	KafkaTopic            string        `mapstructure:"KAFKA_TOPIC"`
+	SMTPHost              string        `mapstructure:"SMTP_HOST"`
+	SMTPPort              string        `mapstructure:"SMTP_PORT"`
+	SMTPUsername          string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword          string        `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom              string        `mapstructure:"SMTP_FROM"`
 }
 
 func NewConfig() *Config {
 	config := &Config{}
 	viper.SetConfigFile(".env")
 
+	viper.SetDefault("DB_DRIVER", "mysql")
+	viper.SetDefault("DB_PATH", "tasks.db")
+	viper.SetDefault("DB_AUTO_MIGRATE", false)
+	viper.SetDefault("DB_MAX_OPEN_CONNS", 25)
+	viper.SetDefault("DB_MAX_IDLE_CONNS", 10)
+	viper.SetDefault("DB_CONN_MAX_LIFETIME", 30*time.Minute)
+	viper.SetDefault("DB_CONN_MAX_IDLE_TIME", 5*time.Minute)
+	viper.SetDefault("DB_REPLICAS", []string{})
+	viper.SetDefault("CACHE_DRIVER", "none")
+	viper.SetDefault("CACHE_TTL", time.Minute)
+	viper.SetDefault("CACHE_SIZE", 10000)
+	viper.SetDefault("REDIS_ADDR", "localhost:6379")
+	viper.SetDefault("REDIS_PASSWORD", "")
+	viper.SetDefault("REDIS_DB", 0)
+	viper.SetDefault("RECURRENCE_JOB_INTERVAL", time.Minute)
+	viper.SetDefault("REMINDER_JOB_INTERVAL", 30*time.Second)
+	viper.SetDefault("WEBHOOK_JOB_INTERVAL", 10*time.Second)
+	viper.SetDefault("OUTBOX_JOB_INTERVAL", 5*time.Second)
+	viper.SetDefault("OUTBOX_RETENTION", 7*24*time.Hour)
+	viper.SetDefault("OUTBOX_PUBLISHER", "log")
+	viper.SetDefault("OUTBOX_HTTP_URL", "")
+	viper.SetDefault("NATS_URL", "nats://localhost:4222")
+	viper.SetDefault("NATS_SUBJECT", "tasks")
+	viper.SetDefault("KAFKA_BROKERS", []string{"localhost:9092"})
+	viper.SetDefault("KAFKA_TOPIC", "tasks")
+	viper.SetDefault("SMTP_HOST", "localhost")
+	viper.SetDefault("SMTP_PORT", "25")
+	viper.SetDefault("SMTP_USERNAME", "")
+	viper.SetDefault("SMTP_PASSWORD", "")
+	viper.SetDefault("SMTP_FROM", "tasks@localhost")
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatalln("❌ Error reading config file", err)
 	}
@@ -0,0 +1,145 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+const (
+	KindTodo  = "todo"
+	KindEvent = "event"
+)
+
+const (
+	productID = "-//GraphZC//sdd-task-management//EN"
+	uidDomain = "sdd-task-management"
+
+	// Events get a fixed length since tasks only have a due date
+	eventDuration = 30 * time.Minute
+
+	// RFC 5545 content lines are folded after 75 octets
+	maxLineLength = 75
+)
+
+// Encode writes the tasks with a due date as an RFC 5545 calendar, either as
+// to-dos or as events for clients that don't show to-dos.
+func Encode(w io.Writer, name string, kind string, tasks []models.Task, now time.Time) error {
+	writer := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writeLine(writer, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", productID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeText(name))
+
+	for i := range tasks {
+		task := &tasks[i]
+		if task.DueDate == nil {
+			continue
+		}
+
+		dueDate := *task.DueDate
+
+		component := "VTODO"
+		if kind == KindEvent {
+			component = "VEVENT"
+		}
+
+		line("BEGIN", component)
+		line("UID", task.ID+"@"+uidDomain)
+		line("DTSTAMP", formatTime(now))
+		line("CREATED", formatTime(task.CreatedAt))
+		line("LAST-MODIFIED", formatTime(task.UpdatedAt))
+		line("SUMMARY", escapeText(task.Title))
+		if task.Description != "" {
+			line("DESCRIPTION", escapeText(task.Description))
+		}
+		if len(task.Tags) > 0 {
+			tags := make([]string, len(task.Tags))
+			for i, tag := range task.Tags {
+				tags[i] = escapeText(tag)
+			}
+			line("CATEGORIES", strings.Join(tags, ","))
+		}
+		line("PRIORITY", priority(task.Priority))
+
+		if kind == KindEvent {
+			line("DTSTART", formatTime(dueDate))
+			line("DTEND", formatTime(dueDate.Add(eventDuration)))
+			line("STATUS", "CONFIRMED")
+			line("TRANSP", "TRANSPARENT")
+		} else {
+			line("DUE", formatTime(dueDate))
+			if task.Status == models.TaskStatusCompleted {
+				line("STATUS", "COMPLETED")
+				line("PERCENT-COMPLETE", "100")
+			} else {
+				line("STATUS", "NEEDS-ACTION")
+			}
+		}
+
+		line("END", component)
+	}
+
+	line("END", "VCALENDAR")
+
+	return writer.Flush()
+}
+
+// iCalendar priorities run from 1 (highest) to 9 (lowest), 0 is undefined
+func priority(value int) string {
+	switch value {
+	case models.TaskPriorityHigh:
+		return "1"
+	case models.TaskPriorityMedium:
+		return "5"
+	case models.TaskPriorityLow:
+		return "9"
+	default:
+		return "0"
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+		"\r", "",
+	).Replace(value)
+}
+
+func writeLine(w *bufio.Writer, line string) {
+	// Fold long lines without splitting a UTF-8 sequence, continuation
+	// lines lose one octet to the leading space
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineLength - 1
+	}
+
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,14 @@
+package events
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// Broker fans task events out to every instance of the service. Subscribe
+// registers the handler and returns without blocking.
+type Broker interface {
+	Publish(ctx context.Context, event *models.TaskEvent) error
+	Subscribe(ctx context.Context, handler Handler) error
+}
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+type Handler func(ctx context.Context, event *models.TaskEvent)
+
+type Dispatcher interface {
+	Subscribe(handler Handler)
+	Dispatch(ctx context.Context, event *models.TaskEvent)
+}
+
+type dispatcher struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewDispatcher() Dispatcher {
+	return &dispatcher{}
+}
+
+func (d *dispatcher) Subscribe(handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers = append(d.handlers, handler)
+}
+
+func (d *dispatcher) Dispatch(ctx context.Context, event *models.TaskEvent) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	// Handlers run in order and must not block the caller for long
+	for _, handler := range d.handlers {
+		handler(ctx, event)
+	}
+}
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// localBroker only reaches subscribers in the same process
+type localBroker struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewLocalBroker() Broker {
+	return &localBroker{}
+}
+
+func (l *localBroker) Publish(ctx context.Context, event *models.TaskEvent) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, handler := range l.handlers {
+		handler(ctx, event)
+	}
+
+	return nil
+}
+
+func (l *localBroker) Subscribe(ctx context.Context, handler Handler) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.handlers = append(l.handlers, handler)
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// Publisher hands outbox messages to other systems. A message may be published
+// more than once, consumers drop repeats by the message id.
+type Publisher interface {
+	Publish(ctx context.Context, message *models.OutboxMessage) error
+}
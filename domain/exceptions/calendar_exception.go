@@ -0,0 +1,7 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrCalendarNotFound = errors.New("calendar not found")
+)
@@ -0,0 +1,8 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+)
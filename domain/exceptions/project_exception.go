@@ -0,0 +1,8 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrProjectNotFound       = errors.New("project not found")
+	ErrDuplicatedProjectName = errors.New("duplicated project name")
+)
@@ -0,0 +1,8 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrInvalidQuery = errors.New("invalid query")
+	ErrInvalidSort  = errors.New("invalid sort")
+)
@@ -0,0 +1,11 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrReminderNotFound       = errors.New("reminder not found")
+	ErrInvalidReminderTime    = errors.New("either remindAt or offsetMinutes is required")
+	ErrReminderWithoutDueDate = errors.New("reminder offset requires a task due date")
+	ErrInvalidReminderTarget  = errors.New("webhook reminder requires a public http or https target url")
+	ErrUnsupportedChannel     = errors.New("unsupported notification channel")
+)
@@ -0,0 +1,7 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrInvalidStatsRange = errors.New("invalid stats range")
+)
@@ -3,7 +3,18 @@ package exceptions
 import "errors"
 
 var (
-	ErrInvalidPriority = errors.New("invalid priority")
-	ErrInvalidStatus   = errors.New("invalid status")
-	ErrTaskNotFound    = errors.New("task not found")
+	ErrInvalidPriority          = errors.New("invalid priority")
+	ErrInvalidStatus            = errors.New("invalid status")
+	ErrTaskNotFound             = errors.New("task not found")
+	ErrInvalidRecurrenceRule    = errors.New("invalid recurrence rule")
+	ErrRecurrenceWithoutDueDate = errors.New("recurrence requires a due date")
+	ErrInvalidTag               = errors.New("invalid tag")
+	ErrBulkOperationRolledBack  = errors.New("operation rolled back")
+	ErrInvalidPatch             = errors.New("invalid patch")
+	ErrPatchTestFailed          = errors.New("patch test failed")
+	ErrDuplicatedExternalID     = errors.New("duplicated external id")
+	ErrInvalidImport            = errors.New("invalid import")
+	ErrImportTooLarge           = errors.New("import has too many rows")
+	ErrUnsupportedImportSource  = errors.New("unsupported import source")
+	ErrChecklistItemNotFound    = errors.New("checklist item not found")
 )
@@ -0,0 +1,10 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrTimeEntryNotFound   = errors.New("time entry not found")
+	ErrInvalidTimeRange    = errors.New("invalid time range")
+	ErrTimerAlreadyRunning = errors.New("timer already running")
+	ErrTimerNotRunning     = errors.New("timer not running")
+)
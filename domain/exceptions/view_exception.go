@@ -0,0 +1,8 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrViewNotFound       = errors.New("view not found")
+	ErrDuplicatedViewName = errors.New("duplicated view name")
+)
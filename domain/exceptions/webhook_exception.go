@@ -0,0 +1,10 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidDestination      = errors.New("webhook url must be a public http or https address")
+	ErrBlockedDestination      = errors.New("webhook destination is not a public address")
+)
@@ -0,0 +1,100 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Replay folds the events of one stream in version order and returns the task
+// as the last of them left it, or nil when the task did not exist by then
+func Replay(events []models.TaskStreamEvent) (*models.Task, error) {
+	var document []byte
+	for _, event := range events {
+		if event.Type == models.TaskEventDeleted {
+			document = nil
+			continue
+		}
+
+		if document == nil {
+			document = []byte("{}")
+		}
+
+		patched, err := jsonpatch.MergePatch(document, []byte(event.Data))
+		if err != nil {
+			return nil, err
+		}
+		document = patched
+	}
+
+	if document == nil {
+		return nil, nil
+	}
+
+	var task models.Task
+	if err := json.Unmarshal(document, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// Until returns the events which occurred at or before asOf
+func Until(events []models.TaskStreamEvent, asOf time.Time) []models.TaskStreamEvent {
+	until := []models.TaskStreamEvent{}
+	for _, event := range events {
+		if !event.OccurredAt.After(asOf) {
+			until = append(until, event)
+		}
+	}
+
+	return until
+}
+
+// Next returns the event appending the change to the stream, its data patches
+// the task as the stream leaves it into the task carried by the change
+func Next(events []models.TaskStreamEvent, change *models.TaskEvent) (*models.TaskStreamEvent, error) {
+	event := &models.TaskStreamEvent{
+		TaskID:     change.TaskID,
+		Version:    len(events) + 1,
+		Type:       change.Type,
+		Data:       "null",
+		OccurredAt: change.OccurredAt.UTC().Truncate(time.Microsecond),
+	}
+
+	if change.ActorID != "" {
+		event.ActorID = &change.ActorID
+	}
+
+	if change.Type == models.TaskEventDeleted {
+		return event, nil
+	}
+
+	previous, err := Replay(events)
+	if err != nil {
+		return nil, err
+	}
+
+	original := []byte("{}")
+	if previous != nil {
+		original, err = json.Marshal(previous)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	modified, err := json.Marshal(change.Task)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	event.Data = string(data)
+
+	return event, nil
+}
@@ -0,0 +1,23 @@
+package importers
+
+import (
+	"io"
+
+	"github.com/GraphZC/sdd-task-management/domain/transfer"
+)
+
+type Options struct {
+	// Project overrides the project derived from the export, if any
+	Project string
+}
+
+type Result struct {
+	Rows []transfer.Row
+
+	// Unmapped describes what the export had that has no place in a task
+	Unmapped []string
+}
+
+type Importer interface {
+	Parse(r io.Reader, options *Options) (*Result, error)
+}
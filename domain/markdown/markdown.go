@@ -0,0 +1,100 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+var ErrTaskItemNotFound = errors.New("task item not found")
+
+var renderer = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+)
+
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	// User generated content allow-list, plus the read-only task list checkboxes
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+
+	return p
+}
+
+// Render converts Markdown to HTML that is safe to embed in a page. Raw HTML in
+// the source is dropped by the renderer and the output is sanitised again.
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+
+	return policy.Sanitize(buf.String()), nil
+}
+
+// ToggleTaskItem checks or unchecks the task list item at index, counted in
+// document order like the checkboxes in the rendered HTML, and returns the
+// rewritten source with every other byte untouched.
+func ToggleTaskItem(source string, index int, checked bool) (string, error) {
+	if index < 0 {
+		return "", ErrTaskItemNotFound
+	}
+
+	mark := byte(' ')
+	if checked {
+		mark = 'x'
+	}
+
+	// Walk the same tree Render uses so quotes, code blocks and lazy lines
+	// count exactly as they do on the page
+	src := []byte(source)
+	document := renderer.Parser().Parse(text.NewReader(src))
+
+	count := 0
+	position := -1
+	err := ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || node.Kind() != extast.KindTaskCheckBox {
+			return ast.WalkContinue, nil
+		}
+
+		if count < index {
+			count++
+			return ast.WalkContinue, nil
+		}
+
+		// The checkbox opens the first line of its text block
+		lines := node.Parent().Lines()
+		if lines.Len() == 0 {
+			return ast.WalkStop, nil
+		}
+
+		segment := lines.At(0)
+		if open := bytes.IndexByte(src[segment.Start:segment.Stop], '['); open >= 0 {
+			position = segment.Start + open + 1
+		}
+
+		return ast.WalkStop, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if position < 0 {
+		return "", ErrTaskItemNotFound
+	}
+
+	src[position] = mark
+
+	return string(src), nil
+}
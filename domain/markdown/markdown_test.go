@@ -0,0 +1,46 @@
+package markdown_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/markdown"
+)
+
+func TestToggleTaskItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		index   int
+		checked bool
+		want    string
+	}{
+		{"FirstItem", "- [ ] one\n- [ ] two", 0, true, "- [x] one\n- [ ] two"},
+		{"Unchecks", "- [x] one\n- [X] two", 1, false, "- [x] one\n- [ ] two"},
+		{"OrderedList", "1. [ ] one\n2) [ ] two", 1, true, "1. [ ] one\n2) [x] two"},
+		{"KeepsCRLF", "- [ ] one\r\n- [ ] two\r\n", 1, true, "- [ ] one\r\n- [x] two\r\n"},
+		{"Blockquote", "> - [ ] quoted\n\n- [ ] real", 0, true, "> - [x] quoted\n\n- [ ] real"},
+		{"AfterBlockquote", "> - [ ] quoted\n\n- [ ] real", 1, true, "> - [ ] quoted\n\n- [x] real"},
+		{"NestedItem", "- [ ] parent\n  - [ ] child", 1, true, "- [ ] parent\n  - [x] child"},
+		{"SkipsFencedCode", "```\n- [ ] code\n```\n- [ ] real", 0, true, "```\n- [ ] code\n```\n- [x] real"},
+		{"SkipsIndentedCode", "    - [ ] code\n\n- [ ] real", 0, true, "    - [ ] code\n\n- [x] real"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := markdown.ToggleTaskItem(tt.source, tt.index, tt.checked)
+			if err != nil || got != tt.want {
+				t.Errorf("ToggleTaskItem(%q, %d) = %q, %v, want %q", tt.source, tt.index, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggleTaskItemNotFound(t *testing.T) {
+	// Plain list items and code are not task items
+	for _, index := range []int{-1, 1} {
+		if _, err := markdown.ToggleTaskItem("- [ ] one\n- two\n\n```\n- [ ] code\n```", index, true); !errors.Is(err, markdown.ErrTaskItemNotFound) {
+			t.Errorf("ToggleTaskItem(%d) = %v, want %v", index, err, markdown.ErrTaskItemNotFound)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+const (
+	NotificationTypeTaskAssigned      = "task.assigned"
+	NotificationTypeCommentMentioned  = "comment.mentioned"
+	NotificationTypeStatusChanged     = "task.status_changed"
+	NotificationTypeOccurrenceCreated = "task.occurrence_created"
+	NotificationTypeReminderDue       = "reminder.due"
+)
+
+var NotificationTypes = []string{
+	NotificationTypeTaskAssigned,
+	NotificationTypeCommentMentioned,
+	NotificationTypeStatusChanged,
+	NotificationTypeOccurrenceCreated,
+	NotificationTypeReminderDue,
+}
+
+type Notification struct {
+	ID        string     `json:"id" db:"id"`
+	UserID    string     `json:"userId" db:"user_id"`
+	TaskID    *string    `json:"taskId" db:"task_id"`
+	Type      string     `json:"type" db:"type"`
+	Title     string     `json:"title" db:"title"`
+	Body      string     `json:"body" db:"body"`
+	ReadAt    *time.Time `json:"readAt" db:"read_at"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+}
+
+type NotificationPreference struct {
+	UserID  string `json:"userId" db:"user_id"`
+	Type    string `json:"type" db:"type"`
+	Enabled bool   `json:"enabled" db:"enabled"`
+}
+
+// NotificationMessage is what a notifier delivers to a single recipient
+type NotificationMessage struct {
+	UserID    string `json:"userId"`
+	TaskID    string `json:"taskId"`
+	Type      string `json:"type"`
+	Recipient string `json:"-"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+}
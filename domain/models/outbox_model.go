@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	OutboxStatusPending    = "PENDING"
+	OutboxStatusPublishing = "PUBLISHING"
+	OutboxStatusPublished  = "PUBLISHED"
+)
+
+// OutboxMessage is a task event waiting to be published to other systems. Its
+// id is the idempotency key, a message published twice carries the same one.
+type OutboxMessage struct {
+	ID            string     `json:"id" db:"id"`
+	EventType     string     `json:"eventType" db:"event_type"`
+	TaskID        string     `json:"taskId" db:"task_id"`
+	Payload       string     `json:"payload" db:"payload"`
+	Status        string     `json:"status" db:"status"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	LastError     *string    `json:"lastError" db:"last_error"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt" db:"next_attempt_at"`
+	LockedUntil   *time.Time `json:"-" db:"locked_until"`
+	PublishedAt   *time.Time `json:"publishedAt" db:"published_at"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updatedAt" db:"updated_at"`
+}
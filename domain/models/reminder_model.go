@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	ReminderChannelEmail   = "EMAIL"
+	ReminderChannelWebhook = "WEBHOOK"
+	ReminderChannelInApp   = "IN_APP"
+)
+
+const (
+	ReminderStatusPending = "PENDING"
+	ReminderStatusSending = "SENDING"
+	ReminderStatusSent    = "SENT"
+	ReminderStatusFailed  = "FAILED"
+)
+
+type Reminder struct {
+	ID            string     `json:"id" db:"id"`
+	TaskID        string     `json:"taskId" db:"task_id"`
+	UserID        string     `json:"userId" db:"user_id"`
+	RemindAt      *time.Time `json:"remindAt" db:"remind_at"`
+	OffsetMinutes *int       `json:"offsetMinutes" db:"offset_minutes"`
+	Channel       string     `json:"channel" db:"channel"`
+	Target        *string    `json:"target" db:"target"`
+	Status        string     `json:"status" db:"status"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	LastError     *string    `json:"lastError" db:"last_error"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt" db:"next_attempt_at"`
+	LockedUntil   *string    `json:"-" db:"locked_until"`
+	SentAt        *time.Time `json:"sentAt" db:"sent_at"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updatedAt" db:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	StatsIntervalDay  = "day"
+	StatsIntervalWeek = "week"
+)
+
+type TaskStatusChange struct {
+	ID         string    `json:"id" db:"id"`
+	TaskID     string    `json:"taskId" db:"task_id"`
+	UserID     string    `json:"userId" db:"user_id"`
+	FromStatus string    `json:"fromStatus" db:"from_status"`
+	ToStatus   string    `json:"toStatus" db:"to_status"`
+	ChangedAt  time.Time `json:"changedAt" db:"changed_at"`
+}
+
+type StatsPeriod struct {
+	Period    string `json:"period" db:"period"`
+	Created   int64  `json:"created" db:"created"`
+	Completed int64  `json:"completed" db:"completed"`
+}
+
+type CycleTime struct {
+	AverageSeconds float64 `json:"averageSeconds" db:"average_seconds"`
+	Tasks          int64   `json:"tasks" db:"tasks"`
+}
+
+type PriorityCount struct {
+	Priority int   `json:"priority" db:"priority"`
+	Count    int64 `json:"count" db:"count"`
+}
+
+// StatsPeriodStart truncates t to the start of its day or ISO week (Monday)
+func StatsPeriodStart(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if interval != StatsIntervalWeek {
+		return day
+	}
+
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
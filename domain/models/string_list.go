@@ -0,0 +1,38 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// StringList is stored as a comma separated column
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
+
+func (l *StringList) Scan(src any) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*l = StringList{}
+		return nil
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", src)
+	}
+
+	if value == "" {
+		*l = StringList{}
+		return nil
+	}
+
+	*l = strings.Split(value, ",")
+
+	return nil
+}
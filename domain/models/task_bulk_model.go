@@ -0,0 +1,10 @@
+package models
+
+const (
+	TaskBulkActionUpdateStatus   = "update_status"
+	TaskBulkActionChangePriority = "change_priority"
+	TaskBulkActionAddTag         = "add_tag"
+	TaskBulkActionRemoveTag      = "remove_tag"
+	TaskBulkActionMoveProject    = "move_project"
+	TaskBulkActionDelete         = "delete"
+)
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type TaskComment struct {
+	ID        string    `json:"id" db:"id"`
+	TaskID    string    `json:"taskId" db:"task_id"`
+	UserID    string    `json:"userId" db:"user_id"`
+	Body      string    `json:"body" db:"body"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+type TaskFollower struct {
+	TaskID    string    `json:"taskId" db:"task_id"`
+	UserID    string    `json:"userId" db:"user_id"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+const (
+	TaskEventCreated       = "task.created"
+	TaskEventUpdated       = "task.updated"
+	TaskEventStatusChanged = "task.status_changed"
+	TaskEventDeleted       = "task.deleted"
+	TaskEventAssigned      = "task.assigned"
+	TaskEventCommented     = "task.commented"
+)
+
+type TaskEvent struct {
+	Type             string       `json:"type"`
+	TaskID           string       `json:"taskId"`
+	UserID           string       `json:"userId"`
+	ActorID          string       `json:"actorId"`
+	PreviousStatus   string       `json:"previousStatus,omitempty"`
+	Task             *Task        `json:"task"`
+	Comment          *TaskComment `json:"comment,omitempty"`
+	MentionedUserIDs []string     `json:"mentionedUserIds,omitempty"`
+	OccurredAt       time.Time    `json:"occurredAt"`
+}
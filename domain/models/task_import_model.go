@@ -0,0 +1,8 @@
+package models
+
+const (
+	TaskImportStatusCreated = "created"
+	TaskImportStatusValid   = "valid"
+	TaskImportStatusSkipped = "skipped"
+	TaskImportStatusFailed  = "failed"
+)
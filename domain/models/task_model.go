@@ -1,23 +1,35 @@
 package models
 
+import "time"
+
 const (
 	TaskStatusTodo      = "TODO"
 	TaskStatusCompleted = "COMPLETED"
 )
 
 const (
-	TaskPriorityLow    = 1
-	TaskPriorityMedium = 2
-	TaskPriorityHigh   = 3
+	TaskPriorityLow    = 0
+	TaskPriorityMedium = 1
+	TaskPriorityHigh   = 2
 )
 
 type Task struct {
-	ID          string `json:"id" db:"id"`
-	UserID      string `json:"userId" db:"user_id"`
-	Title       string `json:"title" db:"title"`
-	Description string `json:"description" db:"description"`
-	Priority    int    `json:"priority" db:"priority"`
-	Status      string `json:"status" db:"status"`
-	CreatedAt   string `json:"createdAt" db:"created_at"`
-	UpdatedAt   string `json:"updatedAt" db:"updated_at"`
+	ID                 string     `json:"id" db:"id"`
+	UserID             string     `json:"userId" db:"user_id"`
+	Title              string     `json:"title" db:"title"`
+	Description        string     `json:"description" db:"description"`
+	DescriptionHTML    string     `json:"descriptionHtml" db:"description_html"`
+	Priority           int        `json:"priority" db:"priority"`
+	Status             string     `json:"status" db:"status"`
+	DueDate            *time.Time `json:"dueDate" db:"due_date"`
+	RecurrenceRule     *string    `json:"recurrenceRule" db:"recurrence_rule"`
+	RecurrenceTimezone *string    `json:"recurrenceTimezone" db:"recurrence_timezone"`
+	SeriesID           *string    `json:"seriesId" db:"series_id"`
+	ProjectID          *string    `json:"projectId" db:"project_id"`
+	ExternalID         *string    `json:"externalId" db:"external_id"`
+	EstimateMinutes    *int       `json:"estimateMinutes" db:"estimate_minutes"`
+	AssigneeID         *string    `json:"assigneeId" db:"assignee_id"`
+	Tags               []string   `json:"tags" db:"-"`
+	CreatedAt          time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updatedAt" db:"updated_at"`
 }
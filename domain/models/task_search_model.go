@@ -0,0 +1,8 @@
+package models
+
+type TaskSearchResult struct {
+	Task           Task    `json:"task"`
+	Score          float64 `json:"score"`
+	TitleHighlight string  `json:"titleHighlight"`
+	Snippet        string  `json:"snippet"`
+}
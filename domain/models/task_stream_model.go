@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TaskStreamEvent is one change in the event stream of a task. Data is a JSON
+// merge patch from the task as the previous version left it to the task as
+// this one leaves it, the first version patches an empty document.
+type TaskStreamEvent struct {
+	ID         string    `json:"id" db:"id"`
+	TaskID     string    `json:"taskId" db:"task_id"`
+	Version    int       `json:"version" db:"version"`
+	Type       string    `json:"type" db:"event_type"`
+	ActorID    *string   `json:"actorId" db:"actor_id"`
+	Data       string    `json:"data" db:"data"`
+	OccurredAt time.Time `json:"occurredAt" db:"occurred_at"`
+}
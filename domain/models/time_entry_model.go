@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	TimesheetGroupByDay     = "day"
+	TimesheetGroupByProject = "project"
+	TimesheetGroupByTag     = "tag"
+)
+
+type TimeEntry struct {
+	ID              string     `json:"id" db:"id"`
+	TaskID          string     `json:"taskId" db:"task_id"`
+	UserID          string     `json:"userId" db:"user_id"`
+	StartedAt       time.Time  `json:"startedAt" db:"started_at"`
+	EndedAt         *time.Time `json:"endedAt" db:"ended_at"`
+	DurationSeconds int64      `json:"durationSeconds" db:"duration_seconds"`
+	Note            string     `json:"note" db:"note"`
+	CreatedAt       time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+type TimesheetRow struct {
+	Group   string `json:"group" db:"group_key"`
+	Seconds int64  `json:"seconds" db:"seconds"`
+	Entries int    `json:"entries" db:"entries"`
+}
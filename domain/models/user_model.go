@@ -1,10 +1,14 @@
 package models
 
+import "time"
+
 type User struct {
-	ID        string `json:"id" db:"id"`
-	Name      string `json:"name" db:"name"`
-	Email     string `json:"email" db:"email"`
-	Password  string `json:"password" db:"password"`
-	CreatedAt string `json:"createdAt" db:"created_at"`
-	UpdatedAt string `json:"updatedAt" db:"updated_at"`
+	ID            string    `json:"id" db:"id"`
+	Name          string    `json:"name" db:"name"`
+	Email         string    `json:"email" db:"email"`
+	Password      string    `json:"password" db:"password"`
+	CalendarToken *string   `json:"-" db:"calendar_token"`
+	Timezone      string    `json:"timezone" db:"timezone"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time `json:"updatedAt" db:"updated_at"`
 }
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	WebhookDeliveryStatusPending   = "PENDING"
+	WebhookDeliveryStatusSending   = "SENDING"
+	WebhookDeliveryStatusSucceeded = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    = "FAILED"
+)
+
+type Webhook struct {
+	ID        string     `json:"id" db:"id"`
+	UserID    string     `json:"userId" db:"user_id"`
+	URL       string     `json:"url" db:"url"`
+	Secret    string     `json:"-" db:"secret"`
+	Events    StringList `json:"events" db:"events"`
+	Active    bool       `json:"active" db:"active"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             string     `json:"id" db:"id"`
+	WebhookID      string     `json:"webhookId" db:"webhook_id"`
+	EventType      string     `json:"eventType" db:"event_type"`
+	Payload        string     `json:"payload" db:"payload"`
+	Status         string     `json:"status" db:"status"`
+	Attempts       int        `json:"attempts" db:"attempts"`
+	ResponseStatus *int       `json:"responseStatus" db:"response_status"`
+	LastError      *string    `json:"lastError" db:"last_error"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt" db:"next_attempt_at"`
+	LockedUntil    *string    `json:"-" db:"locked_until"`
+	DeliveredAt    *time.Time `json:"deliveredAt" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updatedAt" db:"updated_at"`
+}
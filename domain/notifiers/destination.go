@@ -0,0 +1,55 @@
+package notifiers
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+)
+
+// reservedPrefixes are ranges the netip helpers do not cover, "this network"
+// and the carrier-grade NAT range which also holds some metadata services
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// ValidateDestination checks rawURL is an http or https URL whose host is not
+// a private address. Names are only resolved when sending, so senders check
+// the resolved address again with PublicAddr
+func ValidateDestination(rawURL string) error {
+	destination, err := url.Parse(rawURL)
+	if err != nil || (destination.Scheme != "http" && destination.Scheme != "https") || destination.Hostname() == "" {
+		return exceptions.ErrInvalidDestination
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(destination.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return exceptions.ErrInvalidDestination
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !PublicAddr(addr) {
+		return exceptions.ErrInvalidDestination
+	}
+
+	return nil
+}
+
+// PublicAddr reports whether addr is outside the loopback, private, link-local
+// and reserved ranges. Link-local holds cloud metadata services such as
+// 169.254.169.254
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return false
+	}
+
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,64 @@
+package notifiers_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+)
+
+func TestValidateDestination(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{url: "https://example.com/hooks", valid: true},
+		{url: "http://93.184.216.34:8080/hooks", valid: true},
+		{url: "ftp://example.com/hooks"},
+		{url: "file:///etc/passwd"},
+		{url: "https:///hooks"},
+		{url: "not a url"},
+		{url: "http://localhost/hooks"},
+		{url: "http://api.localhost./hooks"},
+		{url: "http://127.0.0.1/hooks"},
+		{url: "http://10.1.2.3/hooks"},
+		{url: "http://172.16.0.1/hooks"},
+		{url: "http://192.168.1.1/hooks"},
+		{url: "http://169.254.169.254/latest/meta-data/"},
+		{url: "http://100.100.100.200/"},
+		{url: "http://0.0.0.0/"},
+		{url: "http://[::1]/hooks"},
+		{url: "http://[fd00:ec2::254]/"},
+		{url: "http://[::ffff:127.0.0.1]/hooks"},
+	}
+
+	for _, tt := range tests {
+		err := notifiers.ValidateDestination(tt.url)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateDestination(%q) = %v, want nil", tt.url, err)
+		}
+
+		if !tt.valid && !errors.Is(err, exceptions.ErrInvalidDestination) {
+			t.Errorf("ValidateDestination(%q) = %v, want %v", tt.url, err, exceptions.ErrInvalidDestination)
+		}
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"224.0.0.1":       false,
+		"255.255.255.255": false,
+		"::":              false,
+	} {
+		if got := notifiers.PublicAddr(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("PublicAddr(%s) = %t, want %t", addr, got, want)
+		}
+	}
+}
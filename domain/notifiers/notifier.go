@@ -0,0 +1,11 @@
+package notifiers
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, msg *models.NotificationMessage) error
+}
@@ -0,0 +1,8 @@
+package notifiers
+
+import "context"
+
+type WebhookSender interface {
+	// Send posts a signed payload and returns the response status code
+	Send(ctx context.Context, url string, secret string, eventType string, deliveryID string, payload []byte) (int, error)
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// Match evaluates the query against a task for stores that can't run SQL
+func (q *Query) Match(task *models.Task, now time.Time) bool {
+	for i := range q.Conditions {
+		if q.Conditions[i].match(task, now) == q.Conditions[i].Negated() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Condition) match(task *models.Task, now time.Time) bool {
+	switch c.Field {
+	case FieldText:
+		return strings.Contains(strings.ToLower(task.Title), c.Value) || strings.Contains(strings.ToLower(task.Description), c.Value)
+	case FieldTitle:
+		return strings.Contains(strings.ToLower(task.Title), c.Value)
+	case FieldStatus:
+		return task.Status == c.Value
+	case FieldTag:
+		return contains(task.Tags, c.Value)
+	case FieldRecurring:
+		return (task.RecurrenceRule != nil) == c.Bool()
+	case FieldPriority:
+		return compareInt(task.Priority, c.Operator, c.Int())
+	case FieldDue:
+		return c.matchTime(task.DueDate, now)
+	case FieldCreated:
+		return c.matchTime(&task.CreatedAt, now)
+	case FieldUpdated:
+		return c.matchTime(&task.UpdatedAt, now)
+	}
+
+	return false
+}
+
+func (c *Condition) matchTime(value *time.Time, now time.Time) bool {
+	switch c.Value {
+	case ValueNone:
+		return value == nil
+	case ValueAny:
+		return value != nil
+	}
+
+	if value == nil {
+		return false
+	}
+
+	for _, comparison := range c.TimeComparisons(now) {
+		if !compareTime(*value, comparison.Operator, comparison.Time) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compareInt(value int, operator Operator, other int) bool {
+	switch operator {
+	case OperatorGreater:
+		return value > other
+	case OperatorGreaterEqual:
+		return value >= other
+	case OperatorLess:
+		return value < other
+	case OperatorLessEqual:
+		return value <= other
+	default:
+		return value == other
+	}
+}
+
+func compareTime(value time.Time, operator Operator, other time.Time) bool {
+	switch operator {
+	case OperatorGreater:
+		return value.After(other)
+	case OperatorGreaterEqual:
+		return !value.Before(other)
+	case OperatorLess:
+		return value.Before(other)
+	case OperatorLessEqual:
+		return !value.After(other)
+	default:
+		return value.Equal(other)
+	}
+}
@@ -0,0 +1,291 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+type Operator string
+
+const (
+	OperatorEqual        Operator = ":"
+	OperatorNotEqual     Operator = "!="
+	OperatorGreater      Operator = ">"
+	OperatorGreaterEqual Operator = ">="
+	OperatorLess         Operator = "<"
+	OperatorLessEqual    Operator = "<="
+)
+
+const (
+	FieldText      = "text"
+	FieldTitle     = "title"
+	FieldStatus    = "status"
+	FieldPriority  = "priority"
+	FieldTag       = "tag"
+	FieldDue       = "due"
+	FieldCreated   = "created"
+	FieldUpdated   = "updated"
+	FieldRecurring = "recurring"
+)
+
+const (
+	ValueNone = "none"
+	ValueAny  = "any"
+)
+
+// Longest operators first so ">=" wins over ">"
+var operators = []Operator{OperatorGreaterEqual, OperatorLessEqual, OperatorNotEqual, OperatorEqual, "=", OperatorGreater, OperatorLess}
+
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    string
+	Negate   bool
+}
+
+type Query struct {
+	Conditions []Condition
+	Sort       []SortField
+}
+
+// Parse reads a filter such as `status:TODO priority>=2 due<7d tag:backend "release notes"`.
+// Terms are ANDed, a leading "-" negates a term and words without a field match title or description.
+// Dates are either YYYY-MM-DD or an offset from now such as 7d, -2w or 12h.
+func Parse(input string) (*Query, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	for _, token := range tokens {
+		condition, err := parseCondition(token)
+		if err != nil {
+			return nil, err
+		}
+
+		q.Conditions = append(q.Conditions, condition)
+	}
+
+	return q, nil
+}
+
+func tokenize(input string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("%w: unterminated quote", exceptions.ErrInvalidQuery)
+	}
+
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+func parseCondition(token string) (Condition, error) {
+	condition := Condition{}
+	if strings.HasPrefix(token, "-") && len(token) > 1 {
+		condition.Negate = true
+		token = token[1:]
+	}
+
+	// Bare words and quoted phrases search the text
+	index := strings.IndexAny(token, ":=!<>")
+	if index <= 0 || strings.HasPrefix(token, "\"") {
+		condition.Field = FieldText
+		condition.Operator = OperatorEqual
+		condition.Value = strings.ToLower(unquote(token))
+		return condition, nil
+	}
+
+	condition.Field = strings.ToLower(token[:index])
+	for _, operator := range operators {
+		if strings.HasPrefix(token[index:], string(operator)) {
+			condition.Operator = operator
+			condition.Value = unquote(token[index+len(operator):])
+			break
+		}
+	}
+
+	if condition.Operator == "=" {
+		condition.Operator = OperatorEqual
+	}
+
+	if condition.Operator == "" || condition.Value == "" {
+		return condition, fmt.Errorf("%w: cannot parse %q", exceptions.ErrInvalidQuery, token)
+	}
+
+	return condition, condition.validate()
+}
+
+func (c *Condition) validate() error {
+	equality := c.Operator == OperatorEqual || c.Operator == OperatorNotEqual
+
+	switch c.Field {
+	case FieldTitle, FieldTag:
+		if !equality {
+			return c.operatorError()
+		}
+		c.Value = strings.ToLower(c.Value)
+	case FieldStatus:
+		if !equality {
+			return c.operatorError()
+		}
+		c.Value = strings.ToUpper(c.Value)
+		if c.Value != models.TaskStatusTodo && c.Value != models.TaskStatusCompleted {
+			return fmt.Errorf("%w: unknown status %q", exceptions.ErrInvalidQuery, c.Value)
+		}
+	case FieldPriority:
+		if _, err := strconv.Atoi(c.Value); err != nil {
+			return fmt.Errorf("%w: priority must be a number", exceptions.ErrInvalidQuery)
+		}
+	case FieldRecurring:
+		if !equality {
+			return c.operatorError()
+		}
+		if _, err := strconv.ParseBool(c.Value); err != nil {
+			return fmt.Errorf("%w: recurring must be true or false", exceptions.ErrInvalidQuery)
+		}
+	case FieldDue, FieldCreated, FieldUpdated:
+		c.Value = strings.ToLower(c.Value)
+		if c.Value == ValueNone || c.Value == ValueAny {
+			if !equality {
+				return c.operatorError()
+			}
+			return nil
+		}
+		if _, _, err := c.Bounds(time.Now()); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown field %q", exceptions.ErrInvalidQuery, c.Field)
+	}
+
+	return nil
+}
+
+func (c *Condition) operatorError() error {
+	return fmt.Errorf("%w: %s does not support %s", exceptions.ErrInvalidQuery, c.Field, c.Operator)
+}
+
+// Int returns the numeric value of a priority condition
+func (c *Condition) Int() int {
+	value, _ := strconv.Atoi(c.Value)
+	return value
+}
+
+// Bool returns the value of a recurring condition
+func (c *Condition) Bool() bool {
+	value, _ := strconv.ParseBool(c.Value)
+	return value
+}
+
+// Bounds resolves a date condition against now. A calendar date covers the
+// whole day in now's location, an offset such as 7d is a single instant so
+// start equals end.
+func (c *Condition) Bounds(now time.Time) (time.Time, time.Time, error) {
+	if date, err := time.ParseInLocation(time.DateOnly, c.Value, now.Location()); err == nil {
+		return date.UTC(), date.AddDate(0, 0, 1).UTC(), nil
+	}
+
+	value := c.Value
+	if value == "now" || value == "today" {
+		value = "0d"
+	}
+
+	if len(value) < 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: cannot parse date %q", exceptions.ErrInvalidQuery, c.Value)
+	}
+
+	amount, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: cannot parse date %q", exceptions.ErrInvalidQuery, c.Value)
+	}
+
+	var instant time.Time
+	switch value[len(value)-1] {
+	case 'h':
+		instant = now.Add(time.Duration(amount) * time.Hour)
+	case 'd':
+		instant = now.AddDate(0, 0, amount)
+	case 'w':
+		instant = now.AddDate(0, 0, 7*amount)
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: cannot parse date %q", exceptions.ErrInvalidQuery, c.Value)
+	}
+
+	return instant.UTC(), instant.UTC(), nil
+}
+
+// Comparison is a single bound a date condition expands to
+type Comparison struct {
+	Operator Operator
+	Time     time.Time
+}
+
+// TimeComparisons expands a date condition into bounds that are ANDed together.
+// Equality matches the day the value falls on, "!=" is handled by the caller negating it.
+func (c *Condition) TimeComparisons(now time.Time) []Comparison {
+	start, end, _ := c.Bounds(now)
+	if start.Equal(end) && (c.Operator == OperatorEqual || c.Operator == OperatorNotEqual) {
+		local := start.In(now.Location())
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, now.Location())
+		start, end = day.UTC(), day.AddDate(0, 0, 1).UTC()
+	}
+
+	switch c.Operator {
+	case OperatorLess:
+		return []Comparison{{OperatorLess, start}}
+	case OperatorLessEqual:
+		if start.Equal(end) {
+			return []Comparison{{OperatorLessEqual, end}}
+		}
+		return []Comparison{{OperatorLess, end}}
+	case OperatorGreater:
+		if start.Equal(end) {
+			return []Comparison{{OperatorGreater, start}}
+		}
+		return []Comparison{{OperatorGreaterEqual, end}}
+	case OperatorGreaterEqual:
+		return []Comparison{{OperatorGreaterEqual, start}}
+	default:
+		return []Comparison{{OperatorGreaterEqual, start}, {OperatorLess, end}}
+	}
+}
+
+// Negated reports whether the condition matches the opposite of its comparison
+func (c *Condition) Negated() bool {
+	return c.Negate != (c.Operator == OperatorNotEqual)
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
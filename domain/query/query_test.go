@@ -0,0 +1,217 @@
+package query_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []query.Condition
+	}{
+		{
+			name:  "Empty",
+			input: "  ",
+		},
+		{
+			name:  "Fields",
+			input: "status:todo priority>=2 tag:Backend recurring=true",
+			want: []query.Condition{
+				{Field: query.FieldStatus, Operator: query.OperatorEqual, Value: models.TaskStatusTodo},
+				{Field: query.FieldPriority, Operator: query.OperatorGreaterEqual, Value: "2"},
+				{Field: query.FieldTag, Operator: query.OperatorEqual, Value: "backend"},
+				{Field: query.FieldRecurring, Operator: query.OperatorEqual, Value: "true"},
+			},
+		},
+		{
+			name:  "TextAndPhrase",
+			input: `Release "Release Notes" title:"Q3 plan"`,
+			want: []query.Condition{
+				{Field: query.FieldText, Operator: query.OperatorEqual, Value: "release"},
+				{Field: query.FieldText, Operator: query.OperatorEqual, Value: "release notes"},
+				{Field: query.FieldTitle, Operator: query.OperatorEqual, Value: "q3 plan"},
+			},
+		},
+		{
+			name:  "Negation",
+			input: "-tag:done status!=COMPLETED -",
+			want: []query.Condition{
+				{Field: query.FieldTag, Operator: query.OperatorEqual, Value: "done", Negate: true},
+				{Field: query.FieldStatus, Operator: query.OperatorNotEqual, Value: models.TaskStatusCompleted},
+				{Field: query.FieldText, Operator: query.OperatorEqual, Value: "-"},
+			},
+		},
+		{
+			name:  "Dates",
+			input: "due<7d created:2030-01-02 updated:NONE due:any",
+			want: []query.Condition{
+				{Field: query.FieldDue, Operator: query.OperatorLess, Value: "7d"},
+				{Field: query.FieldCreated, Operator: query.OperatorEqual, Value: "2030-01-02"},
+				{Field: query.FieldUpdated, Operator: query.OperatorEqual, Value: query.ValueNone},
+				{Field: query.FieldDue, Operator: query.OperatorEqual, Value: query.ValueAny},
+			},
+		},
+		{
+			// Values are data, quotes and SQL in them stay in the value
+			name:  "InjectionShaped",
+			input: `"x' OR 1=1 --" tag:a');DROP title:"%_\"`,
+			want: []query.Condition{
+				{Field: query.FieldText, Operator: query.OperatorEqual, Value: "x' or 1=1 --"},
+				{Field: query.FieldTag, Operator: query.OperatorEqual, Value: "a');drop"},
+				{Field: query.FieldTitle, Operator: query.OperatorEqual, Value: `%_\`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := query.Parse(tt.input)
+			if err != nil || !reflect.DeepEqual(q.Conditions, tt.want) {
+				t.Fatalf("Parse(%q) = %+v, %v, want %+v", tt.input, q, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidQueries(t *testing.T) {
+	for _, input := range []string{
+		`"unterminated`,
+		"status:DOING",
+		"status>TODO",
+		"priority:high",
+		"tag>a",
+		"recurring:maybe",
+		"due:tomorrow",
+		"due>none",
+		"due:7y",
+		"owner:me",
+		"title:",
+		"1=1;DROP:x",
+		"priority:1)OR(1=1",
+	} {
+		if _, err := query.Parse(input); !errors.Is(err, exceptions.ErrInvalidQuery) {
+			t.Errorf("Parse(%q) = %v, want %v", input, err, exceptions.ErrInvalidQuery)
+		}
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	fields, err := query.ParseSort(" -Priority, due ,,title")
+	want := []query.SortField{{Field: query.FieldPriority, Descending: true}, {Field: query.FieldDue}, {Field: query.FieldTitle}}
+	if err != nil || !reflect.DeepEqual(fields, want) {
+		t.Fatalf("ParseSort = %+v, %v, want %+v", fields, err, want)
+	}
+
+	for _, input := range []string{"owner", "priority;DROP TABLE tasks", "due desc", "-"} {
+		if _, err := query.ParseSort(input); !errors.Is(err, exceptions.ErrInvalidSort) {
+			t.Errorf("ParseSort(%q) = %v, want %v", input, err, exceptions.ErrInvalidSort)
+		}
+	}
+}
+
+func TestTimeComparisons(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2030, 1, 2, 10, 0, 0, 0, tokyo)
+	dayStart := time.Date(2030, 1, 2, 0, 0, 0, 0, tokyo).UTC()
+	dayEnd := time.Date(2030, 1, 3, 0, 0, 0, 0, tokyo).UTC()
+
+	tests := []struct {
+		input string
+		want  []query.Comparison
+	}{
+		{input: "due:2030-01-02", want: []query.Comparison{{Operator: query.OperatorGreaterEqual, Time: dayStart}, {Operator: query.OperatorLess, Time: dayEnd}}},
+		{input: "due<2030-01-02", want: []query.Comparison{{Operator: query.OperatorLess, Time: dayStart}}},
+		{input: "due<=2030-01-02", want: []query.Comparison{{Operator: query.OperatorLess, Time: dayEnd}}},
+		{input: "due>2030-01-02", want: []query.Comparison{{Operator: query.OperatorGreaterEqual, Time: dayEnd}}},
+		{input: "due>=2030-01-02", want: []query.Comparison{{Operator: query.OperatorGreaterEqual, Time: dayStart}}},
+		{input: "due<12h", want: []query.Comparison{{Operator: query.OperatorLess, Time: now.Add(12 * time.Hour).UTC()}}},
+		{input: "due>-1w", want: []query.Comparison{{Operator: query.OperatorGreater, Time: now.AddDate(0, 0, -7).UTC()}}},
+		{input: "due:today", want: []query.Comparison{{Operator: query.OperatorGreaterEqual, Time: dayStart}, {Operator: query.OperatorLess, Time: dayEnd}}},
+	}
+
+	for _, tt := range tests {
+		q, err := query.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+
+		if got := q.Conditions[0].TimeComparisons(now); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TimeComparisons(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+	due := time.Date(2030, 1, 5, 9, 0, 0, 0, time.UTC)
+	rule := "DAILY"
+	task := models.Task{
+		Title:          "Release notes",
+		Description:    "Write 100% of them",
+		Status:         models.TaskStatusTodo,
+		Priority:       models.TaskPriorityHigh,
+		Tags:           []string{"backend"},
+		DueDate:        &due,
+		RecurrenceRule: &rule,
+		CreatedAt:      now.AddDate(0, 0, -1),
+	}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "notes", want: true},
+		{input: "100%", want: true},
+		{input: "-notes", want: false},
+		{input: "title:release status:todo priority>1 tag:backend recurring:true", want: true},
+		{input: "status!=TODO", want: false},
+		{input: "-status!=TODO", want: true},
+		{input: "priority<2", want: false},
+		{input: "due<7d due>2d due:2030-01-05", want: true},
+		{input: "due:none", want: false},
+		{input: "created:2030-01-01 updated:none", want: false},
+		{input: "tag:frontend", want: false},
+	}
+
+	for _, tt := range tests {
+		q, err := query.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+
+		if got := q.Match(&task, now); got != tt.want {
+			t.Errorf("Match(%q) = %t, want %t", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSortTasks(t *testing.T) {
+	created := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	due := created.AddDate(0, 0, 3)
+	tasks := []models.Task{
+		{ID: "no-due", Priority: models.TaskPriorityHigh, CreatedAt: created},
+		{ID: "low", Priority: models.TaskPriorityLow, DueDate: &due, CreatedAt: created},
+		{ID: "high-later", Priority: models.TaskPriorityHigh, DueDate: &due, CreatedAt: created.Add(time.Hour)},
+		{ID: "high", Priority: models.TaskPriorityHigh, DueDate: &due, CreatedAt: created},
+	}
+
+	query.SortTasks(tasks, []query.SortField{{Field: query.FieldDue, Descending: true}, {Field: query.FieldPriority, Descending: true}})
+
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	// Tasks without a due date come last even descending
+	if want := []string{"high", "high-later", "low", "no-due"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("SortTasks = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+var sortFields = []string{FieldPriority, FieldDue, FieldCreated, FieldUpdated, FieldTitle, FieldStatus}
+
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSort reads a comma separated list such as "-priority,due", a leading "-" sorts descending
+func ParseSort(input string) ([]SortField, error) {
+	var fields []SortField
+	for _, part := range strings.Split(input, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Descending: strings.HasPrefix(part, "-")}
+		if !contains(sortFields, field.Field) {
+			return nil, fmt.Errorf("%w: unknown field %q", exceptions.ErrInvalidSort, field.Field)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// SortTasks orders tasks the way the SQL adapters do, tasks without a due date come last
+func SortTasks(tasks []models.Task, fields []SortField) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, field := range fields {
+			result := compareTasks(&tasks[i], &tasks[j], field.Field)
+			if result == 0 {
+				continue
+			}
+
+			if field.Field == FieldDue && (tasks[i].DueDate == nil || tasks[j].DueDate == nil) {
+				return result < 0
+			}
+
+			if field.Descending {
+				return result > 0
+			}
+			return result < 0
+		}
+
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+func compareTasks(a *models.Task, b *models.Task, field string) int {
+	switch field {
+	case FieldPriority:
+		return a.Priority - b.Priority
+	case FieldDue:
+		switch {
+		case a.DueDate == nil && b.DueDate == nil:
+			return 0
+		case a.DueDate == nil:
+			return 1
+		case b.DueDate == nil:
+			return -1
+		}
+		return a.DueDate.Compare(*b.DueDate)
+	case FieldCreated:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case FieldUpdated:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case FieldTitle:
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case FieldStatus:
+		return strings.Compare(a.Status, b.Status)
+	}
+
+	return 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
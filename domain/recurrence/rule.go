@@ -0,0 +1,292 @@
+package recurrence
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// Upper bound of periods searched when looking for the next occurrence
+const maxSearchPeriods = 1200
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Rule is the subset of an RFC 5545 RRULE supported by tasks:
+// FREQ, INTERVAL, BYDAY (weekly), BYMONTHDAY (monthly) and UNTIL.
+type Rule struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Until      *time.Time
+	Location   *time.Location
+}
+
+// Parse accepts either a preset (DAILY, WEEKLY, MONTHLY, YEARLY) or an RRULE
+// such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH" evaluated in the given timezone.
+func Parse(rule string, timezone string) (*Rule, error) {
+	loc := time.UTC
+	if timezone != "" {
+		l, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, exceptions.ErrInvalidRecurrenceRule
+		}
+		loc = l
+	}
+
+	rule = strings.ToUpper(strings.TrimSpace(rule))
+	rule = strings.TrimPrefix(rule, "RRULE:")
+	if rule == "" {
+		return nil, exceptions.ErrInvalidRecurrenceRule
+	}
+
+	// Presets are shorthand for a bare frequency
+	if !strings.Contains(rule, "=") {
+		rule = "FREQ=" + rule
+	}
+
+	r := &Rule{Interval: 1, Location: loc}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, exceptions.ErrInvalidRecurrenceRule
+		}
+
+		switch key {
+		case "FREQ":
+			switch Frequency(value) {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				r.Frequency = Frequency(value)
+			default:
+				return nil, exceptions.ErrInvalidRecurrenceRule
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, exceptions.ErrInvalidRecurrenceRule
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return nil, exceptions.ErrInvalidRecurrenceRule
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, exceptions.ErrInvalidRecurrenceRule
+				}
+				r.ByMonthDay = append(r.ByMonthDay, monthDay)
+			}
+		case "UNTIL":
+			until, err := parseUntil(value, loc)
+			if err != nil {
+				return nil, exceptions.ErrInvalidRecurrenceRule
+			}
+			r.Until = &until
+		default:
+			return nil, exceptions.ErrInvalidRecurrenceRule
+		}
+	}
+
+	if r.Frequency == "" {
+		return nil, exceptions.ErrInvalidRecurrenceRule
+	}
+
+	if len(r.ByDay) > 0 && r.Frequency != FrequencyWeekly {
+		return nil, exceptions.ErrInvalidRecurrenceRule
+	}
+
+	if len(r.ByMonthDay) > 0 && r.Frequency != FrequencyMonthly {
+		return nil, exceptions.ErrInvalidRecurrenceRule
+	}
+
+	return r, nil
+}
+
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t, nil
+	}
+
+	// A bare date includes the whole day
+	t, err := time.ParseInLocation("20060102", value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+// String returns the normalised RRULE representation of the rule.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, monthDay := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(monthDay))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after the given one, keeping its
+// wall-clock time in the rule's timezone. It returns false once UNTIL is passed.
+func (r *Rule) Next(after time.Time) (time.Time, bool) {
+	start := after.In(r.Location)
+
+	var next time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		next = r.date(start, start.Year(), start.Month(), start.Day()+r.Interval)
+	case FrequencyWeekly:
+		next = r.nextWeekly(start)
+	case FrequencyMonthly:
+		next = r.nextMonthly(start)
+	case FrequencyYearly:
+		next = r.nextYearly(start)
+	}
+
+	if next.IsZero() {
+		return time.Time{}, false
+	}
+
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+
+	return next.UTC(), true
+}
+
+func (r *Rule) nextWeekly(start time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return r.date(start, start.Year(), start.Month(), start.Day()+7*r.Interval)
+	}
+
+	startWeek := weekStart(start)
+	for i := 1; i <= 7*(r.Interval+1); i++ {
+		candidate := r.date(start, start.Year(), start.Month(), start.Day()+i)
+		weeks := daysBetween(startWeek, weekStart(candidate)) / 7
+		if weeks%r.Interval == 0 && containsWeekday(r.ByDay, candidate.Weekday()) {
+			return candidate
+		}
+	}
+
+	return time.Time{}
+}
+
+func (r *Rule) nextMonthly(start time.Time) time.Time {
+	monthDays := r.ByMonthDay
+	if len(monthDays) == 0 {
+		monthDays = []int{start.Day()}
+	}
+
+	for offset := 0; offset < maxSearchPeriods; offset += r.Interval {
+		first := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, r.Location)
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+
+		// Resolve negative days from the end of the month and skip days the month doesn't have
+		days := make([]int, 0, len(monthDays))
+		for _, day := range monthDays {
+			if day < 0 {
+				day = daysInMonth + day + 1
+			}
+			if day >= 1 && day <= daysInMonth {
+				days = append(days, day)
+			}
+		}
+		sort.Ints(days)
+
+		for _, day := range days {
+			candidate := r.date(start, first.Year(), first.Month(), day)
+			if candidate.After(start) {
+				return candidate
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+func (r *Rule) nextYearly(start time.Time) time.Time {
+	// Years without the day, such as Feb 29, are skipped rather than normalised
+	for offset := r.Interval; offset < maxSearchPeriods; offset += r.Interval {
+		candidate := r.date(start, start.Year()+offset, start.Month(), start.Day())
+		if candidate.Month() == start.Month() && candidate.Day() == start.Day() {
+			return candidate
+		}
+	}
+
+	return time.Time{}
+}
+
+func (r *Rule) date(clock time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), 0, r.Location)
+}
+
+func weekStart(t time.Time) time.Time {
+	// Weeks start on Monday (RFC 5545 default WKST)
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
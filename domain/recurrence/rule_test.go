@@ -0,0 +1,133 @@
+package recurrence_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/recurrence"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule     string
+		timezone string
+		want     string
+	}{
+		{"daily", "", "FREQ=DAILY"},
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1", "", "FREQ=MONTHLY;BYMONTHDAY=1,-1"},
+		{"FREQ=DAILY;INTERVAL=1", "", "FREQ=DAILY"},
+		{"FREQ=DAILY;UNTIL=20300101T120000Z", "", "FREQ=DAILY;UNTIL=20300101T120000Z"},
+		// Local and bare UNTIL values are read in the rule's timezone, a date includes the whole day
+		{"FREQ=DAILY;UNTIL=20300101T120000", "Asia/Tokyo", "FREQ=DAILY;UNTIL=20300101T030000Z"},
+		{"FREQ=DAILY;UNTIL=20300101", "Asia/Tokyo", "FREQ=DAILY;UNTIL=20300101T145959Z"},
+	}
+
+	for _, tt := range tests {
+		rule, err := recurrence.Parse(tt.rule, tt.timezone)
+		if err != nil || rule.String() != tt.want {
+			t.Errorf("Parse(%q, %q) = %v, %v, want %s", tt.rule, tt.timezone, rule, err, tt.want)
+		}
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		rule     string
+		timezone string
+	}{
+		{"", ""},
+		{"HOURLY", ""},
+		{"INTERVAL=2", ""},
+		{"FREQ=DAILY;INTERVAL=0", ""},
+		{"FREQ=DAILY;COUNT=3", ""},
+		{"FREQ=DAILY;BYDAY=MO", ""},
+		{"FREQ=WEEKLY;BYDAY=XX", ""},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", ""},
+		{"FREQ=MONTHLY;BYMONTHDAY=0", ""},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", ""},
+		{"FREQ=DAILY;UNTIL=tomorrow", ""},
+		{"FREQ=DAILY;", ""},
+		{"DAILY", "Mars/Olympus"},
+	}
+
+	for _, tt := range tests {
+		if _, err := recurrence.Parse(tt.rule, tt.timezone); !errors.Is(err, exceptions.ErrInvalidRecurrenceRule) {
+			t.Errorf("Parse(%q, %q) = %v, want %v", tt.rule, tt.timezone, err, exceptions.ErrInvalidRecurrenceRule)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		timezone string
+		after    string
+		want     []string
+	}{
+		{"Daily", "DAILY", "", "2030-01-30 09:00:00", []string{"2030-01-31 09:00:00", "2030-02-01 09:00:00"}},
+		{"Weekly", "WEEKLY", "", "2030-01-01 09:00:00", []string{"2030-01-08 09:00:00", "2030-01-15 09:00:00"}},
+		// 2030-01-01 is a Tuesday, every other week on Monday and Thursday
+		{"WeeklyByDay", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "", "2030-01-01 09:00:00", []string{"2030-01-03 09:00:00", "2030-01-14 09:00:00", "2030-01-17 09:00:00", "2030-01-28 09:00:00"}},
+		{"WeeklyAcrossTheYear", "FREQ=WEEKLY;BYDAY=MO", "", "2030-12-30 09:00:00", []string{"2031-01-06 09:00:00"}},
+		// Months without the day are skipped rather than clamped
+		{"MonthlyOn31st", "MONTHLY", "", "2030-01-31 09:00:00", []string{"2030-03-31 09:00:00", "2030-05-31 09:00:00"}},
+		{"MonthlyLastDay", "FREQ=MONTHLY;BYMONTHDAY=-1", "", "2030-01-31 09:00:00", []string{"2030-02-28 09:00:00", "2030-03-31 09:00:00", "2030-04-30 09:00:00"}},
+		{"MonthlyLeapYear", "FREQ=MONTHLY;BYMONTHDAY=29", "", "2031-12-29 09:00:00", []string{"2032-01-29 09:00:00", "2032-02-29 09:00:00", "2032-03-29 09:00:00"}},
+		{"MonthlyInterval", "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15", "", "2030-11-15 09:00:00", []string{"2031-02-15 09:00:00"}},
+		{"Yearly", "YEARLY", "", "2030-06-15 09:00:00", []string{"2031-06-15 09:00:00"}},
+		// Years without Feb 29 are skipped, the series stays on the leap day
+		{"YearlyLeapDay", "YEARLY", "", "2028-02-29 09:00:00", []string{"2032-02-29 09:00:00", "2036-02-29 09:00:00"}},
+		{"YearlyLeapDayInterval", "FREQ=YEARLY;INTERVAL=3", "", "2028-02-29 09:00:00", []string{"2040-02-29 09:00:00"}},
+		// Berlin switches to summer time on 2030-03-31, the wall clock stays at 09:00
+		{"DaylightSaving", "DAILY", "Europe/Berlin", "2030-03-30 08:00:00", []string{"2030-03-31 07:00:00", "2030-04-01 07:00:00"}},
+		// Weekdays are those of the timezone, Monday 09:00 in Auckland is Sunday in UTC
+		{"WeeklyByDayInTimezone", "FREQ=WEEKLY;BYDAY=MO", "Pacific/Auckland", "2030-01-05 20:00:00", []string{"2030-01-06 20:00:00", "2030-01-13 20:00:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := recurrence.Parse(tt.rule, tt.timezone)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			current := date(tt.after)
+			for _, want := range tt.want {
+				next, ok := rule.Next(current)
+				if !ok || !next.Equal(date(want)) {
+					t.Fatalf("Next(%s) = %s, %v, want %s", current, next, ok, want)
+				}
+				current = next
+			}
+		})
+	}
+}
+
+func TestNextStopsAtUntil(t *testing.T) {
+	rule, err := recurrence.Parse("FREQ=DAILY;UNTIL=20300102", "")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	// The last day is included
+	if next, ok := rule.Next(date("2030-01-01 09:00:00")); !ok || !next.Equal(date("2030-01-02 09:00:00")) {
+		t.Errorf("Next = %s, %v, want 2030-01-02 09:00", next, ok)
+	}
+
+	if next, ok := rule.Next(date("2030-01-02 09:00:00")); ok {
+		t.Errorf("Next after the last day = %s, want the series ended", next)
+	}
+}
+
+func date(value string) time.Time {
+	t, err := time.Parse(time.DateTime, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) (string, error)
+	FindByID(ctx context.Context, notificationID string) (*models.Notification, error)
+	FindByUserID(ctx context.Context, userID string, unreadOnly bool, limit int, offset int) ([]models.Notification, error)
+	CountByUserID(ctx context.Context, userID string, unreadOnly bool) (int, error)
+	MarkReadByID(ctx context.Context, notificationID string, readAt time.Time) error
+	MarkAllReadByUserID(ctx context.Context, userID string, readAt time.Time) (int, error)
+	FindPreferencesByUserID(ctx context.Context, userID string) ([]models.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, userID string, notificationType string, enabled bool) error
+}
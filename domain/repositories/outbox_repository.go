@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, eventType string, taskID string, payload string) (string, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]models.OutboxMessage, error)
+	Claim(ctx context.Context, messageID string, now time.Time, lockedUntil time.Time) (bool, error)
+	MarkPublished(ctx context.Context, messageID string, publishedAt time.Time) error
+	Reschedule(ctx context.Context, messageID string, lastError string, nextAttemptAt time.Time) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type ProjectRepository interface {
+	Create(ctx context.Context, req *requests.ProjectCreateRequest, userID string) (string, error)
+	FindByID(ctx context.Context, projectID string) (*models.Project, error)
+	FindByUserID(ctx context.Context, userID string) ([]models.Project, error)
+	FindByName(ctx context.Context, userID string, name string) (*models.Project, error)
+	DeleteByID(ctx context.Context, projectID string) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type ReminderRepository interface {
+	Create(ctx context.Context, req *requests.ReminderCreateRequest, taskID string, userID string) (string, error)
+	FindByID(ctx context.Context, reminderID string) (*models.Reminder, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]models.Reminder, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error)
+	DeleteByID(ctx context.Context, reminderID string) error
+	Claim(ctx context.Context, reminderID string, now time.Time, lockedUntil time.Time) (bool, error)
+	MarkSent(ctx context.Context, reminderID string, sentAt time.Time) error
+	Reschedule(ctx context.Context, reminderID string, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, reminderID string, lastError string) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// ReportingRepository buckets periods by the days of from's location
+type ReportingRepository interface {
+	CountByPeriod(ctx context.Context, userID string, from time.Time, to time.Time, interval string) ([]models.StatsPeriod, error)
+	AverageCycleTime(ctx context.Context, userID string, from time.Time, to time.Time) (*models.CycleTime, error)
+	CountOpenByPriority(ctx context.Context, userID string) ([]models.PriorityCount, error)
+	CountOverdue(ctx context.Context, userID string, now time.Time) (int64, error)
+}
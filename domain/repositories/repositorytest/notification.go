@@ -0,0 +1,81 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+)
+
+// RunNotificationRepository checks a NotificationRepository sharing a database
+// with the repositories returned by newRepos
+func RunNotificationRepository(t *testing.T, newRepos Factory, notificationRepo repositories.NotificationRepository) {
+	ctx := context.Background()
+
+	t.Run("MarkReadNarrowsUnread", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+
+		var ids []string
+		for _, title := range []string{"First", "Second", "Third"} {
+			id, err := notificationRepo.Create(ctx, &models.Notification{UserID: user.ID, Type: models.NotificationTypeReminderDue, Title: title, Body: "Body"})
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			ids = append(ids, id)
+		}
+
+		if err := notificationRepo.MarkReadByID(ctx, ids[0], time.Now()); err != nil {
+			t.Fatalf("MarkReadByID: %v", err)
+		}
+
+		if count, err := notificationRepo.CountByUserID(ctx, user.ID, true); err != nil || count != 2 {
+			t.Errorf("CountByUserID(unread) = %d, %v, want 2, nil", count, err)
+		}
+
+		if count, err := notificationRepo.CountByUserID(ctx, user.ID, false); err != nil || count != 3 {
+			t.Errorf("CountByUserID(all) = %d, %v, want 3, nil", count, err)
+		}
+
+		// Newest first
+		unread, err := notificationRepo.FindByUserID(ctx, user.ID, true, 10, 0)
+		if err != nil || len(unread) != 2 || unread[0].ID != ids[2] || unread[1].ID != ids[1] {
+			t.Errorf("FindByUserID(unread) = %+v, %v, want the third and second notification", unread, err)
+		}
+
+		page, err := notificationRepo.FindByUserID(ctx, user.ID, false, 1, 1)
+		if err != nil || len(page) != 1 || page[0].ID != ids[1] {
+			t.Errorf("FindByUserID(limit 1, offset 1) = %+v, %v, want the second notification", page, err)
+		}
+
+		marked, err := notificationRepo.MarkAllReadByUserID(ctx, user.ID, time.Now())
+		if err != nil || marked != 2 {
+			t.Errorf("MarkAllReadByUserID = %d, %v, want 2, nil", marked, err)
+		}
+
+		notification, err := notificationRepo.FindByID(ctx, ids[2])
+		if err != nil || notification == nil || notification.ReadAt == nil || !recent(*notification.ReadAt) {
+			t.Errorf("FindByID = %+v, %v, want it read just now", notification, err)
+		}
+	})
+
+	t.Run("UpsertPreferenceReplaces", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+
+		if err := notificationRepo.UpsertPreference(ctx, user.ID, models.NotificationTypeReminderDue, false); err != nil {
+			t.Fatalf("UpsertPreference: %v", err)
+		}
+
+		if err := notificationRepo.UpsertPreference(ctx, user.ID, models.NotificationTypeReminderDue, true); err != nil {
+			t.Fatalf("UpsertPreference again: %v", err)
+		}
+
+		preferences, err := notificationRepo.FindPreferencesByUserID(ctx, user.ID)
+		if err != nil || len(preferences) != 1 || !preferences[0].Enabled {
+			t.Errorf("FindPreferencesByUserID = %+v, %v, want the one preference enabled", preferences, err)
+		}
+	})
+}
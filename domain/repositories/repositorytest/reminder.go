@@ -0,0 +1,147 @@
+package repositorytest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+// RunReminderRepository checks a ReminderRepository sharing a database with
+// the repositories returned by newRepos
+func RunReminderRepository(t *testing.T, newRepos Factory, reminderRepo repositories.ReminderRepository) {
+	ctx := context.Background()
+
+	t.Run("OffsetFollowsDueDate", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Due", DueDate: date("2030-01-01 10:00:00")})
+
+		offsetID := newReminder(t, reminderRepo, task, &requests.ReminderCreateRequest{OffsetMinutes: pointer(30), Channel: models.ReminderChannelInApp})
+		absoluteID := newReminder(t, reminderRepo, task, &requests.ReminderCreateRequest{RemindAt: date("2030-01-01 09:45:00"), Channel: models.ReminderChannelInApp})
+
+		if due := dueReminderIDs(t, reminderRepo, *date("2030-01-01 09:29:59")); slices.Contains(due, offsetID) || slices.Contains(due, absoluteID) {
+			t.Errorf("FindDue before 09:30 = %v, want neither reminder", due)
+		}
+
+		if due := dueReminderIDs(t, reminderRepo, *date("2030-01-01 09:30:00")); !slices.Contains(due, offsetID) || slices.Contains(due, absoluteID) {
+			t.Errorf("FindDue at 09:30 = %v, want only the offset reminder %s", due, offsetID)
+		}
+
+		if due := dueReminderIDs(t, reminderRepo, *date("2030-01-01 09:45:00")); !slices.Contains(due, offsetID) || !slices.Contains(due, absoluteID) {
+			t.Errorf("FindDue at 09:45 = %v, want both reminders", due)
+		}
+
+		// Completed tasks remind nobody
+		if err := taskRepo.UpdateStatusByID(ctx, task.ID, models.TaskStatusCompleted); err != nil {
+			t.Fatalf("UpdateStatusByID: %v", err)
+		}
+
+		if due := dueReminderIDs(t, reminderRepo, *date("2030-01-01 09:45:00")); slices.Contains(due, offsetID) || slices.Contains(due, absoluteID) {
+			t.Errorf("FindDue after completing = %v, want neither reminder", due)
+		}
+	})
+
+	t.Run("ClaimLeasesReminder", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Due"})
+		reminderID := newReminder(t, reminderRepo, task, &requests.ReminderCreateRequest{RemindAt: date("2030-01-01 09:00:00"), Channel: models.ReminderChannelInApp})
+
+		now := *date("2030-01-01 09:00:00")
+		if claimed, err := reminderRepo.Claim(ctx, reminderID, now, now.Add(time.Minute)); err != nil || !claimed {
+			t.Fatalf("Claim = %v, %v, want true, nil", claimed, err)
+		}
+
+		if claimed, err := reminderRepo.Claim(ctx, reminderID, now.Add(30*time.Second), now.Add(2*time.Minute)); err != nil || claimed {
+			t.Errorf("Claim while leased = %v, %v, want false, nil", claimed, err)
+		}
+
+		if due := dueReminderIDs(t, reminderRepo, now.Add(30*time.Second)); slices.Contains(due, reminderID) {
+			t.Errorf("FindDue while leased = %v, want the reminder left out", due)
+		}
+
+		// An expired lease is picked up again
+		later := now.Add(2 * time.Minute)
+		if due := dueReminderIDs(t, reminderRepo, later); !slices.Contains(due, reminderID) {
+			t.Errorf("FindDue after the lease = %v, want %s", due, reminderID)
+		}
+
+		if claimed, err := reminderRepo.Claim(ctx, reminderID, later, later.Add(time.Minute)); err != nil || !claimed {
+			t.Fatalf("Claim after the lease = %v, %v, want true, nil", claimed, err)
+		}
+
+		reminder, err := reminderRepo.FindByID(ctx, reminderID)
+		if err != nil || reminder == nil || reminder.Status != models.ReminderStatusSending || reminder.Attempts != 2 {
+			t.Fatalf("FindByID = %+v, %v, want SENDING after two attempts", reminder, err)
+		}
+	})
+
+	t.Run("RescheduleAndMarkSent", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Due"})
+		reminderID := newReminder(t, reminderRepo, task, &requests.ReminderCreateRequest{RemindAt: date("2030-01-01 09:00:00"), Channel: models.ReminderChannelInApp})
+
+		now := *date("2030-01-01 09:00:00")
+		if _, err := reminderRepo.Claim(ctx, reminderID, now, now.Add(time.Minute)); err != nil {
+			t.Fatalf("Claim: %v", err)
+		}
+
+		if err := reminderRepo.Reschedule(ctx, reminderID, "smtp down", now.Add(10*time.Minute)); err != nil {
+			t.Fatalf("Reschedule: %v", err)
+		}
+
+		if due := dueReminderIDs(t, reminderRepo, now.Add(5*time.Minute)); slices.Contains(due, reminderID) {
+			t.Errorf("FindDue before the next attempt = %v, want the reminder left out", due)
+		}
+
+		if due := dueReminderIDs(t, reminderRepo, now.Add(10*time.Minute)); !slices.Contains(due, reminderID) {
+			t.Errorf("FindDue at the next attempt = %v, want %s", due, reminderID)
+		}
+
+		if err := reminderRepo.MarkSent(ctx, reminderID, now.Add(10*time.Minute)); err != nil {
+			t.Fatalf("MarkSent: %v", err)
+		}
+
+		reminder, err := reminderRepo.FindByID(ctx, reminderID)
+		if err != nil || reminder == nil || reminder.Status != models.ReminderStatusSent || !equalTime(reminder.SentAt, pointer(now.Add(10*time.Minute))) || reminder.LastError != nil {
+			t.Errorf("FindByID = %+v, %v, want SENT at 09:10 without an error", reminder, err)
+		}
+
+		if due := dueReminderIDs(t, reminderRepo, now.Add(time.Hour)); slices.Contains(due, reminderID) {
+			t.Errorf("FindDue after sending = %v, want the reminder left out", due)
+		}
+	})
+}
+
+func newReminder(t *testing.T, reminderRepo repositories.ReminderRepository, task *models.Task, req *requests.ReminderCreateRequest) string {
+	t.Helper()
+
+	reminderID, err := reminderRepo.Create(context.Background(), req, task.ID, task.UserID)
+	if err != nil {
+		t.Fatalf("Create reminder: %v", err)
+	}
+
+	return reminderID
+}
+
+func dueReminderIDs(t *testing.T, reminderRepo repositories.ReminderRepository, now time.Time) []string {
+	t.Helper()
+
+	reminders, err := reminderRepo.FindDue(context.Background(), now, 1000)
+	if err != nil {
+		t.Fatalf("FindDue: %v", err)
+	}
+
+	ids := make([]string, len(reminders))
+	for i := range reminders {
+		ids[i] = reminders[i].ID
+	}
+
+	return ids
+}
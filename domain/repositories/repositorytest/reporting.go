@@ -0,0 +1,92 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+// RunReportingRepository checks a ReportingRepository reading the tasks and
+// status history written by the repositories returned by newRepos
+func RunReportingRepository(t *testing.T, newRepos Factory, reportingRepo repositories.ReportingRepository) {
+	ctx := context.Background()
+
+	t.Run("CountsByLocalPeriod", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Done"})
+		newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Open"})
+		if err := taskRepo.UpdateStatusByID(ctx, task.ID, models.TaskStatusCompleted); err != nil {
+			t.Fatalf("UpdateStatusByID: %v", err)
+		}
+
+		// The zones far apart put now on different dates
+		for _, name := range []string{"UTC", "Pacific/Kiritimati", "Etc/GMT+12"} {
+			location, err := time.LoadLocation(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			now := time.Now().In(location)
+			for _, interval := range []string{models.StatsIntervalDay, models.StatsIntervalWeek} {
+				from := models.StatsPeriodStart(now, interval).AddDate(0, 0, -7)
+				periods, err := reportingRepo.CountByPeriod(ctx, user.ID, from, from.AddDate(0, 0, 21), interval)
+
+				want := models.StatsPeriod{Period: models.StatsPeriodStart(now, interval).Format(time.DateOnly), Created: 2, Completed: 1}
+				if err != nil || len(periods) != 1 || periods[0] != want {
+					t.Errorf("CountByPeriod(%s, %s) = %+v, %v, want [%+v]", name, interval, periods, err, want)
+				}
+			}
+		}
+	})
+
+	t.Run("CycleTimeFromFirstCompletion", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Reopened"})
+		for _, status := range []string{models.TaskStatusCompleted, models.TaskStatusTodo, models.TaskStatusCompleted} {
+			if err := taskRepo.UpdateStatusByID(ctx, task.ID, status); err != nil {
+				t.Fatalf("UpdateStatusByID: %v", err)
+			}
+		}
+
+		now := time.Now()
+		cycleTime, err := reportingRepo.AverageCycleTime(ctx, user.ID, now.Add(-time.Hour), now.Add(time.Hour))
+		if err != nil || cycleTime.Tasks != 1 || cycleTime.AverageSeconds < 0 || cycleTime.AverageSeconds > 60 {
+			t.Errorf("AverageCycleTime = %+v, %v, want one task done within a minute", cycleTime, err)
+		}
+
+		// Completions outside the range are left out
+		cycleTime, err = reportingRepo.AverageCycleTime(ctx, user.ID, now.Add(time.Hour), now.Add(2*time.Hour))
+		if err != nil || cycleTime.Tasks != 0 || cycleTime.AverageSeconds != 0 {
+			t.Errorf("AverageCycleTime(later) = %+v, %v, want no tasks", cycleTime, err)
+		}
+	})
+
+	t.Run("OpenAndOverdue", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Late", Priority: models.TaskPriorityHigh, DueDate: date("2020-01-01 00:00:00")})
+		newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Later", Priority: models.TaskPriorityHigh, DueDate: date("2040-01-01 00:00:00")})
+		done := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Done", Priority: models.TaskPriorityLow, DueDate: date("2020-01-01 00:00:00")})
+		if err := taskRepo.UpdateStatusByID(ctx, done.ID, models.TaskStatusCompleted); err != nil {
+			t.Fatalf("UpdateStatusByID: %v", err)
+		}
+
+		counts, err := reportingRepo.CountOpenByPriority(ctx, user.ID)
+		if err != nil || len(counts) != 1 || counts[0] != (models.PriorityCount{Priority: models.TaskPriorityHigh, Count: 2}) {
+			t.Errorf("CountOpenByPriority = %+v, %v, want two open high priority tasks", counts, err)
+		}
+
+		if overdue, err := reportingRepo.CountOverdue(ctx, user.ID, time.Now()); err != nil || overdue != 1 {
+			t.Errorf("CountOverdue = %d, %v, want 1, nil", overdue, err)
+		}
+	})
+}
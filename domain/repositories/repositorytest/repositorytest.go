@@ -0,0 +1,139 @@
+// Package repositorytest is a conformance suite for the repositories. Every
+// adapter runs it from its own tests, so behaviour the use cases rely on, such
+// as a missing row being nil, nil, is pinned down once.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/google/uuid"
+)
+
+// Factory returns the repositories under test. The suite creates a fresh user
+// for every case, so repositories may share one database between calls.
+type Factory func(t *testing.T) (repositories.UserRepository, repositories.TaskRepository)
+
+// Run runs the whole suite against the repositories returned by newRepos
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("User", func(t *testing.T) {
+		RunUserRepository(t, newRepos)
+	})
+	t.Run("Task", func(t *testing.T) {
+		RunTaskRepository(t, newRepos)
+	})
+}
+
+// newUser registers a user with a unique email and returns it
+func newUser(t *testing.T, userRepo repositories.UserRepository) *models.User {
+	t.Helper()
+
+	ctx := context.Background()
+	email := fmt.Sprintf("%s@example.com", uuid.NewString())
+
+	if err := userRepo.Create(ctx, &requests.UserRegisterRequest{Name: "Test User", Email: email, Password: "hashed"}); err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	user, err := userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		t.Fatalf("FindByEmail: %v", err)
+	}
+
+	if user == nil {
+		t.Fatalf("FindByEmail(%q) = nil after Create", email)
+	}
+
+	return user
+}
+
+// newTask creates a task for the user from the given request
+func newTask(t *testing.T, taskRepo repositories.TaskRepository, userID string, req *requests.TaskCreateRequest) *models.Task {
+	t.Helper()
+
+	if req.Description == "" {
+		req.Description = "Description"
+	}
+
+	task, err := taskRepo.Create(context.Background(), req, userID)
+	if err != nil {
+		t.Fatalf("Create task: %v", err)
+	}
+
+	return task
+}
+
+func mustFindTask(t *testing.T, taskRepo repositories.TaskRepository, taskID string) *models.Task {
+	t.Helper()
+
+	task, err := taskRepo.FindByID(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("FindByID(%q): %v", taskID, err)
+	}
+
+	if task == nil {
+		t.Fatalf("FindByID(%q) = nil", taskID)
+	}
+
+	return task
+}
+
+func taskIDs(tasks []models.Task) []string {
+	ids := make([]string, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+	}
+
+	return ids
+}
+
+func date(value string) *time.Time {
+	t, err := time.Parse(time.DateTime, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return &t
+}
+
+func equalPointer[T comparable](a *T, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// equalTime compares instants, drivers return them in different locations
+func equalTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// recent reports whether a stored timestamp is the current time, which it is
+// not when the database and the driver disagree about its time zone
+func recent(value time.Time) bool {
+	return time.Since(value).Abs() < time.Minute
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+func sameIDs(got []string, want ...string) bool {
+	got = slices.Clone(got)
+	want = slices.Clone(want)
+	slices.Sort(got)
+	slices.Sort(want)
+
+	return slices.Equal(got, want)
+}
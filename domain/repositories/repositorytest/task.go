@@ -0,0 +1,496 @@
+package repositorytest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/google/uuid"
+)
+
+// RunTaskRepository checks a TaskRepository
+func RunTaskRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateReturnsStoredTask", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{
+			Title:           "Write report",
+			Priority:        models.TaskPriorityHigh,
+			DueDate:         date("2030-01-02 15:04:05"),
+			Tags:            []string{"work", "backend"},
+			ExternalID:      pointer("ext-1"),
+			EstimateMinutes: pointer(30),
+		})
+
+		if created.ID == "" || created.UserID != user.ID || created.Status != models.TaskStatusTodo {
+			t.Fatalf("Create = %+v, want a TODO task for user %s", created, user.ID)
+		}
+
+		if !equalTime(created.DueDate, date("2030-01-02 15:04:05")) {
+			t.Errorf("DueDate = %v, want 2030-01-02 15:04:05", created.DueDate)
+		}
+
+		if !slices.Equal(created.Tags, []string{"backend", "work"}) {
+			t.Errorf("Tags = %v, want them sorted", created.Tags)
+		}
+
+		if !recent(created.CreatedAt) {
+			t.Errorf("CreatedAt = %v, want the current time", created.CreatedAt)
+		}
+
+		if created.SeriesID != nil {
+			t.Errorf("SeriesID = %v, want nil without a recurrence rule", *created.SeriesID)
+		}
+
+		// Create returns what a read gives back
+		found := mustFindTask(t, taskRepo, created.ID)
+		if found.Title != created.Title || found.Priority != created.Priority || !found.CreatedAt.Equal(created.CreatedAt) ||
+			!equalTime(found.DueDate, created.DueDate) || !equalPointer(found.ExternalID, created.ExternalID) ||
+			!equalPointer(found.EstimateMinutes, created.EstimateMinutes) || !slices.Equal(found.Tags, created.Tags) {
+			t.Errorf("FindByID = %+v, want %+v", found, created)
+		}
+	})
+
+	t.Run("TagsAreNeverNil", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "No tags"})
+		if created.Tags == nil {
+			t.Error("Create returned nil Tags, want an empty list")
+		}
+
+		if found := mustFindTask(t, taskRepo, created.ID); found.Tags == nil {
+			t.Error("FindByID returned nil Tags, want an empty list")
+		}
+	})
+
+	t.Run("RecurringTaskStartsSeries", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Standup", DueDate: date("2030-01-01 09:00:00"), RecurrenceRule: "FREQ=DAILY"})
+		if !equalPointer(created.SeriesID, &created.ID) {
+			t.Errorf("SeriesID = %v, want the task's own id %s", created.SeriesID, created.ID)
+		}
+	})
+
+	t.Run("NotFoundIsNil", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		task, err := taskRepo.FindByID(ctx, uuid.NewString())
+		if task != nil || err != nil {
+			t.Errorf("FindByID(unknown) = %v, %v, want nil, nil", task, err)
+		}
+
+		task, err = taskRepo.FindByExternalID(ctx, user.ID, "missing")
+		if task != nil || err != nil {
+			t.Errorf("FindByExternalID(unknown) = %v, %v, want nil, nil", task, err)
+		}
+
+		task, err = taskRepo.FindLatestBySeriesID(ctx, uuid.NewString())
+		if task != nil || err != nil {
+			t.Errorf("FindLatestBySeriesID(unknown) = %v, %v, want nil, nil", task, err)
+		}
+
+		tasks, err := taskRepo.FindByUserID(ctx, user.ID)
+		if len(tasks) != 0 || err != nil {
+			t.Errorf("FindByUserID(no tasks) = %v, %v, want no tasks", tasks, err)
+		}
+	})
+
+	t.Run("FindByUserAndIDs", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		other := newUser(t, userRepo)
+
+		first := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "First"})
+		second := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Second"})
+		third := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Third"})
+		foreign := newTask(t, taskRepo, other.ID, &requests.TaskCreateRequest{Title: "Foreign"})
+
+		tasks, err := taskRepo.FindByUserID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("FindByUserID: %v", err)
+		}
+
+		if !sameIDs(taskIDs(tasks), first.ID, second.ID, third.ID) {
+			t.Errorf("FindByUserID = %v, want only the user's tasks", taskIDs(tasks))
+		}
+
+		tasks, err = taskRepo.FindByIDs(ctx, []string{first.ID, foreign.ID, uuid.NewString()})
+		if err != nil {
+			t.Fatalf("FindByIDs: %v", err)
+		}
+
+		if !sameIDs(taskIDs(tasks), first.ID, foreign.ID) {
+			t.Errorf("FindByIDs = %v, want the existing tasks only", taskIDs(tasks))
+		}
+
+		tasks, err = taskRepo.FindByIDs(ctx, nil)
+		if len(tasks) != 0 || err != nil {
+			t.Errorf("FindByIDs(nil) = %v, %v, want no tasks", tasks, err)
+		}
+	})
+
+	t.Run("FindByUserIDAfterPagesInCreationOrder", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		var want []string
+		for _, title := range []string{"One", "Two", "Three"} {
+			want = append(want, newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: title}).ID)
+		}
+
+		page, err := taskRepo.FindByUserIDAfter(ctx, user.ID, "", 2)
+		if err != nil {
+			t.Fatalf("FindByUserIDAfter: %v", err)
+		}
+
+		if !slices.Equal(taskIDs(page), want[:2]) {
+			t.Fatalf("first page = %v, want %v", taskIDs(page), want[:2])
+		}
+
+		page, err = taskRepo.FindByUserIDAfter(ctx, user.ID, want[1], 2)
+		if err != nil {
+			t.Fatalf("FindByUserIDAfter: %v", err)
+		}
+
+		if !slices.Equal(taskIDs(page), want[2:]) {
+			t.Errorf("second page = %v, want %v", taskIDs(page), want[2:])
+		}
+	})
+
+	t.Run("FindByExternalIDIsPerUser", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		other := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Imported", ExternalID: pointer("ext-1")})
+
+		found, err := taskRepo.FindByExternalID(ctx, user.ID, "ext-1")
+		if err != nil || found == nil || found.ID != created.ID {
+			t.Errorf("FindByExternalID = %v, %v, want task %s", found, err, created.ID)
+		}
+
+		found, err = taskRepo.FindByExternalID(ctx, other.ID, "ext-1")
+		if found != nil || err != nil {
+			t.Errorf("FindByExternalID(other user) = %v, %v, want nil, nil", found, err)
+		}
+	})
+
+	t.Run("FindByQuery", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		low := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Low backend", Priority: models.TaskPriorityLow, Tags: []string{"backend"}})
+		high := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "High backend", Priority: models.TaskPriorityHigh, Tags: []string{"backend"}})
+		newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Frontend", Priority: models.TaskPriorityHigh, Tags: []string{"frontend"}})
+		done := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Done backend", Tags: []string{"backend"}})
+
+		if err := taskRepo.UpdateStatusByID(ctx, done.ID, models.TaskStatusCompleted); err != nil {
+			t.Fatalf("UpdateStatusByID: %v", err)
+		}
+
+		q, err := query.Parse("status:TODO tag:backend")
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+
+		if q.Sort, err = query.ParseSort("-priority"); err != nil {
+			t.Fatalf("ParseSort: %v", err)
+		}
+
+		tasks, err := taskRepo.FindByQuery(ctx, user.ID, q, time.Now())
+		if err != nil {
+			t.Fatalf("FindByQuery: %v", err)
+		}
+
+		if !slices.Equal(taskIDs(tasks), []string{high.ID, low.ID}) {
+			t.Errorf("FindByQuery = %v, want %v", taskIDs(tasks), []string{high.ID, low.ID})
+		}
+	})
+
+	t.Run("UpdateByUD", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Before", Tags: []string{"keep"}})
+
+		// Tags stay when the request leaves them out, adding a rule starts a series
+		err := taskRepo.UpdateByUD(ctx, created.ID, &requests.TaskUpdateRequest{
+			Title:          "After",
+			Description:    "Changed",
+			Priority:       models.TaskPriorityHigh,
+			DueDate:        date("2030-02-01 08:00:00"),
+			RecurrenceRule: "FREQ=WEEKLY",
+		})
+		if err != nil {
+			t.Fatalf("UpdateByUD: %v", err)
+		}
+
+		found := mustFindTask(t, taskRepo, created.ID)
+		if found.Title != "After" || found.Description != "Changed" || found.Priority != models.TaskPriorityHigh {
+			t.Errorf("FindByID = %+v, want the updated fields", found)
+		}
+
+		if !equalTime(found.DueDate, date("2030-02-01 08:00:00")) || !equalPointer(found.RecurrenceRule, pointer("FREQ=WEEKLY")) {
+			t.Errorf("DueDate, RecurrenceRule = %v, %v, want the updated values", found.DueDate, found.RecurrenceRule)
+		}
+
+		if !equalPointer(found.SeriesID, &created.ID) {
+			t.Errorf("SeriesID = %v, want %s", found.SeriesID, created.ID)
+		}
+
+		if !slices.Equal(found.Tags, []string{"keep"}) {
+			t.Errorf("Tags = %v, want [keep]", found.Tags)
+		}
+
+		// Removing the rule clears it
+		err = taskRepo.UpdateByUD(ctx, created.ID, &requests.TaskUpdateRequest{Title: "After", Description: "Changed", Priority: models.TaskPriorityHigh, Tags: []string{}})
+		if err != nil {
+			t.Fatalf("UpdateByUD: %v", err)
+		}
+
+		found = mustFindTask(t, taskRepo, created.ID)
+		if found.RecurrenceRule != nil || found.DueDate != nil || len(found.Tags) != 0 {
+			t.Errorf("FindByID = %+v, want no rule, due date or tags", found)
+		}
+	})
+
+	t.Run("PatchByIDWritesOnlyGivenFields", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Before", Priority: models.TaskPriorityLow, Tags: []string{"old"}})
+
+		err := taskRepo.PatchByID(ctx, created.ID, &requests.TaskUpdateRequest{Title: "After", Priority: models.TaskPriorityHigh, Tags: []string{"new"}}, []string{"title", "tags"})
+		if err != nil {
+			t.Fatalf("PatchByID: %v", err)
+		}
+
+		found := mustFindTask(t, taskRepo, created.ID)
+		if found.Title != "After" || found.Priority != models.TaskPriorityLow || !slices.Equal(found.Tags, []string{"new"}) {
+			t.Errorf("FindByID = %+v, want title and tags patched only", found)
+		}
+	})
+
+	t.Run("UpdateStatusAndDelete", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Finish me"})
+
+		if err := taskRepo.UpdateStatusByID(ctx, created.ID, models.TaskStatusCompleted); err != nil {
+			t.Fatalf("UpdateStatusByID: %v", err)
+		}
+
+		if found := mustFindTask(t, taskRepo, created.ID); found.Status != models.TaskStatusCompleted {
+			t.Errorf("Status = %s, want %s", found.Status, models.TaskStatusCompleted)
+		}
+
+		if err := taskRepo.DeleteByID(ctx, created.ID); err != nil {
+			t.Fatalf("DeleteByID: %v", err)
+		}
+
+		found, err := taskRepo.FindByID(ctx, created.ID)
+		if found != nil || err != nil {
+			t.Errorf("FindByID(deleted) = %v, %v, want nil, nil", found, err)
+		}
+
+		// Deleting twice is not an error
+		if err := taskRepo.DeleteByID(ctx, created.ID); err != nil {
+			t.Errorf("DeleteByID(deleted): %v", err)
+		}
+	})
+
+	t.Run("Occurrences", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		first := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Standup", DueDate: date("2020-01-01 09:00:00"), RecurrenceRule: "FREQ=DAILY", Tags: []string{"daily"}})
+		stale := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Stale", DueDate: date("2020-01-01 09:00:00"), RecurrenceRule: "FREQ=DAILY"})
+		future := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Future", DueDate: date("2099-01-01 09:00:00"), RecurrenceRule: "FREQ=DAILY"})
+
+		occurrenceID, err := taskRepo.CreateOccurrence(ctx, first, *date("2020-01-02 09:00:00"))
+		if err != nil {
+			t.Fatalf("CreateOccurrence: %v", err)
+		}
+
+		occurrence := mustFindTask(t, taskRepo, occurrenceID)
+		if occurrence.Status != models.TaskStatusTodo || !equalPointer(occurrence.SeriesID, first.SeriesID) ||
+			!equalTime(occurrence.DueDate, date("2020-01-02 09:00:00")) || !slices.Equal(occurrence.Tags, first.Tags) {
+			t.Errorf("occurrence = %+v, want a TODO copy in series %v", occurrence, first.SeriesID)
+		}
+
+		latest, err := taskRepo.FindLatestBySeriesID(ctx, *first.SeriesID)
+		if err != nil || latest == nil || latest.ID != occurrenceID {
+			t.Errorf("FindLatestBySeriesID = %v, %v, want occurrence %s", latest, err, occurrenceID)
+		}
+
+		// Only the latest past-due occurrence of each series rolls forward
+		due, err := taskRepo.FindRecurringDueBefore(ctx, *date("2021-01-01 00:00:00"))
+		if err != nil {
+			t.Fatalf("FindRecurringDueBefore: %v", err)
+		}
+
+		ids := taskIDs(due)
+		if !slices.Contains(ids, occurrenceID) || !slices.Contains(ids, stale.ID) {
+			t.Errorf("FindRecurringDueBefore = %v, want %s and %s", ids, occurrenceID, stale.ID)
+		}
+
+		if slices.Contains(ids, first.ID) || slices.Contains(ids, future.ID) {
+			t.Errorf("FindRecurringDueBefore = %v, want neither %s nor %s", ids, first.ID, future.ID)
+		}
+	})
+
+	t.Run("EndedSeriesStayUntilTheRuleChanges", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		ended := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Sprint", DueDate: date("2020-01-01 09:00:00"), RecurrenceRule: "FREQ=DAILY;UNTIL=20200101T120000Z"})
+		if err := taskRepo.EndRecurrenceByID(ctx, ended.ID); err != nil {
+			t.Fatalf("EndRecurrenceByID: %v", err)
+		}
+
+		due, err := taskRepo.FindRecurringDueBefore(ctx, *date("2021-01-01 00:00:00"))
+		if err != nil || slices.Contains(taskIDs(due), ended.ID) {
+			t.Fatalf("FindRecurringDueBefore = %v, %v, want %s left out", taskIDs(due), err, ended.ID)
+		}
+
+		// Extending the series brings it back
+		if err := taskRepo.PatchByID(ctx, ended.ID, &requests.TaskUpdateRequest{RecurrenceRule: "FREQ=DAILY"}, []string{"recurrenceRule"}); err != nil {
+			t.Fatalf("PatchByID: %v", err)
+		}
+
+		due, err = taskRepo.FindRecurringDueBefore(ctx, *date("2021-01-01 00:00:00"))
+		if err != nil || !slices.Contains(taskIDs(due), ended.ID) {
+			t.Errorf("FindRecurringDueBefore after changing the rule = %v, %v, want %s", taskIDs(due), err, ended.ID)
+		}
+	})
+
+	t.Run("AssignByID", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		owner := newUser(t, userRepo)
+		assignee := newUser(t, userRepo)
+		task := newTask(t, taskRepo, owner.ID, &requests.TaskCreateRequest{Title: "Delegated"})
+
+		if task.AssigneeID != nil {
+			t.Fatalf("AssigneeID = %v, want nil on a new task", *task.AssigneeID)
+		}
+
+		if err := taskRepo.AssignByID(ctx, task.ID, &assignee.ID); err != nil {
+			t.Fatalf("AssignByID: %v", err)
+		}
+
+		if found := mustFindTask(t, taskRepo, task.ID); found.AssigneeID == nil || *found.AssigneeID != assignee.ID {
+			t.Errorf("AssigneeID = %v, want %s", found.AssigneeID, assignee.ID)
+		}
+
+		if err := taskRepo.AssignByID(ctx, task.ID, nil); err != nil {
+			t.Fatalf("AssignByID(nil): %v", err)
+		}
+
+		if found := mustFindTask(t, taskRepo, task.ID); found.AssigneeID != nil {
+			t.Errorf("AssigneeID after unassigning = %v, want nil", *found.AssigneeID)
+		}
+	})
+
+	t.Run("SaveRestoresTask", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Restore me", DueDate: date("2030-01-02 15:04:05"), Tags: []string{"old"}})
+
+		// Save replaces a stored task
+		saved := *created
+		saved.Title = "Restored"
+		saved.Status = models.TaskStatusCompleted
+		saved.Tags = []string{"new"}
+		if err := taskRepo.Save(ctx, &saved); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		found := mustFindTask(t, taskRepo, created.ID)
+		if found.Title != "Restored" || found.Status != models.TaskStatusCompleted || !slices.Equal(found.Tags, []string{"new"}) {
+			t.Errorf("FindByID after Save = %+v, want the saved task", found)
+		}
+
+		// Save brings a deleted task back as it was
+		if err := taskRepo.DeleteByID(ctx, created.ID); err != nil {
+			t.Fatalf("DeleteByID: %v", err)
+		}
+
+		if err := taskRepo.Save(ctx, created); err != nil {
+			t.Fatalf("Save(deleted): %v", err)
+		}
+
+		found = mustFindTask(t, taskRepo, created.ID)
+		if found.Title != created.Title || !found.CreatedAt.Equal(created.CreatedAt) || !equalTime(found.DueDate, created.DueDate) || !slices.Equal(found.Tags, created.Tags) {
+			t.Errorf("FindByID after restoring = %+v, want %+v", found, created)
+		}
+	})
+
+	t.Run("ApplyBulkReportsEachOperation", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Bulk", Priority: models.TaskPriorityLow, Tags: []string{"old"}})
+		doomed := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Doomed"})
+
+		errs, err := taskRepo.ApplyBulk(ctx, []requests.TaskBulkOperation{
+			{TaskID: created.ID, Action: models.TaskBulkActionChangePriority, Priority: models.TaskPriorityHigh},
+			{TaskID: created.ID, Action: "explode"},
+			{TaskID: created.ID, Action: models.TaskBulkActionAddTag, Tag: "new"},
+			{TaskID: created.ID, Action: models.TaskBulkActionAddTag, Tag: "new"},
+			{TaskID: created.ID, Action: models.TaskBulkActionRemoveTag, Tag: "old"},
+			{TaskID: created.ID, Action: models.TaskBulkActionUpdateStatus, Status: models.TaskStatusCompleted},
+			{TaskID: doomed.ID, Action: models.TaskBulkActionDelete},
+		}, false)
+		if err != nil {
+			t.Fatalf("ApplyBulk: %v", err)
+		}
+
+		for i, opErr := range errs {
+			if (opErr != nil) != (i == 1) {
+				t.Errorf("errs[%d] = %v, want an error only for the unknown action", i, opErr)
+			}
+		}
+
+		found := mustFindTask(t, taskRepo, created.ID)
+		if found.Priority != models.TaskPriorityHigh || found.Status != models.TaskStatusCompleted || !slices.Equal(found.Tags, []string{"new"}) {
+			t.Errorf("FindByID = %+v, want the valid operations applied", found)
+		}
+
+		if gone, _ := taskRepo.FindByID(ctx, doomed.ID); gone != nil {
+			t.Errorf("FindByID(deleted in bulk) = %+v, want nil", gone)
+		}
+	})
+
+	t.Run("ApplyBulkAtomicRollsBack", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		created := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Atomic", Priority: models.TaskPriorityLow})
+
+		errs, err := taskRepo.ApplyBulk(ctx, []requests.TaskBulkOperation{
+			{TaskID: created.ID, Action: models.TaskBulkActionChangePriority, Priority: models.TaskPriorityHigh},
+			{TaskID: created.ID, Action: "explode"},
+		}, true)
+		if err == nil || errs[1] == nil {
+			t.Fatalf("ApplyBulk = %v, %v, want the unknown action to fail", errs, err)
+		}
+
+		if found := mustFindTask(t, taskRepo, created.ID); found.Priority != models.TaskPriorityLow {
+			t.Errorf("Priority = %d, want the change rolled back", found.Priority)
+		}
+	})
+}
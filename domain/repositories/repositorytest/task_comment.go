@@ -0,0 +1,72 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+// RunTaskCommentRepository checks a TaskCommentRepository and a
+// TaskFollowerRepository sharing a database with the repositories returned by
+// newRepos
+func RunTaskCommentRepository(t *testing.T, newRepos Factory, commentRepo repositories.TaskCommentRepository, followerRepo repositories.TaskFollowerRepository) {
+	ctx := context.Background()
+
+	t.Run("CommentsInOrder", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Discussed"})
+		other := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Quiet"})
+
+		var ids []string
+		for _, body := range []string{"First", "Second"} {
+			id, err := commentRepo.Create(ctx, task.ID, user.ID, body)
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			ids = append(ids, id)
+		}
+
+		comment, err := commentRepo.FindByID(ctx, ids[0])
+		if err != nil || comment == nil || comment.TaskID != task.ID || comment.UserID != user.ID || comment.Body != "First" || !recent(comment.CreatedAt) {
+			t.Errorf("FindByID = %+v, %v, want the first comment", comment, err)
+		}
+
+		comments, err := commentRepo.FindByTaskID(ctx, task.ID)
+		if err != nil || len(comments) != 2 || comments[0].ID != ids[0] || comments[1].ID != ids[1] {
+			t.Errorf("FindByTaskID = %+v, %v, want both comments oldest first", comments, err)
+		}
+
+		if comments, err := commentRepo.FindByTaskID(ctx, other.ID); err != nil || len(comments) != 0 {
+			t.Errorf("FindByTaskID(other task) = %+v, %v, want none", comments, err)
+		}
+	})
+
+	t.Run("FollowOnce", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		owner := newUser(t, userRepo)
+		follower := newUser(t, userRepo)
+		task := newTask(t, taskRepo, owner.ID, &requests.TaskCreateRequest{Title: "Followed"})
+
+		for range 2 {
+			if err := followerRepo.Create(ctx, task.ID, follower.ID); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		followers, err := followerRepo.FindByTaskID(ctx, task.ID)
+		if err != nil || len(followers) != 1 || followers[0].UserID != follower.ID || followers[0].TaskID != task.ID {
+			t.Fatalf("FindByTaskID = %+v, %v, want the follower once", followers, err)
+		}
+
+		if err := followerRepo.DeleteByTaskIDAndUserID(ctx, task.ID, follower.ID); err != nil {
+			t.Fatalf("DeleteByTaskIDAndUserID: %v", err)
+		}
+
+		if followers, err := followerRepo.FindByTaskID(ctx, task.ID); err != nil || len(followers) != 0 {
+			t.Errorf("FindByTaskID after delete = %+v, %v, want none", followers, err)
+		}
+	})
+}
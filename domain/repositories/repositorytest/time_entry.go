@@ -0,0 +1,116 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+// RunTimeEntryRepository checks a TimeEntryRepository sharing a database with
+// the repositories returned by newRepos
+func RunTimeEntryRepository(t *testing.T, newRepos Factory, timeEntryRepo repositories.TimeEntryRepository) {
+	ctx := context.Background()
+
+	t.Run("StopRecordsDuration", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Tracked"})
+
+		startedAt := *date("2030-01-01 09:00:00")
+		entryID, err := timeEntryRepo.Start(ctx, task.ID, user.ID, "Focus", startedAt)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		running, err := timeEntryRepo.FindRunningByUserID(ctx, user.ID)
+		if err != nil || running == nil || running.ID != entryID || !running.StartedAt.Equal(startedAt) {
+			t.Fatalf("FindRunningByUserID = %+v, %v, want %s started at 09:00", running, err, entryID)
+		}
+
+		if err := timeEntryRepo.Stop(ctx, entryID, startedAt.Add(90*time.Second)); err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+
+		entry, err := timeEntryRepo.FindByID(ctx, entryID)
+		if err != nil || entry == nil || entry.DurationSeconds != 90 || !equalTime(entry.EndedAt, pointer(startedAt.Add(90*time.Second))) {
+			t.Errorf("FindByID = %+v, %v, want 90 seconds ending at 09:01:30", entry, err)
+		}
+
+		if running, err := timeEntryRepo.FindRunningByUserID(ctx, user.ID); err != nil || running != nil {
+			t.Errorf("FindRunningByUserID after Stop = %+v, %v, want nil, nil", running, err)
+		}
+	})
+
+	t.Run("ConcurrentStartsRunOneTimer", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Tracked"})
+
+		// Both pass the service's running check before either inserts
+		const starts = 8
+		errs := make(chan error, starts)
+		var wg sync.WaitGroup
+		for range starts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := timeEntryRepo.Start(ctx, task.ID, user.ID, "", time.Now())
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		started := 0
+		for err := range errs {
+			switch {
+			case err == nil:
+				started++
+			case !errors.Is(err, exceptions.ErrTimerAlreadyRunning):
+				t.Errorf("Start = %v, want nil or %v", err, exceptions.ErrTimerAlreadyRunning)
+			}
+		}
+
+		if started != 1 {
+			t.Errorf("%d timers started, want 1", started)
+		}
+	})
+
+	t.Run("TimesheetGroupsByLocalDay", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+		task := newTask(t, taskRepo, user.ID, &requests.TaskCreateRequest{Title: "Tracked", Tags: []string{"api", "work"}})
+
+		// 23:30 UTC is already the next day two hours east
+		for _, entry := range [][2]string{{"2030-01-01 23:30:00", "2030-01-01 23:50:00"}, {"2030-01-02 10:00:00", "2030-01-02 10:30:00"}} {
+			if _, err := timeEntryRepo.Create(ctx, &requests.TimeEntryCreateRequest{StartedAt: date(entry[0]), EndedAt: date(entry[1])}, task.ID, user.ID); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		east := time.FixedZone("UTC+2", 2*60*60)
+		from := time.Date(2030, 1, 1, 0, 0, 0, 0, east)
+		rows, err := timeEntryRepo.Timesheet(ctx, user.ID, from, from.AddDate(0, 0, 3), models.TimesheetGroupByDay)
+		if err != nil || len(rows) != 1 || rows[0] != (models.TimesheetRow{Group: "2030-01-02", Seconds: 3000, Entries: 2}) {
+			t.Errorf("Timesheet(day, UTC+2) = %+v, %v, want both entries on 2030-01-02", rows, err)
+		}
+
+		from = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		rows, err = timeEntryRepo.Timesheet(ctx, user.ID, from, from.AddDate(0, 0, 3), models.TimesheetGroupByDay)
+		if err != nil || len(rows) != 2 || rows[0] != (models.TimesheetRow{Group: "2030-01-01", Seconds: 1200, Entries: 1}) || rows[1] != (models.TimesheetRow{Group: "2030-01-02", Seconds: 1800, Entries: 1}) {
+			t.Errorf("Timesheet(day, UTC) = %+v, %v, want one entry on each day", rows, err)
+		}
+
+		rows, err = timeEntryRepo.Timesheet(ctx, user.ID, from, from.AddDate(0, 0, 3), models.TimesheetGroupByTag)
+		if err != nil || len(rows) != 2 || rows[0] != (models.TimesheetRow{Group: "api", Seconds: 3000, Entries: 2}) || rows[1] != (models.TimesheetRow{Group: "work", Seconds: 3000, Entries: 2}) {
+			t.Errorf("Timesheet(tag) = %+v, %v, want both entries under each tag", rows, err)
+		}
+	})
+}
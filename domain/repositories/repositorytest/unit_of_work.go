@@ -0,0 +1,109 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+var errAbort = errors.New("abort")
+
+// RunUnitOfWork checks that repositories join the unit of work's transaction.
+// Only adapters that can roll back run it.
+func RunUnitOfWork(t *testing.T, newRepos Factory, unitOfWork repositories.UnitOfWork) {
+	ctx := context.Background()
+
+	t.Run("CommitsTogether", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		var created *models.Task
+		err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+			task, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Committed", Description: "Description", Priority: models.TaskPriorityLow, Tags: []string{"kept"}}, user.ID)
+			if err != nil {
+				return err
+			}
+			created = task
+
+			return taskRepo.UpdateStatusByID(ctx, task.ID, models.TaskStatusCompleted)
+		})
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+
+		if found := mustFindTask(t, taskRepo, created.ID); found.Status != models.TaskStatusCompleted {
+			t.Errorf("Status = %s, want %s", found.Status, models.TaskStatusCompleted)
+		}
+	})
+
+	t.Run("RollsBackOnError", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		var created *models.Task
+		err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+			task, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Rolled back", Description: "Description", Priority: models.TaskPriorityLow, Tags: []string{"gone"}}, user.ID)
+			if err != nil {
+				return err
+			}
+			created = task
+
+			// Reads in the unit of work see its own writes
+			if found, err := taskRepo.FindByID(ctx, task.ID); found == nil || err != nil {
+				t.Errorf("FindByID in the unit of work = %v, %v, want the new task", found, err)
+			}
+
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("Do = %v, want %v", err, errAbort)
+		}
+
+		if found, err := taskRepo.FindByID(ctx, created.ID); found != nil || err != nil {
+			t.Errorf("FindByID(rolled back) = %v, %v, want nil, nil", found, err)
+		}
+	})
+
+	t.Run("NestedRollbackKeepsOuterWork", func(t *testing.T) {
+		userRepo, taskRepo := newRepos(t)
+		user := newUser(t, userRepo)
+
+		var outer, inner *models.Task
+		err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+			task, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Outer", Description: "Description", Priority: models.TaskPriorityLow}, user.ID)
+			if err != nil {
+				return err
+			}
+			outer = task
+
+			// The inner failure only undoes the inner work
+			err = unitOfWork.Do(ctx, func(ctx context.Context) error {
+				task, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Inner", Description: "Description", Priority: models.TaskPriorityLow}, user.ID)
+				if err != nil {
+					return err
+				}
+				inner = task
+
+				return errAbort
+			})
+			if !errors.Is(err, errAbort) {
+				t.Errorf("nested Do = %v, want %v", err, errAbort)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+
+		mustFindTask(t, taskRepo, outer.ID)
+
+		if found, err := taskRepo.FindByID(ctx, inner.ID); found != nil || err != nil {
+			t.Errorf("FindByID(inner) = %v, %v, want nil, nil", found, err)
+		}
+	})
+}
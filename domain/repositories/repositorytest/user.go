@@ -0,0 +1,115 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/google/uuid"
+)
+
+// RunUserRepository checks a UserRepository
+func RunUserRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+
+		if user.ID == "" || user.Name != "Test User" || user.Password != "hashed" {
+			t.Fatalf("FindByEmail = %+v, want the created user", user)
+		}
+
+		if !recent(user.CreatedAt) {
+			t.Errorf("CreatedAt = %v, want the current time", user.CreatedAt)
+		}
+
+		if user.Timezone != "UTC" {
+			t.Errorf("Timezone = %q, want UTC by default", user.Timezone)
+		}
+
+		found, err := userRepo.FindByID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+
+		if found == nil || found.Email != user.Email {
+			t.Fatalf("FindByID = %+v, want %+v", found, user)
+		}
+	})
+
+	t.Run("NotFoundIsNil", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+
+		user, err := userRepo.FindByID(ctx, uuid.NewString())
+		if user != nil || err != nil {
+			t.Errorf("FindByID(unknown) = %v, %v, want nil, nil", user, err)
+		}
+
+		user, err = userRepo.FindByEmail(ctx, uuid.NewString()+"@example.com")
+		if user != nil || err != nil {
+			t.Errorf("FindByEmail(unknown) = %v, %v, want nil, nil", user, err)
+		}
+
+		user, err = userRepo.FindByCalendarToken(ctx, uuid.NewString())
+		if user != nil || err != nil {
+			t.Errorf("FindByCalendarToken(unknown) = %v, %v, want nil, nil", user, err)
+		}
+	})
+
+	t.Run("DuplicateEmail", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+
+		err := userRepo.Create(ctx, &requests.UserRegisterRequest{Name: "Other", Email: user.Email, Password: "hashed"})
+		if err == nil {
+			t.Fatal("Create with a taken email succeeded, want an error")
+		}
+	})
+
+	t.Run("CalendarToken", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+		token := uuid.NewString()
+
+		if err := userRepo.UpdateCalendarToken(ctx, user.ID, &token); err != nil {
+			t.Fatalf("UpdateCalendarToken: %v", err)
+		}
+
+		found, err := userRepo.FindByCalendarToken(ctx, token)
+		if err != nil {
+			t.Fatalf("FindByCalendarToken: %v", err)
+		}
+
+		if found == nil || found.ID != user.ID {
+			t.Fatalf("FindByCalendarToken = %+v, want user %s", found, user.ID)
+		}
+
+		// A nil token revokes it
+		if err := userRepo.UpdateCalendarToken(ctx, user.ID, nil); err != nil {
+			t.Fatalf("UpdateCalendarToken(nil): %v", err)
+		}
+
+		found, err = userRepo.FindByCalendarToken(ctx, token)
+		if found != nil || err != nil {
+			t.Errorf("FindByCalendarToken(revoked) = %v, %v, want nil, nil", found, err)
+		}
+	})
+	t.Run("Timezone", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+
+		if err := userRepo.UpdateTimezone(ctx, user.ID, "Asia/Bangkok"); err != nil {
+			t.Fatalf("UpdateTimezone: %v", err)
+		}
+
+		found, err := userRepo.FindByID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+
+		if found == nil || found.Timezone != "Asia/Bangkok" {
+			t.Errorf("FindByID = %+v, want timezone Asia/Bangkok", found)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package repositorytest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+// RunWebhookRepository checks a WebhookRepository sharing a database with the
+// repositories returned by newRepos
+func RunWebhookRepository(t *testing.T, newRepos Factory, webhookRepo repositories.WebhookRepository) {
+	ctx := context.Background()
+
+	t.Run("FindActiveMatchesWholeEvents", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+
+		webhookID, err := webhookRepo.Create(ctx, &requests.WebhookCreateRequest{URL: "https://example.com/hook", Events: []string{models.TaskEventCreated, models.TaskEventStatusChanged}}, user.ID, "secret")
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		for _, eventType := range []string{models.TaskEventCreated, models.TaskEventStatusChanged} {
+			webhooks, err := webhookRepo.FindActiveByUserIDAndEvent(ctx, user.ID, eventType)
+			if err != nil || len(webhooks) != 1 || webhooks[0].ID != webhookID {
+				t.Errorf("FindActiveByUserIDAndEvent(%s) = %+v, %v, want %s", eventType, webhooks, err, webhookID)
+			}
+		}
+
+		for _, eventType := range []string{models.TaskEventDeleted, "task", "created"} {
+			if webhooks, err := webhookRepo.FindActiveByUserIDAndEvent(ctx, user.ID, eventType); err != nil || len(webhooks) != 0 {
+				t.Errorf("FindActiveByUserIDAndEvent(%s) = %+v, %v, want none", eventType, webhooks, err)
+			}
+		}
+
+		webhook, err := webhookRepo.FindByID(ctx, webhookID)
+		if err != nil || webhook == nil || !webhook.Active || !slices.Equal(webhook.Events, []string{models.TaskEventCreated, models.TaskEventStatusChanged}) {
+			t.Errorf("FindByID = %+v, %v, want the active webhook with its events", webhook, err)
+		}
+	})
+
+	t.Run("DeliveryLifecycle", func(t *testing.T) {
+		userRepo, _ := newRepos(t)
+		user := newUser(t, userRepo)
+
+		webhookID, err := webhookRepo.Create(ctx, &requests.WebhookCreateRequest{URL: "https://example.com/hook", Events: []string{models.TaskEventCreated}}, user.ID, "secret")
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		deliveryID, err := webhookRepo.CreateDelivery(ctx, webhookID, models.TaskEventCreated, `{"id":"1"}`)
+		if err != nil {
+			t.Fatalf("CreateDelivery: %v", err)
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		if due := dueDeliveryIDs(t, webhookRepo, now); !slices.Contains(due, deliveryID) {
+			t.Fatalf("FindDueDeliveries = %v, want %s", due, deliveryID)
+		}
+
+		if claimed, err := webhookRepo.ClaimDelivery(ctx, deliveryID, now, now.Add(time.Minute)); err != nil || !claimed {
+			t.Fatalf("ClaimDelivery = %v, %v, want true, nil", claimed, err)
+		}
+
+		if claimed, err := webhookRepo.ClaimDelivery(ctx, deliveryID, now, now.Add(time.Minute)); err != nil || claimed {
+			t.Errorf("ClaimDelivery while leased = %v, %v, want false, nil", claimed, err)
+		}
+
+		if err := webhookRepo.RescheduleDelivery(ctx, deliveryID, pointer(503), "unavailable", now.Add(time.Hour)); err != nil {
+			t.Fatalf("RescheduleDelivery: %v", err)
+		}
+
+		if due := dueDeliveryIDs(t, webhookRepo, now.Add(time.Minute)); slices.Contains(due, deliveryID) {
+			t.Errorf("FindDueDeliveries before the next attempt = %v, want the delivery left out", due)
+		}
+
+		if err := webhookRepo.MarkDeliverySucceeded(ctx, deliveryID, 200, now.Add(time.Hour)); err != nil {
+			t.Fatalf("MarkDeliverySucceeded: %v", err)
+		}
+
+		deliveries, err := webhookRepo.FindDeliveriesByWebhookID(ctx, webhookID, 10)
+		if err != nil || len(deliveries) != 1 {
+			t.Fatalf("FindDeliveriesByWebhookID = %+v, %v, want the delivery", deliveries, err)
+		}
+
+		delivery := deliveries[0]
+		if delivery.Status != models.WebhookDeliveryStatusSucceeded || !equalPointer(delivery.ResponseStatus, pointer(200)) || delivery.LastError != nil || !equalTime(delivery.DeliveredAt, pointer(now.Add(time.Hour))) {
+			t.Errorf("delivery = %+v, want it SUCCEEDED with status 200", delivery)
+		}
+
+		// Deleting the webhook takes its deliveries along
+		if err := webhookRepo.DeleteByID(ctx, webhookID); err != nil {
+			t.Fatalf("DeleteByID: %v", err)
+		}
+
+		if delivery, err := webhookRepo.FindDeliveryByID(ctx, deliveryID); err != nil || delivery != nil {
+			t.Errorf("FindDeliveryByID after deleting the webhook = %+v, %v, want nil, nil", delivery, err)
+		}
+	})
+}
+
+func dueDeliveryIDs(t *testing.T, webhookRepo repositories.WebhookRepository, now time.Time) []string {
+	t.Helper()
+
+	deliveries, err := webhookRepo.FindDueDeliveries(context.Background(), now, 1000)
+	if err != nil {
+		t.Fatalf("FindDueDeliveries: %v", err)
+	}
+
+	ids := make([]string, len(deliveries))
+	for i := range deliveries {
+		ids[i] = deliveries[i].ID
+	}
+
+	return ids
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+type TaskCommentRepository interface {
+	Create(ctx context.Context, taskID string, userID string, body string) (string, error)
+	FindByID(ctx context.Context, commentID string) (*models.TaskComment, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]models.TaskComment, error)
+}
+
+type TaskFollowerRepository interface {
+	Create(ctx context.Context, taskID string, userID string) error
+	FindByTaskID(ctx context.Context, taskID string) ([]models.TaskFollower, error)
+	DeleteByTaskIDAndUserID(ctx context.Context, taskID string, userID string) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// TaskEventStore keeps the event stream of every task. Versions number the
+// events of a task from 1, appending a version that already exists fails.
+type TaskEventStore interface {
+	Append(ctx context.Context, event *models.TaskStreamEvent) error
+	FindByTaskID(ctx context.Context, taskID string) ([]models.TaskStreamEvent, error)
+	FindTaskIDsAfter(ctx context.Context, afterTaskID string, limit int) ([]string, error)
+}
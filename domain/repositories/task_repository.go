@@ -2,16 +2,30 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
 	"github.com/GraphZC/sdd-task-management/domain/requests"
 )
 
 type TaskRepository interface {
-	Create(ctx context.Context, req *requests.TaskCreateRequest, userID string) (string, error)
+	Create(ctx context.Context, req *requests.TaskCreateRequest, userID string) (*models.Task, error)
+	CreateOccurrence(ctx context.Context, task *models.Task, dueDate time.Time) (string, error)
+	Save(ctx context.Context, task *models.Task) error
 	FindByID(ctx context.Context, taskID string) (*models.Task, error)
+	FindByIDs(ctx context.Context, taskIDs []string) ([]models.Task, error)
 	FindByUserID(ctx context.Context, userID string) ([]models.Task, error)
+	FindByUserIDAfter(ctx context.Context, userID string, afterID string, limit int) ([]models.Task, error)
+	FindByExternalID(ctx context.Context, userID string, externalID string) (*models.Task, error)
+	FindByQuery(ctx context.Context, userID string, q *query.Query, now time.Time) ([]models.Task, error)
+	FindLatestBySeriesID(ctx context.Context, seriesID string) (*models.Task, error)
+	FindRecurringDueBefore(ctx context.Context, before time.Time) ([]models.Task, error)
 	DeleteByID(ctx context.Context, taskID string) error
 	UpdateByUD(ctx context.Context, taskID string, req *requests.TaskUpdateRequest) error
+	PatchByID(ctx context.Context, taskID string, req *requests.TaskUpdateRequest, fields []string) error
 	UpdateStatusByID(ctx context.Context, taskID string, status string) error
+	EndRecurrenceByID(ctx context.Context, taskID string) error
+	AssignByID(ctx context.Context, taskID string, assigneeID *string) error
+	ApplyBulk(ctx context.Context, operations []requests.TaskBulkOperation, atomic bool) ([]error, error)
 }
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+type TaskSearchIndex interface {
+	Index(ctx context.Context, task *models.Task) error
+	Remove(ctx context.Context, taskID string) error
+	Search(ctx context.Context, userID string, query string, limit int) ([]models.TaskSearchResult, error)
+}
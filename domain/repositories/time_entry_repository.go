@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type TimeEntryRepository interface {
+	Create(ctx context.Context, req *requests.TimeEntryCreateRequest, taskID string, userID string) (string, error)
+	// Start fails with exceptions.ErrTimerAlreadyRunning while the user has a running timer
+	Start(ctx context.Context, taskID string, userID string, note string, startedAt time.Time) (string, error)
+	Stop(ctx context.Context, entryID string, endedAt time.Time) error
+	FindByID(ctx context.Context, entryID string) (*models.TimeEntry, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]models.TimeEntry, error)
+	FindRunningByUserID(ctx context.Context, userID string) (*models.TimeEntry, error)
+	DeleteByID(ctx context.Context, entryID string) error
+	Timesheet(ctx context.Context, userID string, from time.Time, to time.Time, groupBy string) ([]models.TimesheetRow, error)
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+// UnitOfWork runs fn atomically. Repository calls made with the context passed
+// to fn take part in the same transaction, and an error from fn undoes them all.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -9,5 +9,9 @@ import (
 
 type UserRepository interface {
 	Create(ctx context.Context, req *requests.UserRegisterRequest) error
+	FindByID(ctx context.Context, userID string) (*models.User, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByCalendarToken(ctx context.Context, token string) (*models.User, error)
+	UpdateCalendarToken(ctx context.Context, userID string, token *string) error
+	UpdateTimezone(ctx context.Context, userID string, timezone string) error
 }
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type ViewRepository interface {
+	Create(ctx context.Context, req *requests.ViewCreateRequest, userID string) (string, error)
+	FindByID(ctx context.Context, viewID string) (*models.View, error)
+	FindByUserID(ctx context.Context, userID string) ([]models.View, error)
+	UpdateByID(ctx context.Context, viewID string, req *requests.ViewUpdateRequest) error
+	DeleteByID(ctx context.Context, viewID string) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, req *requests.WebhookCreateRequest, userID string, secret string) (string, error)
+	FindByID(ctx context.Context, webhookID string) (*models.Webhook, error)
+	FindByUserID(ctx context.Context, userID string) ([]models.Webhook, error)
+	FindActiveByUserIDAndEvent(ctx context.Context, userID string, eventType string) ([]models.Webhook, error)
+	DeleteByID(ctx context.Context, webhookID string) error
+	CreateDelivery(ctx context.Context, webhookID string, eventType string, payload string) (string, error)
+	FindDeliveryByID(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error)
+	FindDeliveriesByWebhookID(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error)
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	ClaimDelivery(ctx context.Context, deliveryID string, now time.Time, lockedUntil time.Time) (bool, error)
+	MarkDeliverySucceeded(ctx context.Context, deliveryID string, responseStatus int, deliveredAt time.Time) error
+	RescheduleDelivery(ctx context.Context, deliveryID string, responseStatus *int, lastError string, nextAttemptAt time.Time) error
+	MarkDeliveryFailed(ctx context.Context, deliveryID string, responseStatus *int, lastError string) error
+}
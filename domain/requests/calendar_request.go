@@ -0,0 +1,5 @@
+package requests
+
+type CalendarFeedRequest struct {
+	Kind string `query:"kind" validate:"omitempty,oneof=todo event"`
+}
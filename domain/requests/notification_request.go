@@ -0,0 +1,11 @@
+package requests
+
+type NotificationListRequest struct {
+	Page   int  `query:"page" validate:"omitempty,min=1"`
+	Limit  int  `query:"limit" validate:"omitempty,min=1,max=100"`
+	Unread bool `query:"unread"`
+}
+
+type NotificationPreferencesUpdateRequest struct {
+	Preferences map[string]bool `json:"preferences" validate:"required"`
+}
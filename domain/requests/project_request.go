@@ -0,0 +1,5 @@
+package requests
+
+type ProjectCreateRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
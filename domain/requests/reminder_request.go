@@ -0,0 +1,10 @@
+package requests
+
+import "time"
+
+type ReminderCreateRequest struct {
+	RemindAt      *time.Time `json:"remindAt"`
+	OffsetMinutes *int       `json:"offsetMinutes" validate:"omitempty,min=0"`
+	Channel       string     `json:"channel" validate:"required,oneof=EMAIL WEBHOOK IN_APP"`
+	Target        string     `json:"target" validate:"omitempty,url"`
+}
@@ -0,0 +1,7 @@
+package requests
+
+type StatsRequest struct {
+	From     string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Interval string `query:"interval" validate:"omitempty,oneof=day week"`
+}
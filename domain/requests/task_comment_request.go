@@ -0,0 +1,9 @@
+package requests
+
+type TaskCommentCreateRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type TaskFollowerCreateRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
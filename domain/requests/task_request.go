@@ -1,13 +1,91 @@
 package requests
 
+import "time"
+
 type TaskCreateRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	Priority    int    `json:"priority" validate:"required"`
+	Title              string     `json:"title" validate:"required"`
+	Description        string     `json:"description" validate:"required"`
+	Priority           int        `json:"priority" validate:"min=0,max=2"`
+	DueDate            *time.Time `json:"dueDate"`
+	RecurrenceRule     string     `json:"recurrenceRule"`
+	RecurrenceTimezone string     `json:"recurrenceTimezone" validate:"omitempty,timezone"`
+	Tags               []string   `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	ProjectID          *string    `json:"projectId"`
+	ExternalID         *string    `json:"externalId" validate:"omitempty,max=100"`
+	EstimateMinutes    *int       `json:"estimateMinutes" validate:"omitnil,min=0"`
+	DescriptionHTML    string     `json:"-"`
 }
 
 type TaskUpdateRequest = TaskCreateRequest
 
+type TaskPatchRequest struct {
+	Patch     []byte
+	JSONPatch bool
+}
+
+type TaskChecklistItemRequest struct {
+	Checked *bool `json:"checked" validate:"required"`
+}
+
 type TaskUpdateStatusRequest struct {
 	Status string `json:"status" validate:"required"`
 }
+
+type TaskFindRequest struct {
+	AsOf string `query:"asOf" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type TaskListRequest struct {
+	Query string `query:"q"`
+	Sort  string `query:"sort"`
+}
+
+type TaskSearchRequest struct {
+	Query string `query:"q" validate:"required"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type TaskBulkRequest struct {
+	Atomic     bool                `json:"atomic"`
+	Operations []TaskBulkOperation `json:"operations" validate:"required,min=1,max=500,dive"`
+}
+
+type TaskBulkOperation struct {
+	TaskID    string  `json:"taskId" validate:"required"`
+	Action    string  `json:"action" validate:"required,oneof=update_status change_priority add_tag remove_tag move_project delete"`
+	Status    string  `json:"status"`
+	Priority  int     `json:"priority"`
+	Tag       string  `json:"tag" validate:"max=50"`
+	ProjectID *string `json:"projectId"`
+}
+
+type TaskExportRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=csv json ndjson"`
+}
+
+type TaskImportRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=csv json ndjson"`
+	DryRun bool   `query:"dryRun"`
+}
+
+type TaskSourceImportRequest struct {
+	Project string `query:"project" validate:"max=100"`
+	DryRun  bool   `query:"dryRun"`
+}
+
+type TaskImportRecord struct {
+	ExternalID         string     `json:"externalId" validate:"omitempty,max=100"`
+	Title              string     `json:"title" validate:"required"`
+	Description        string     `json:"description" validate:"required"`
+	Status             string     `json:"status" validate:"omitempty,oneof=TODO COMPLETED"`
+	Priority           int        `json:"priority"`
+	DueDate            *time.Time `json:"dueDate"`
+	RecurrenceRule     string     `json:"recurrenceRule"`
+	RecurrenceTimezone string     `json:"recurrenceTimezone" validate:"omitempty,timezone"`
+	Tags               []string   `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	Project            string     `json:"project" validate:"max=100"`
+}
+
+type TaskAssignRequest struct {
+	Email string `json:"email" validate:"omitempty,email"`
+}
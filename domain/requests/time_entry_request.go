@@ -0,0 +1,20 @@
+package requests
+
+import "time"
+
+type TimeEntryCreateRequest struct {
+	StartedAt *time.Time `json:"startedAt" validate:"required"`
+	EndedAt   *time.Time `json:"endedAt" validate:"required"`
+	Note      string     `json:"note" validate:"max=500"`
+}
+
+type TimerStartRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
+
+type TimesheetRequest struct {
+	From    string `query:"from" validate:"required,datetime=2006-01-02"`
+	To      string `query:"to" validate:"required,datetime=2006-01-02"`
+	GroupBy string `query:"groupBy" validate:"omitempty,oneof=day project tag"`
+	Format  string `query:"format" validate:"omitempty,oneof=json csv"`
+}
@@ -10,3 +10,7 @@ type UserLoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
+
+type UserSettingsUpdateRequest struct {
+	Timezone string `json:"timezone" validate:"required,timezone"`
+}
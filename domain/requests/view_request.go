@@ -0,0 +1,9 @@
+package requests
+
+type ViewCreateRequest struct {
+	Name  string `json:"name" validate:"required,max=100"`
+	Query string `json:"query"`
+	Sort  string `json:"sort"`
+}
+
+type ViewUpdateRequest = ViewCreateRequest
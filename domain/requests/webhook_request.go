@@ -0,0 +1,6 @@
+package requests
+
+type WebhookCreateRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=task.created task.updated task.status_changed task.deleted"`
+}
@@ -0,0 +1,11 @@
+package responses
+
+import "github.com/GraphZC/sdd-task-management/domain/models"
+
+type NotificationListResponse struct {
+	Data        []models.Notification `json:"data"`
+	Page        int                   `json:"page"`
+	Limit       int                   `json:"limit"`
+	Total       int                   `json:"total"`
+	UnreadCount int                   `json:"unreadCount"`
+}
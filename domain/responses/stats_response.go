@@ -0,0 +1,13 @@
+package responses
+
+import "github.com/GraphZC/sdd-task-management/domain/models"
+
+type StatsResponse struct {
+	From           string                 `json:"from"`
+	To             string                 `json:"to"`
+	Interval       string                 `json:"interval"`
+	Periods        []models.StatsPeriod   `json:"periods"`
+	CycleTime      models.CycleTime       `json:"cycleTime"`
+	OpenByPriority []models.PriorityCount `json:"openByPriority"`
+	Overdue        int64                  `json:"overdue"`
+}
@@ -0,0 +1,38 @@
+package responses
+
+import "github.com/GraphZC/sdd-task-management/domain/models"
+
+type TaskBulkResponse struct {
+	Atomic    bool             `json:"atomic"`
+	Committed bool             `json:"committed"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []TaskBulkResult `json:"results"`
+}
+
+type TaskBulkResult struct {
+	Index   int          `json:"index"`
+	TaskID  string       `json:"taskId"`
+	Action  string       `json:"action"`
+	Success bool         `json:"success"`
+	Error   string       `json:"error,omitempty"`
+	Task    *models.Task `json:"task,omitempty"`
+}
+
+type TaskImportResponse struct {
+	DryRun   bool                  `json:"dryRun"`
+	Total    int                   `json:"total"`
+	Created  int                   `json:"created"`
+	Skipped  int                   `json:"skipped"`
+	Failed   int                   `json:"failed"`
+	Rows     []TaskImportRowResult `json:"rows"`
+	Unmapped []string              `json:"unmapped,omitempty"`
+}
+
+type TaskImportRowResult struct {
+	Line       int    `json:"line"`
+	ExternalID string `json:"externalId,omitempty"`
+	Status     string `json:"status"`
+	TaskID     string `json:"taskId,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
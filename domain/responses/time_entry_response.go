@@ -0,0 +1,11 @@
+package responses
+
+import "github.com/GraphZC/sdd-task-management/domain/models"
+
+type TimesheetResponse struct {
+	From         string                `json:"from"`
+	To           string                `json:"to"`
+	GroupBy      string                `json:"groupBy"`
+	TotalSeconds int64                 `json:"totalSeconds"`
+	Rows         []models.TimesheetRow `json:"rows"`
+}
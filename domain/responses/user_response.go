@@ -1,10 +1,19 @@
 package responses
 
+import "time"
+
 type UserLoginResponse struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Token     string `json:"token"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Timezone  string    `json:"timezone"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type UserSettingsResponse struct {
+	Timezone      string  `json:"timezone"`
+	CalendarToken *string `json:"calendarToken"`
+	CalendarURL   *string `json:"calendarUrl"`
 }
@@ -0,0 +1,10 @@
+package responses
+
+import "github.com/GraphZC/sdd-task-management/domain/models"
+
+// WebhookCreateResponse is the only response carrying the signing secret,
+// it is not shown again after the webhook is created
+type WebhookCreateResponse struct {
+	models.Webhook
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,107 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+)
+
+// Tokenize lower-cases the text and splits it on anything that isn't a letter or digit
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Matches reports whether a token matches a query term, terms match as prefixes
+func Matches(token string, term string) bool {
+	return strings.HasPrefix(token, term)
+}
+
+// Highlight HTML-escapes the text and wraps words matching any term in <mark>.
+// When maxLength is positive the result is cut to a window around the first match.
+func Highlight(text string, terms []string, maxLength int) string {
+	runes := []rune(text)
+	start, end := 0, len(runes)
+
+	if maxLength > 0 && len(runes) > maxLength {
+		first := firstMatch(runes, terms)
+		start = max(0, first-maxLength/4)
+		end = min(len(runes), start+maxLength)
+		start = max(0, end-maxLength)
+	}
+
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("…")
+	}
+
+	i := start
+	for i < end {
+		if !isWordRune(runes[i]) {
+			builder.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+
+		j := i
+		for j < end && isWordRune(runes[j]) {
+			j++
+		}
+
+		word := string(runes[i:j])
+		if matchesAny(strings.ToLower(word), terms) {
+			builder.WriteString(highlightOpen + html.EscapeString(word) + highlightClose)
+		} else {
+			builder.WriteString(html.EscapeString(word))
+		}
+		i = j
+	}
+
+	if end < len(runes) {
+		builder.WriteString("…")
+	}
+
+	return builder.String()
+}
+
+func firstMatch(runes []rune, terms []string) int {
+	i := 0
+	for i < len(runes) {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+
+		if matchesAny(strings.ToLower(string(runes[i:j])), terms) {
+			return i
+		}
+		i = j
+	}
+
+	return 0
+}
+
+func matchesAny(token string, terms []string) bool {
+	for _, term := range terms {
+		if Matches(token, term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
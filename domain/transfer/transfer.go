@@ -0,0 +1,289 @@
+package transfer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// Columns of the CSV format, in export order
+var csvHeader = []string{"externalId", "title", "description", "status", "priority", "dueDate", "recurrenceRule", "recurrenceTimezone", "tags", "project"}
+
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+type Encoder interface {
+	Encode(record *requests.TaskImportRecord) error
+	Close() error
+}
+
+// Row is a decoded record, or the reason it could not be decoded. Line is the
+// line number for CSV and NDJSON and the element position for a JSON array.
+type Row struct {
+	Line   int
+	Record *requests.TaskImportRecord
+	Err    error
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json"
+	}
+}
+
+func NewEncoder(format string, w io.Writer) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		return &csvEncoder{writer: writer}, writer.Write(csvHeader)
+	case FormatJSON:
+		_, err := io.WriteString(w, "[")
+		return &jsonEncoder{w: w}, err
+	case FormatNDJSON:
+		return &ndjsonEncoder{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func Decode(format string, r io.Reader) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatJSON:
+		return decodeJSON(r)
+	case FormatNDJSON:
+		return decodeNDJSON(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) Encode(record *requests.TaskImportRecord) error {
+	dueDate := ""
+	if record.DueDate != nil {
+		dueDate = record.DueDate.UTC().Format(time.RFC3339)
+	}
+
+	return e.writer.Write([]string{
+		record.ExternalID,
+		record.Title,
+		record.Description,
+		record.Status,
+		strconv.Itoa(record.Priority),
+		dueDate,
+		record.RecurrenceRule,
+		record.RecurrenceTimezone,
+		strings.Join(record.Tags, ","),
+		record.Project,
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(record *requests.TaskImportRecord) error {
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.count++
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	_, err := io.WriteString(e.w, "]")
+	return err
+}
+
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(record *requests.TaskImportRecord) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
+
+func decodeCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	// Columns are matched by name, unknown ones are ignored
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	rows := []Row{}
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.Line
+			}
+			rows = append(rows, Row{Line: line, Err: err})
+
+			// The reader can't resync after a quoting error
+			if errors.Is(err, csv.ErrQuote) || errors.Is(err, csv.ErrBareQuote) {
+				break
+			}
+			continue
+		}
+
+		value := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(values) {
+				return ""
+			}
+			return strings.TrimSpace(values[i])
+		}
+
+		record, err := csvRecord(value)
+		rows = append(rows, Row{Line: line, Record: record, Err: err})
+	}
+
+	return rows, nil
+}
+
+func csvRecord(value func(name string) string) (*requests.TaskImportRecord, error) {
+	record := &requests.TaskImportRecord{
+		ExternalID:         value("externalId"),
+		Title:              value("title"),
+		Description:        value("description"),
+		Status:             value("status"),
+		RecurrenceRule:     value("recurrenceRule"),
+		RecurrenceTimezone: value("recurrenceTimezone"),
+		Project:            value("project"),
+	}
+
+	if priority := value("priority"); priority != "" {
+		n, err := strconv.Atoi(priority)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority %q", priority)
+		}
+		record.Priority = n
+	}
+
+	if dueDate := value("dueDate"); dueDate != "" {
+		parsed, err := time.Parse(time.RFC3339, dueDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date %q", dueDate)
+		}
+		record.DueDate = &parsed
+	}
+
+	if tags := value("tags"); tags != "" {
+		record.Tags = strings.Split(tags, ",")
+	}
+
+	return record, nil
+}
+
+func decodeJSON(r io.Reader) ([]Row, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("expected a JSON array")
+	}
+
+	rows := []Row{}
+	for line := 1; decoder.More(); line++ {
+		var record requests.TaskImportRecord
+		err := decoder.Decode(&record)
+
+		// A type error still consumes the element, anything else is fatal
+		var typeErr *json.UnmarshalTypeError
+		if err != nil && !errors.As(err, &typeErr) {
+			return nil, err
+		}
+
+		if err != nil {
+			rows = append(rows, Row{Line: line, Err: err})
+			continue
+		}
+
+		rows = append(rows, Row{Line: line, Record: &record})
+	}
+
+	return rows, nil
+}
+
+func decodeNDJSON(r io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	rows := []Row{}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record requests.TaskImportRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			rows = append(rows, Row{Line: line, Err: err})
+			continue
+		}
+
+		rows = append(rows, Row{Line: line, Record: &record})
+	}
+
+	return rows, scanner.Err()
+}
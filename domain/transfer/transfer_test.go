@@ -0,0 +1,123 @@
+package transfer_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/transfer"
+)
+
+func TestRoundTrip(t *testing.T) {
+	dueDate := time.Date(2030, 1, 2, 9, 30, 0, 0, time.UTC)
+	records := []*requests.TaskImportRecord{
+		{
+			ExternalID:         "ext-1",
+			Title:              "Standup, daily",
+			Description:        "Line one\nLine \"two\"",
+			Status:             "COMPLETED",
+			Priority:           2,
+			DueDate:            &dueDate,
+			RecurrenceRule:     "FREQ=DAILY",
+			RecurrenceTimezone: "Europe/Berlin",
+			Tags:               []string{"api", "work"},
+			Project:            "Backend",
+		},
+		{
+			ExternalID:  "ext-2",
+			Title:       "Plain",
+			Description: "Description",
+			Status:      "TODO",
+		},
+	}
+
+	for _, format := range []string{transfer.FormatCSV, transfer.FormatJSON, transfer.FormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder, err := transfer.NewEncoder(format, &buf)
+			if err != nil {
+				t.Fatalf("NewEncoder: %v", err)
+			}
+
+			for _, record := range records {
+				if err := encoder.Encode(record); err != nil {
+					t.Fatalf("Encode: %v", err)
+				}
+			}
+
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			rows, err := transfer.Decode(format, &buf)
+			if err != nil || len(rows) != len(records) {
+				t.Fatalf("Decode = %+v, %v, want %d rows", rows, err, len(records))
+			}
+
+			for i, row := range rows {
+				if row.Err != nil || !reflect.DeepEqual(row.Record, records[i]) {
+					t.Errorf("row %d = %+v, %v, want %+v", i, row.Record, row.Err, records[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeReportsRowErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		data   string
+		lines  []int
+		failed []int
+	}{
+		{
+			format: transfer.FormatCSV,
+			data:   "title,description,priority,dueDate\nOne,Description,1,\nTwo,Description,high,\nThree,Description,1,tomorrow\nFour,Description,,\n",
+			lines:  []int{2, 3, 4, 5},
+			failed: []int{3, 4},
+		},
+		{
+			format: transfer.FormatJSON,
+			data:   `[{"title":"One"},{"title":"Two","priority":"high"},{"title":"Three"}]`,
+			lines:  []int{1, 2, 3},
+			failed: []int{2},
+		},
+		{
+			format: transfer.FormatNDJSON,
+			data:   "{\"title\":\"One\"}\n\n{\"title\":\n{\"title\":\"Three\"}\n",
+			lines:  []int{1, 3, 4},
+			failed: []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			rows, err := transfer.Decode(tt.format, strings.NewReader(tt.data))
+			if err != nil || len(rows) != len(tt.lines) {
+				t.Fatalf("Decode = %+v, %v, want %d rows", rows, err, len(tt.lines))
+			}
+
+			for i, row := range rows {
+				failed := slices.Contains(tt.failed, row.Line)
+				if row.Line != tt.lines[i] || (row.Err != nil) != failed || (row.Record == nil) != failed {
+					t.Errorf("row %d = line %d, %+v, %v, want line %d failing %v", i, row.Line, row.Record, row.Err, tt.lines[i], failed)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsMalformedInput(t *testing.T) {
+	if _, err := transfer.Decode(transfer.FormatJSON, strings.NewReader(`{"title":"One"}`)); err == nil {
+		t.Error("Decode(json object) = nil, want an error")
+	}
+
+	if _, err := transfer.Decode("xml", strings.NewReader("")); !errors.Is(err, transfer.ErrUnsupportedFormat) {
+		t.Errorf("Decode(xml) = %v, want %v", err, transfer.ErrUnsupportedFormat)
+	}
+}
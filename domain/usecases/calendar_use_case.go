@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+)
+
+type CalendarUseCase interface {
+	FindCalendarTasks(ctx context.Context, token string) (*models.User, []models.Task, error)
+}
+
+type calendarService struct {
+	userRepo repositories.UserRepository
+	taskRepo repositories.TaskRepository
+}
+
+func NewCalendarService(userRepo repositories.UserRepository, taskRepo repositories.TaskRepository) CalendarUseCase {
+	return &calendarService{
+		userRepo: userRepo,
+		taskRepo: taskRepo,
+	}
+}
+
+func (c *calendarService) FindCalendarTasks(ctx context.Context, token string) (*models.User, []models.Task, error) {
+	// Find the owner of the feed
+	user, err := c.userRepo.FindByCalendarToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user == nil {
+		return nil, nil, exceptions.ErrCalendarNotFound
+	}
+
+	// Only tasks with a due date end up in the calendar
+	tasks, err := c.taskRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dated := []models.Task{}
+	for _, task := range tasks {
+		if task.DueDate != nil {
+			dated = append(dated, task)
+		}
+	}
+
+	return user, dated, nil
+}
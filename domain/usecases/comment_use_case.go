@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+// Users are mentioned by email, as in "@ada@example.com"
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)+)`)
+
+type CommentUseCase interface {
+	CreateComment(ctx context.Context, taskID string, req *requests.TaskCommentCreateRequest, userID string) (*models.TaskComment, error)
+	FindComments(ctx context.Context, taskID string, userID string) ([]models.TaskComment, error)
+}
+
+type commentService struct {
+	commentRepo repositories.TaskCommentRepository
+	taskRepo    repositories.TaskRepository
+	userRepo    repositories.UserRepository
+	dispatcher  events.Dispatcher
+}
+
+func NewCommentService(commentRepo repositories.TaskCommentRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository, dispatcher events.Dispatcher) CommentUseCase {
+	return &commentService{
+		commentRepo: commentRepo,
+		taskRepo:    taskRepo,
+		userRepo:    userRepo,
+		dispatcher:  dispatcher,
+	}
+}
+
+func (c *commentService) CreateComment(ctx context.Context, taskID string, req *requests.TaskCommentCreateRequest, userID string) (*models.TaskComment, error) {
+	// Find the task
+	task, err := c.findTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve mentions, unknown emails are left as text
+	mentioned, err := c.mentionedUserIDs(ctx, req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create comment
+	commentID, err := c.commentRepo.Create(ctx, taskID, userID, req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := c.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.dispatcher.Dispatch(ctx, &models.TaskEvent{
+		Type:             models.TaskEventCommented,
+		TaskID:           task.ID,
+		UserID:           task.UserID,
+		ActorID:          userID,
+		Task:             task,
+		Comment:          comment,
+		MentionedUserIDs: mentioned,
+		OccurredAt:       time.Now().UTC(),
+	})
+
+	return comment, nil
+}
+
+func (c *commentService) FindComments(ctx context.Context, taskID string, userID string) ([]models.TaskComment, error) {
+	// Find the task
+	if _, err := c.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return c.commentRepo.FindByTaskID(ctx, taskID)
+}
+
+// mentionedUserIDs finds the users mentioned in body, each once
+func (c *commentService) mentionedUserIDs(ctx context.Context, body string) ([]string, error) {
+	userIDs := []string{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.TrimRight(match[1], ".")
+
+		user, err := c.userRepo.FindByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+
+		if user != nil && !slices.Contains(userIDs, user.ID) {
+			userIDs = append(userIDs, user.ID)
+		}
+	}
+
+	return userIDs, nil
+}
+
+func (c *commentService) findTask(ctx context.Context, taskID string, userID string) (*models.Task, error) {
+	task, err := c.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task is exist and belong to the user
+	if task == nil || task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	return task, nil
+}
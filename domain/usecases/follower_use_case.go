@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type FollowerUseCase interface {
+	AddFollower(ctx context.Context, taskID string, req *requests.TaskFollowerCreateRequest, userID string) ([]models.TaskFollower, error)
+	FindFollowers(ctx context.Context, taskID string, userID string) ([]models.TaskFollower, error)
+	RemoveFollower(ctx context.Context, taskID string, followerID string, userID string) ([]models.TaskFollower, error)
+}
+
+type followerService struct {
+	followerRepo repositories.TaskFollowerRepository
+	taskRepo     repositories.TaskRepository
+	userRepo     repositories.UserRepository
+}
+
+func NewFollowerService(followerRepo repositories.TaskFollowerRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository) FollowerUseCase {
+	return &followerService{
+		followerRepo: followerRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (f *followerService) AddFollower(ctx context.Context, taskID string, req *requests.TaskFollowerCreateRequest, userID string) ([]models.TaskFollower, error) {
+	// Find the task
+	if _, err := f.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Find the follower
+	follower, err := f.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if follower == nil {
+		return nil, exceptions.ErrUserNotFound
+	}
+
+	// Add follower in database
+	if err := f.followerRepo.Create(ctx, taskID, follower.ID); err != nil {
+		return nil, err
+	}
+
+	return f.followerRepo.FindByTaskID(ctx, taskID)
+}
+
+func (f *followerService) FindFollowers(ctx context.Context, taskID string, userID string) ([]models.TaskFollower, error) {
+	// Find the task
+	if _, err := f.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return f.followerRepo.FindByTaskID(ctx, taskID)
+}
+
+func (f *followerService) RemoveFollower(ctx context.Context, taskID string, followerID string, userID string) ([]models.TaskFollower, error) {
+	// Find the task
+	if _, err := f.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Remove follower from database
+	if err := f.followerRepo.DeleteByTaskIDAndUserID(ctx, taskID, followerID); err != nil {
+		return nil, err
+	}
+
+	return f.followerRepo.FindByTaskID(ctx, taskID)
+}
+
+func (f *followerService) findTask(ctx context.Context, taskID string, userID string) (*models.Task, error) {
+	task, err := f.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task is exist and belong to the user
+	if task == nil || task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	return task, nil
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/importers"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/responses"
+)
+
+type ImportUseCase interface {
+	ImportFromSource(ctx context.Context, source string, req *requests.TaskSourceImportRequest, data io.Reader, userID string) (*responses.TaskImportResponse, error)
+}
+
+type importService struct {
+	taskService TaskUseCase
+	importers   map[string]importers.Importer
+}
+
+func NewImportService(taskService TaskUseCase, importers map[string]importers.Importer) ImportUseCase {
+	return &importService{
+		taskService: taskService,
+		importers:   importers,
+	}
+}
+
+func (i *importService) ImportFromSource(ctx context.Context, source string, req *requests.TaskSourceImportRequest, data io.Reader, userID string) (*responses.TaskImportResponse, error) {
+	// Find the parser for the source
+	importer, ok := i.importers[source]
+	if !ok {
+		return nil, exceptions.ErrUnsupportedImportSource
+	}
+
+	// Map the export onto task rows
+	result, err := importer.Parse(data, &importers.Options{Project: req.Project})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidImport, err.Error())
+	}
+
+	// Rows go through the same checks as any other import
+	response, err := i.taskService.ImportTaskRows(ctx, result.Rows, req.DryRun, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Unmapped = result.Unmapped
+
+	return response, nil
+}
@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/responses"
+)
+
+const defaultNotificationLimit = 20
+
+type NotificationUseCase interface {
+	FindNotifications(ctx context.Context, req *requests.NotificationListRequest, userID string) (*responses.NotificationListResponse, error)
+	MarkNotificationRead(ctx context.Context, notificationID string, userID string) (*models.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, userID string) (int, error)
+	FindPreferences(ctx context.Context, userID string) (map[string]bool, error)
+	UpdatePreferences(ctx context.Context, req *requests.NotificationPreferencesUpdateRequest, userID string) (map[string]bool, error)
+	Notify(ctx context.Context, notification *models.Notification) error
+	HandleTaskEvent(ctx context.Context, event *models.TaskEvent)
+}
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	followerRepo     repositories.TaskFollowerRepository
+}
+
+func NewNotificationService(notificationRepo repositories.NotificationRepository, followerRepo repositories.TaskFollowerRepository) NotificationUseCase {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		followerRepo:     followerRepo,
+	}
+}
+
+func (n *notificationService) FindNotifications(ctx context.Context, req *requests.NotificationListRequest, userID string) (*responses.NotificationListResponse, error) {
+	page := max(req.Page, 1)
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultNotificationLimit
+	}
+
+	// Find the page of notifications
+	notifications, err := n.notificationRepo.FindByUserID(ctx, userID, req.Unread, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count notifications for the pager
+	total, err := n.notificationRepo.CountByUserID(ctx, userID, req.Unread)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := n.notificationRepo.CountByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.NotificationListResponse{
+		Data:        notifications,
+		Page:        page,
+		Limit:       limit,
+		Total:       total,
+		UnreadCount: unreadCount,
+	}, nil
+}
+
+func (n *notificationService) MarkNotificationRead(ctx context.Context, notificationID string, userID string) (*models.Notification, error) {
+	// Find the notification
+	notification, err := n.notificationRepo.FindByID(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check notification is exist and belong to the user
+	if notification == nil || notification.UserID != userID {
+		return nil, exceptions.ErrNotificationNotFound
+	}
+
+	// Mark as read in database
+	if err := n.notificationRepo.MarkReadByID(ctx, notificationID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	// Find the updated notification
+	return n.notificationRepo.FindByID(ctx, notificationID)
+}
+
+func (n *notificationService) MarkAllNotificationsRead(ctx context.Context, userID string) (int, error) {
+	return n.notificationRepo.MarkAllReadByUserID(ctx, userID, time.Now().UTC())
+}
+
+func (n *notificationService) FindPreferences(ctx context.Context, userID string) (map[string]bool, error) {
+	// Every type is enabled unless the user turned it off
+	preferences := make(map[string]bool, len(models.NotificationTypes))
+	for _, notificationType := range models.NotificationTypes {
+		preferences[notificationType] = true
+	}
+
+	stored, err := n.notificationRepo.FindPreferencesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, preference := range stored {
+		if _, known := preferences[preference.Type]; known {
+			preferences[preference.Type] = preference.Enabled
+		}
+	}
+
+	return preferences, nil
+}
+
+func (n *notificationService) UpdatePreferences(ctx context.Context, req *requests.NotificationPreferencesUpdateRequest, userID string) (map[string]bool, error) {
+	// Check notification types
+	for notificationType := range req.Preferences {
+		if !slices.Contains(models.NotificationTypes, notificationType) {
+			return nil, exceptions.ErrInvalidNotificationType
+		}
+	}
+
+	// Update preferences in database
+	for notificationType, enabled := range req.Preferences {
+		if err := n.notificationRepo.UpsertPreference(ctx, userID, notificationType, enabled); err != nil {
+			return nil, err
+		}
+	}
+
+	return n.FindPreferences(ctx, userID)
+}
+
+func (n *notificationService) Notify(ctx context.Context, notification *models.Notification) error {
+	// Check the user wants this type
+	preferences, err := n.FindPreferences(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !preferences[notification.Type] {
+		return nil
+	}
+
+	_, err = n.notificationRepo.Create(ctx, notification)
+
+	return err
+}
+
+func (n *notificationService) HandleTaskEvent(ctx context.Context, event *models.TaskEvent) {
+	if event.Task == nil {
+		return
+	}
+
+	var recipients []string
+	var notification models.Notification
+	switch event.Type {
+	case models.TaskEventAssigned:
+		if event.Task.AssigneeID == nil {
+			return
+		}
+
+		recipients = []string{*event.Task.AssigneeID}
+		notification = models.Notification{
+			Type:  models.NotificationTypeTaskAssigned,
+			Title: "Assigned to you: " + event.Task.Title,
+			Body:  "You were assigned to this task",
+		}
+	case models.TaskEventCommented:
+		if event.Comment == nil {
+			return
+		}
+
+		recipients = event.MentionedUserIDs
+		notification = models.Notification{
+			Type:  models.NotificationTypeCommentMentioned,
+			Title: "Mentioned on: " + event.Task.Title,
+			Body:  event.Comment.Body,
+		}
+	case models.TaskEventStatusChanged:
+		// The owner, the assignee and everyone following the task
+		followers, err := n.followerRepo.FindByTaskID(ctx, event.TaskID)
+		if err != nil {
+			log.Println("❌ Error finding task followers", err)
+			return
+		}
+
+		recipients = []string{event.UserID}
+		if event.Task.AssigneeID != nil {
+			recipients = append(recipients, *event.Task.AssigneeID)
+		}
+
+		for _, follower := range followers {
+			recipients = append(recipients, follower.UserID)
+		}
+
+		notification = models.Notification{
+			Type:  models.NotificationTypeStatusChanged,
+			Title: "Status changed: " + event.Task.Title,
+			Body:  fmt.Sprintf("Status changed from %s to %s", event.PreviousStatus, event.Task.Status),
+		}
+	case models.TaskEventCreated:
+		// Only occurrences the recurrence worker created, users know about their own tasks
+		if event.Task.SeriesID == nil {
+			return
+		}
+
+		body := "A new occurrence of a recurring task was created"
+		if event.Task.DueDate != nil {
+			body = fmt.Sprintf("A new occurrence of a recurring task is due %s", event.Task.DueDate.UTC().Format(dueDateLayout))
+		}
+
+		recipients = []string{event.UserID}
+		notification = models.Notification{
+			Type:  models.NotificationTypeOccurrenceCreated,
+			Title: "New occurrence: " + event.Task.Title,
+			Body:  body,
+		}
+	default:
+		return
+	}
+
+	// Nobody is notified about their own changes, or twice about one
+	notified := map[string]bool{event.ActorID: true}
+	for _, recipient := range recipients {
+		if notified[recipient] {
+			continue
+		}
+		notified[recipient] = true
+
+		notification := notification
+		notification.UserID = recipient
+		notification.TaskID = &event.TaskID
+
+		if err := n.Notify(ctx, &notification); err != nil {
+			log.Println("❌ Error creating notification", err)
+		}
+	}
+}
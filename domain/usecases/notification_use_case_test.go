@@ -0,0 +1,209 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/memory"
+)
+
+// collaboration wires the services whose events fill the inbox
+type collaboration struct {
+	users         repositories.UserRepository
+	tasks         usecases.TaskUseCase
+	comments      usecases.CommentUseCase
+	followers     usecases.FollowerUseCase
+	notifications usecases.NotificationUseCase
+}
+
+func newCollaboration() *collaboration {
+	userRepo := memory.NewUserMemoryRepository()
+	taskRepo := memory.NewTaskMemoryRepository()
+	followerRepo := memory.NewTaskFollowerMemoryRepository()
+	dispatcher := events.NewDispatcher()
+
+	notificationService := usecases.NewNotificationService(memory.NewNotificationMemoryRepository(), followerRepo)
+	dispatcher.Subscribe(notificationService.HandleTaskEvent)
+
+	return &collaboration{
+		users:         userRepo,
+		tasks:         usecases.NewTaskService(taskRepo, nil, userRepo, memory.NewOutboxMemoryRepository(), memory.NewTaskEventMemoryStore(), memory.NewUnitOfWork(), dispatcher),
+		comments:      usecases.NewCommentService(memory.NewTaskCommentMemoryRepository(), taskRepo, userRepo, dispatcher),
+		followers:     usecases.NewFollowerService(followerRepo, taskRepo, userRepo),
+		notifications: notificationService,
+	}
+}
+
+// register creates a user with the email and returns its id
+func (c *collaboration) register(t *testing.T, email string) string {
+	t.Helper()
+
+	ctx := context.Background()
+	if err := c.users.Create(ctx, &requests.UserRegisterRequest{Name: email, Email: email, Password: "password"}); err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	user, err := c.users.FindByEmail(ctx, email)
+	if err != nil || user == nil {
+		t.Fatalf("FindByEmail = %v, %v", user, err)
+	}
+
+	return user.ID
+}
+
+// inbox returns the types of the user's notifications, newest first
+func (c *collaboration) inbox(t *testing.T, userID string) []string {
+	t.Helper()
+
+	found, err := c.notifications.FindNotifications(context.Background(), &requests.NotificationListRequest{}, userID)
+	if err != nil {
+		t.Fatalf("FindNotifications: %v", err)
+	}
+
+	types := []string{}
+	for _, notification := range found.Data {
+		types = append(types, notification.Type)
+	}
+
+	return types
+}
+
+func (c *collaboration) createTask(t *testing.T, ownerID string) *models.Task {
+	t.Helper()
+
+	task, err := c.tasks.CreateTask(context.Background(), &requests.TaskCreateRequest{Title: "Shared", Description: "Description"}, ownerID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	return task
+}
+
+func TestAssigningTaskNotifiesAssignee(t *testing.T) {
+	ctx := context.Background()
+	c := newCollaboration()
+	ownerID := c.register(t, "owner@example.com")
+	assigneeID := c.register(t, "assignee@example.com")
+	task := c.createTask(t, ownerID)
+
+	assigned, err := c.tasks.AssignTask(ctx, task.ID, &requests.TaskAssignRequest{Email: "assignee@example.com"}, ownerID)
+	if err != nil || assigned.AssigneeID == nil || *assigned.AssigneeID != assigneeID {
+		t.Fatalf("AssignTask = %+v, %v, want assigned to %s", assigned, err, assigneeID)
+	}
+
+	if inbox := c.inbox(t, assigneeID); len(inbox) != 1 || inbox[0] != models.NotificationTypeTaskAssigned {
+		t.Errorf("assignee inbox = %v, want one %s", inbox, models.NotificationTypeTaskAssigned)
+	}
+
+	// The owner made the change, and unassigning notifies nobody
+	if _, err := c.tasks.AssignTask(ctx, task.ID, &requests.TaskAssignRequest{}, ownerID); err != nil {
+		t.Fatalf("AssignTask(unassign): %v", err)
+	}
+
+	if inbox := c.inbox(t, ownerID); len(inbox) != 0 {
+		t.Errorf("owner inbox = %v, want empty", inbox)
+	}
+
+	if inbox := c.inbox(t, assigneeID); len(inbox) != 1 {
+		t.Errorf("assignee inbox after unassigning = %v, want still one", inbox)
+	}
+}
+
+func TestCommentNotifiesMentionedUsers(t *testing.T) {
+	ctx := context.Background()
+	c := newCollaboration()
+	ownerID := c.register(t, "owner@example.com")
+	mentionedID := c.register(t, "ada@example.com")
+	bystanderID := c.register(t, "bob@example.com")
+	task := c.createTask(t, ownerID)
+
+	body := "@ada@example.com can you check this? Twice @ada@example.com, myself @owner@example.com, a stranger @nobody@example.com and mail bob@example.com."
+	comment, err := c.comments.CreateComment(ctx, task.ID, &requests.TaskCommentCreateRequest{Body: body}, ownerID)
+	if err != nil || comment.Body != body || comment.TaskID != task.ID {
+		t.Fatalf("CreateComment = %+v, %v, want the comment", comment, err)
+	}
+
+	if inbox := c.inbox(t, mentionedID); len(inbox) != 1 || inbox[0] != models.NotificationTypeCommentMentioned {
+		t.Errorf("mentioned inbox = %v, want one %s", inbox, models.NotificationTypeCommentMentioned)
+	}
+
+	// An email without the @ in front is not a mention
+	if inbox := c.inbox(t, bystanderID); len(inbox) != 0 {
+		t.Errorf("bystander inbox = %v, want empty", inbox)
+	}
+
+	if inbox := c.inbox(t, ownerID); len(inbox) != 0 {
+		t.Errorf("owner inbox = %v, want empty", inbox)
+	}
+}
+
+func TestStatusChangeNotifiesFollowersAndAssignee(t *testing.T) {
+	ctx := context.Background()
+	c := newCollaboration()
+	ownerID := c.register(t, "owner@example.com")
+	followerID := c.register(t, "follower@example.com")
+	assigneeID := c.register(t, "assignee@example.com")
+	formerID := c.register(t, "former@example.com")
+	task := c.createTask(t, ownerID)
+
+	for _, email := range []string{"follower@example.com", "former@example.com", "assignee@example.com"} {
+		if _, err := c.followers.AddFollower(ctx, task.ID, &requests.TaskFollowerCreateRequest{Email: email}, ownerID); err != nil {
+			t.Fatalf("AddFollower(%s): %v", email, err)
+		}
+	}
+
+	if _, err := c.followers.RemoveFollower(ctx, task.ID, formerID, ownerID); err != nil {
+		t.Fatalf("RemoveFollower: %v", err)
+	}
+
+	if _, err := c.tasks.AssignTask(ctx, task.ID, &requests.TaskAssignRequest{Email: "assignee@example.com"}, ownerID); err != nil {
+		t.Fatalf("AssignTask: %v", err)
+	}
+
+	if _, err := c.tasks.UpdateTaskStatusByID(ctx, task.ID, &requests.TaskUpdateStatusRequest{Status: models.TaskStatusCompleted}, ownerID); err != nil {
+		t.Fatalf("UpdateTaskStatusByID: %v", err)
+	}
+
+	if inbox := c.inbox(t, followerID); len(inbox) != 1 || inbox[0] != models.NotificationTypeStatusChanged {
+		t.Errorf("follower inbox = %v, want one %s", inbox, models.NotificationTypeStatusChanged)
+	}
+
+	// Following and being assigned is still one notification
+	want := []string{models.NotificationTypeStatusChanged, models.NotificationTypeTaskAssigned}
+	if inbox := c.inbox(t, assigneeID); len(inbox) != 2 || inbox[0] != want[0] || inbox[1] != want[1] {
+		t.Errorf("assignee inbox = %v, want %v", inbox, want)
+	}
+
+	if inbox := c.inbox(t, formerID); len(inbox) != 0 {
+		t.Errorf("former follower inbox = %v, want empty", inbox)
+	}
+
+	if inbox := c.inbox(t, ownerID); len(inbox) != 0 {
+		t.Errorf("owner inbox = %v, want empty", inbox)
+	}
+}
+
+func TestNotificationsFollowPreferences(t *testing.T) {
+	ctx := context.Background()
+	c := newCollaboration()
+	ownerID := c.register(t, "owner@example.com")
+	assigneeID := c.register(t, "assignee@example.com")
+	task := c.createTask(t, ownerID)
+
+	if _, err := c.notifications.UpdatePreferences(ctx, &requests.NotificationPreferencesUpdateRequest{Preferences: map[string]bool{models.NotificationTypeTaskAssigned: false}}, assigneeID); err != nil {
+		t.Fatalf("UpdatePreferences: %v", err)
+	}
+
+	if _, err := c.tasks.AssignTask(ctx, task.ID, &requests.TaskAssignRequest{Email: "assignee@example.com"}, ownerID); err != nil {
+		t.Fatalf("AssignTask: %v", err)
+	}
+
+	if inbox := c.inbox(t, assigneeID); len(inbox) != 0 {
+		t.Errorf("assignee inbox = %v, want empty with assignments turned off", inbox)
+	}
+}
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+)
+
+const (
+	outboxBatchSize   = 100
+	outboxLease       = time.Minute
+	outboxBaseBackoff = 5 * time.Second
+	outboxMaxBackoff  = 10 * time.Minute
+)
+
+type OutboxUseCase interface {
+	HandleTaskEvent(ctx context.Context, event *models.TaskEvent)
+	RelayPending(ctx context.Context, now time.Time) (int, error)
+	PrunePublished(ctx context.Context, before time.Time) (int, error)
+	Wakeup() <-chan struct{}
+}
+
+// outboxService relays messages the task service wrote to the outbox. A message
+// is only marked published after the publisher accepted it, so a crash between
+// the two publishes it again once its lease runs out: delivery is at least once.
+type outboxService struct {
+	outboxRepo repositories.OutboxRepository
+	publisher  events.Publisher
+	wakeup     chan struct{}
+}
+
+func NewOutboxService(outboxRepo repositories.OutboxRepository, publisher events.Publisher) OutboxUseCase {
+	return &outboxService{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		wakeup:     make(chan struct{}, 1),
+	}
+}
+
+func (o *outboxService) HandleTaskEvent(ctx context.Context, event *models.TaskEvent) {
+	// The event is dispatched after its transaction committed, so its message is ready to relay
+	select {
+	case o.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (o *outboxService) RelayPending(ctx context.Context, now time.Time) (int, error) {
+	// Find messages which are due
+	messages, err := o.outboxRepo.FindDue(ctx, now, outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for i := range messages {
+		message := &messages[i]
+
+		// Claim the message so other instances skip it
+		claimed, err := o.outboxRepo.Claim(ctx, message.ID, now, now.Add(outboxLease))
+		if err != nil {
+			return published, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		// Publish the message, a failure is retried and never dropped
+		if err := o.publisher.Publish(ctx, message); err != nil {
+			if err := o.outboxRepo.Reschedule(ctx, message.ID, err.Error(), now.Add(outboxBackoff(message.Attempts+1))); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := o.outboxRepo.MarkPublished(ctx, message.ID, now); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func (o *outboxService) PrunePublished(ctx context.Context, before time.Time) (int, error) {
+	return o.outboxRepo.DeletePublishedBefore(ctx, before)
+}
+
+func (o *outboxService) Wakeup() <-chan struct{} {
+	return o.wakeup
+}
+
+// outboxBackoff doubles the wait after each failed attempt up to outboxMaxBackoff
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for n := 1; n < attempts && backoff < outboxMaxBackoff; n++ {
+		backoff *= 2
+	}
+
+	return min(backoff, outboxMaxBackoff)
+}
@@ -0,0 +1,63 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/memory"
+)
+
+// flakyPublisher fails the first attempts, then records what it publishes
+type flakyPublisher struct {
+	failures int
+	keys     []string
+}
+
+func (f *flakyPublisher) Publish(ctx context.Context, message *models.OutboxMessage) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("broker unavailable")
+	}
+
+	f.keys = append(f.keys, message.ID)
+	return nil
+}
+
+func TestRelayRetriesUntilPublished(t *testing.T) {
+	ctx := context.Background()
+	outboxRepo := memory.NewOutboxMemoryRepository()
+	publisher := &flakyPublisher{failures: 1}
+	outboxService := usecases.NewOutboxService(outboxRepo, publisher)
+
+	messageID, err := outboxRepo.Create(ctx, models.TaskEventCreated, "task", "{}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	if published, err := outboxService.RelayPending(ctx, now); err != nil || published != 0 {
+		t.Fatalf("RelayPending = %d, %v, want the failed message kept", published, err)
+	}
+
+	// The message waits for its backoff before the next attempt
+	if published, err := outboxService.RelayPending(ctx, now.Add(time.Second)); err != nil || published != 0 {
+		t.Fatalf("RelayPending before the backoff = %d, %v, want 0, nil", published, err)
+	}
+
+	if published, err := outboxService.RelayPending(ctx, now.Add(time.Minute)); err != nil || published != 1 {
+		t.Fatalf("RelayPending after the backoff = %d, %v, want 1, nil", published, err)
+	}
+
+	if len(publisher.keys) != 1 || publisher.keys[0] != messageID {
+		t.Errorf("published keys %v, want [%s]", publisher.keys, messageID)
+	}
+
+	// Nothing is left to relay
+	if published, err := outboxService.RelayPending(ctx, now.Add(time.Hour)); err != nil || published != 0 {
+		t.Errorf("RelayPending again = %d, %v, want 0, nil", published, err)
+	}
+}
@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type ProjectUseCase interface {
+	CreateProject(ctx context.Context, req *requests.ProjectCreateRequest, userID string) (*models.Project, error)
+	FindProjectsByUserID(ctx context.Context, userID string) ([]models.Project, error)
+	DeleteProjectByID(ctx context.Context, projectID string, userID string) (*models.Project, error)
+}
+
+type projectService struct {
+	projectRepo repositories.ProjectRepository
+}
+
+func NewProjectService(projectRepo repositories.ProjectRepository) ProjectUseCase {
+	return &projectService{
+		projectRepo: projectRepo,
+	}
+}
+
+func (p *projectService) CreateProject(ctx context.Context, req *requests.ProjectCreateRequest, userID string) (*models.Project, error) {
+	// Check name is unique for the user
+	existing, err := p.projectRepo.FindByName(ctx, userID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, exceptions.ErrDuplicatedProjectName
+	}
+
+	// Create project
+	projectID, err := p.projectRepo.Create(ctx, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the project
+	return p.projectRepo.FindByID(ctx, projectID)
+}
+
+func (p *projectService) FindProjectsByUserID(ctx context.Context, userID string) ([]models.Project, error) {
+	return p.projectRepo.FindByUserID(ctx, userID)
+}
+
+func (p *projectService) DeleteProjectByID(ctx context.Context, projectID string, userID string) (*models.Project, error) {
+	// Find the project
+	project, err := p.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check project is exist and belong to the user
+	if project == nil || project.UserID != userID {
+		return nil, exceptions.ErrProjectNotFound
+	}
+
+	// Delete project in database
+	if err := p.projectRepo.DeleteByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
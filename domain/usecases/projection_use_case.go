@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GraphZC/sdd-task-management/domain/history"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+)
+
+const projectionPageSize = 100
+
+type ProjectionUseCase interface {
+	RebuildTasks(ctx context.Context) (int, error)
+}
+
+// projectionService rebuilds the tasks table from the task event streams, to
+// recover from a lost or damaged table. Tasks written before streams were kept
+// have none and are left as they are.
+type projectionService struct {
+	eventStore  repositories.TaskEventStore
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+}
+
+func NewProjectionService(eventStore repositories.TaskEventStore, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository) ProjectionUseCase {
+	return &projectionService{
+		eventStore:  eventStore,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (p *projectionService) RebuildTasks(ctx context.Context) (int, error) {
+	// Page through the streams so they are never all held in memory
+	rebuilt := 0
+	afterTaskID := ""
+	for {
+		taskIDs, err := p.eventStore.FindTaskIDsAfter(ctx, afterTaskID, projectionPageSize)
+		if err != nil {
+			return rebuilt, err
+		}
+
+		if len(taskIDs) == 0 {
+			return rebuilt, nil
+		}
+
+		for _, taskID := range taskIDs {
+			if err := p.rebuildTask(ctx, taskID); err != nil {
+				return rebuilt, fmt.Errorf("rebuilding task %s: %w", taskID, err)
+			}
+			rebuilt++
+		}
+
+		afterTaskID = taskIDs[len(taskIDs)-1]
+	}
+}
+
+func (p *projectionService) rebuildTask(ctx context.Context, taskID string) error {
+	stream, err := p.eventStore.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	task, err := history.Replay(stream)
+	if err != nil {
+		return err
+	}
+
+	// A deleted task stays deleted
+	if task == nil {
+		return p.taskRepo.DeleteByID(ctx, taskID)
+	}
+
+	// Deleting a project clears it from its tasks without an event
+	if task.ProjectID != nil {
+		project, err := p.projectRepo.FindByID(ctx, *task.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		if project == nil {
+			task.ProjectID = nil
+		}
+	}
+
+	return p.taskRepo.Save(ctx, task)
+}
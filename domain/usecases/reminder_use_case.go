@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+const (
+	reminderBatchSize   = 100
+	reminderLease       = 5 * time.Minute
+	reminderMaxAttempts = 5
+)
+
+type ReminderUseCase interface {
+	CreateReminder(ctx context.Context, taskID string, req *requests.ReminderCreateRequest, userID string) (*models.Reminder, error)
+	FindRemindersByTaskID(ctx context.Context, taskID string, userID string) ([]models.Reminder, error)
+	DeleteReminderByID(ctx context.Context, taskID string, reminderID string, userID string) (*models.Reminder, error)
+	DispatchDueReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type reminderService struct {
+	reminderRepo repositories.ReminderRepository
+	taskRepo     repositories.TaskRepository
+	userRepo     repositories.UserRepository
+	notifiers    map[string]notifiers.Notifier
+}
+
+func NewReminderService(reminderRepo repositories.ReminderRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository, notifiers map[string]notifiers.Notifier) ReminderUseCase {
+	return &reminderService{
+		reminderRepo: reminderRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		notifiers:    notifiers,
+	}
+}
+
+func (r *reminderService) CreateReminder(ctx context.Context, taskID string, req *requests.ReminderCreateRequest, userID string) (*models.Reminder, error) {
+	// Check reminder time, exactly one of absolute time or offset
+	if (req.RemindAt == nil) == (req.OffsetMinutes == nil) {
+		return nil, exceptions.ErrInvalidReminderTime
+	}
+
+	// Check webhook target is a public http or https address
+	if req.Channel == models.ReminderChannelWebhook && notifiers.ValidateDestination(req.Target) != nil {
+		return nil, exceptions.ErrInvalidReminderTarget
+	}
+
+	// Find the task
+	task, err := r.findTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Offset reminders need a due date to count back from
+	if req.OffsetMinutes != nil && task.DueDate == nil {
+		return nil, exceptions.ErrReminderWithoutDueDate
+	}
+
+	// Create reminder
+	reminderID, err := r.reminderRepo.Create(ctx, req, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the reminder
+	return r.reminderRepo.FindByID(ctx, reminderID)
+}
+
+func (r *reminderService) FindRemindersByTaskID(ctx context.Context, taskID string, userID string) ([]models.Reminder, error) {
+	// Find the task
+	if _, err := r.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return r.reminderRepo.FindByTaskID(ctx, taskID)
+}
+
+func (r *reminderService) DeleteReminderByID(ctx context.Context, taskID string, reminderID string, userID string) (*models.Reminder, error) {
+	// Find the task
+	if _, err := r.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Find the reminder
+	reminder, err := r.reminderRepo.FindByID(ctx, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check reminder is exist and belong to the task
+	if reminder == nil || reminder.TaskID != taskID {
+		return nil, exceptions.ErrReminderNotFound
+	}
+
+	// Delete reminder in database
+	if err := r.reminderRepo.DeleteByID(ctx, reminderID); err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+func (r *reminderService) DispatchDueReminders(ctx context.Context, now time.Time) (int, error) {
+	// Find pending reminders which are due
+	reminders, err := r.reminderRepo.FindDue(ctx, now, reminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range reminders {
+		reminder := &reminders[i]
+
+		// Claim the reminder so other instances skip it
+		claimed, err := r.reminderRepo.Claim(ctx, reminder.ID, now, now.Add(reminderLease))
+		if err != nil {
+			return sent, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		// Send the reminder
+		if err := r.send(ctx, reminder); err != nil {
+			log.Println("❌ Error sending reminder", reminder.ID, err)
+
+			if err := r.retry(ctx, reminder, err, now); err != nil {
+				return sent, err
+			}
+
+			continue
+		}
+
+		if err := r.reminderRepo.MarkSent(ctx, reminder.ID, now); err != nil {
+			return sent, err
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (r *reminderService) send(ctx context.Context, reminder *models.Reminder) error {
+	notifier, ok := r.notifiers[reminder.Channel]
+	if !ok {
+		return exceptions.ErrUnsupportedChannel
+	}
+
+	// Find the task
+	task, err := r.taskRepo.FindByID(ctx, reminder.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if task == nil {
+		return exceptions.ErrTaskNotFound
+	}
+
+	// Resolve the recipient for the channel
+	recipient := reminder.UserID
+	switch reminder.Channel {
+	case models.ReminderChannelEmail:
+		user, err := r.userRepo.FindByID(ctx, reminder.UserID)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			return exceptions.ErrUserNotFound
+		}
+
+		recipient = user.Email
+	case models.ReminderChannelWebhook:
+		if reminder.Target == nil {
+			return exceptions.ErrInvalidReminderTarget
+		}
+
+		recipient = *reminder.Target
+	}
+
+	// The due date is shown in the user's time zone
+	body := task.Description
+	if task.DueDate != nil {
+		location, err := userLocation(ctx, r.userRepo, reminder.UserID)
+		if err != nil {
+			return err
+		}
+
+		body = fmt.Sprintf("Due %s\n\n%s", task.DueDate.In(location).Format(dueDateLayout), task.Description)
+	}
+
+	return notifier.Notify(ctx, &models.NotificationMessage{
+		UserID:    reminder.UserID,
+		TaskID:    task.ID,
+		Type:      models.NotificationTypeReminderDue,
+		Recipient: recipient,
+		Subject:   "Reminder: " + task.Title,
+		Body:      body,
+	})
+}
+
+func (r *reminderService) retry(ctx context.Context, reminder *models.Reminder, sendErr error, now time.Time) error {
+	// Give up once the attempts are used up, the claim already counted this one
+	attempts := reminder.Attempts + 1
+	if attempts >= reminderMaxAttempts {
+		return r.reminderRepo.MarkFailed(ctx, reminder.ID, sendErr.Error())
+	}
+
+	// Back off exponentially between attempts
+	backoff := time.Minute << (attempts - 1)
+
+	return r.reminderRepo.Reschedule(ctx, reminder.ID, sendErr.Error(), now.Add(backoff))
+}
+
+func (r *reminderService) findTask(ctx context.Context, taskID string, userID string) (*models.Task, error) {
+	task, err := r.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task is exist and belong to the user
+	if task == nil || task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	return task, nil
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"log"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+const defaultSearchLimit = 20
+
+type SearchUseCase interface {
+	SearchTasks(ctx context.Context, req *requests.TaskSearchRequest, userID string) ([]models.TaskSearchResult, error)
+	HandleTaskEvent(ctx context.Context, event *models.TaskEvent)
+}
+
+type searchService struct {
+	searchIndex repositories.TaskSearchIndex
+}
+
+func NewSearchService(searchIndex repositories.TaskSearchIndex) SearchUseCase {
+	return &searchService{
+		searchIndex: searchIndex,
+	}
+}
+
+func (s *searchService) SearchTasks(ctx context.Context, req *requests.TaskSearchRequest, userID string) ([]models.TaskSearchResult, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	return s.searchIndex.Search(ctx, userID, req.Query, limit)
+}
+
+func (s *searchService) HandleTaskEvent(ctx context.Context, event *models.TaskEvent) {
+	// Keep the index in step with every task write
+	var err error
+	switch event.Type {
+	case models.TaskEventDeleted:
+		err = s.searchIndex.Remove(ctx, event.TaskID)
+	default:
+		if event.Task != nil {
+			err = s.searchIndex.Index(ctx, event.Task)
+		}
+	}
+
+	if err != nil {
+		log.Println("❌ Error updating search index", err)
+	}
+}
@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/responses"
+)
+
+const (
+	statsDefaultDays = 30
+	statsMaxDays     = 366
+)
+
+type StatsUseCase interface {
+	FindStats(ctx context.Context, req *requests.StatsRequest, userID string) (*responses.StatsResponse, error)
+}
+
+type statsService struct {
+	reportingRepo repositories.ReportingRepository
+	userRepo      repositories.UserRepository
+}
+
+func NewStatsService(reportingRepo repositories.ReportingRepository, userRepo repositories.UserRepository) StatsUseCase {
+	return &statsService{
+		reportingRepo: reportingRepo,
+		userRepo:      userRepo,
+	}
+}
+
+func (s *statsService) FindStats(ctx context.Context, req *requests.StatsRequest, userID string) (*responses.StatsResponse, error) {
+	// Days start at midnight in the user's time zone
+	location, err := userLocation(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(location)
+
+	// Resolve the range, both days are included and it defaults to the last 30 days
+	to := models.StatsPeriodStart(now, models.StatsIntervalDay)
+	if req.To != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, req.To, location)
+		if err != nil {
+			return nil, exceptions.ErrInvalidStatsRange
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, 1-statsDefaultDays)
+	if req.From != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, req.From, location)
+		if err != nil {
+			return nil, exceptions.ErrInvalidStatsRange
+		}
+		from = parsed
+	}
+
+	// Check range
+	if to.Before(from) || to.Sub(from) > statsMaxDays*24*time.Hour {
+		return nil, exceptions.ErrInvalidStatsRange
+	}
+
+	interval := req.Interval
+	if interval == "" {
+		interval = models.StatsIntervalDay
+	}
+
+	end := to.AddDate(0, 0, 1)
+
+	// Aggregate in the reporting store
+	periods, err := s.reportingRepo.CountByPeriod(ctx, userID, from, end, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	cycleTime, err := s.reportingRepo.AverageCycleTime(ctx, userID, from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	openByPriority, err := s.reportingRepo.CountOpenByPriority(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overdue, err := s.reportingRepo.CountOverdue(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.StatsResponse{
+		From:           from.Format(time.DateOnly),
+		To:             to.Format(time.DateOnly),
+		Interval:       interval,
+		Periods:        fillPeriods(periods, from, end, interval),
+		CycleTime:      *cycleTime,
+		OpenByPriority: openByPriority,
+		Overdue:        overdue,
+	}, nil
+}
+
+// fillPeriods adds empty periods so charts get one point per day or week
+func fillPeriods(periods []models.StatsPeriod, from time.Time, end time.Time, interval string) []models.StatsPeriod {
+	counts := map[string]models.StatsPeriod{}
+	for _, period := range periods {
+		counts[period.Period] = period
+	}
+
+	step := 1
+	if interval == models.StatsIntervalWeek {
+		step = 7
+	}
+
+	filled := []models.StatsPeriod{}
+	for start := models.StatsPeriodStart(from, interval); start.Before(end); start = start.AddDate(0, 0, step) {
+		key := start.Format(time.DateOnly)
+
+		period, ok := counts[key]
+		if !ok {
+			period = models.StatsPeriod{Period: key}
+		}
+
+		filled = append(filled, period)
+	}
+
+	return filled
+}
@@ -0,0 +1,162 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/memory"
+)
+
+// newStatsService registers a user in timezone and returns their id with a
+// service reporting over the returned task repository
+func newStatsService(t *testing.T, timezone string) (usecases.StatsUseCase, *memory.TaskMemoryRepository, string) {
+	ctx := context.Background()
+	userRepo := memory.NewUserMemoryRepository()
+	taskRepo := memory.NewTaskMemoryRepository().(*memory.TaskMemoryRepository)
+
+	if err := userRepo.Create(ctx, &requests.UserRegisterRequest{Name: "User", Email: "user@example.com", Password: "password"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	user, err := userRepo.FindByEmail(ctx, "user@example.com")
+	if err != nil || user == nil {
+		t.Fatalf("FindByEmail = %v, %v", user, err)
+	}
+
+	if err := userRepo.UpdateTimezone(ctx, user.ID, timezone); err != nil {
+		t.Fatalf("UpdateTimezone: %v", err)
+	}
+
+	return usecases.NewStatsService(memory.NewReportingMemoryRepository(taskRepo), userRepo), taskRepo, user.ID
+}
+
+func saveTask(t *testing.T, taskRepo *memory.TaskMemoryRepository, task models.Task) {
+	if err := taskRepo.Save(context.Background(), &task); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+func complete(taskRepo *memory.TaskMemoryRepository, taskID string, userID string, at string) {
+	taskRepo.SaveStatusChange(models.TaskStatusChange{TaskID: taskID, UserID: userID, FromStatus: models.TaskStatusTodo, ToStatus: models.TaskStatusCompleted, ChangedAt: *dueDate(at)})
+}
+
+func TestFindStatsFillsEmptyPeriods(t *testing.T) {
+	statsService, taskRepo, userID := newStatsService(t, "UTC")
+
+	saveTask(t, taskRepo, models.Task{ID: "one", UserID: userID, CreatedAt: *dueDate("2030-01-01 09:00:00")})
+	saveTask(t, taskRepo, models.Task{ID: "two", UserID: userID, CreatedAt: *dueDate("2030-01-03 09:00:00")})
+	complete(taskRepo, "one", userID, "2030-01-03 10:00:00")
+
+	// Someone else's tasks are not counted
+	saveTask(t, taskRepo, models.Task{ID: "other", UserID: "someone-else", CreatedAt: *dueDate("2030-01-02 09:00:00")})
+
+	tests := []struct {
+		name string
+		req  requests.StatsRequest
+		want []models.StatsPeriod
+	}{
+		{
+			name: "Day",
+			req:  requests.StatsRequest{From: "2030-01-01", To: "2030-01-04"},
+			want: []models.StatsPeriod{
+				{Period: "2030-01-01", Created: 1},
+				{Period: "2030-01-02"},
+				{Period: "2030-01-03", Created: 1, Completed: 1},
+				{Period: "2030-01-04"},
+			},
+		},
+		{
+			// Weeks start on Monday, 2030-01-01 is a Tuesday
+			name: "Week",
+			req:  requests.StatsRequest{From: "2030-01-02", To: "2030-01-14", Interval: models.StatsIntervalWeek},
+			want: []models.StatsPeriod{
+				{Period: "2029-12-31", Created: 1, Completed: 1},
+				{Period: "2030-01-07"},
+				{Period: "2030-01-14"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := statsService.FindStats(context.Background(), &tt.req, userID)
+			if err != nil || !reflect.DeepEqual(stats.Periods, tt.want) {
+				t.Fatalf("FindStats = %+v, %v, want periods %+v", stats, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindStatsCycleTimeFromStatusHistory(t *testing.T) {
+	statsService, taskRepo, userID := newStatsService(t, "UTC")
+
+	// Reopened and completed again, the first completion counts
+	saveTask(t, taskRepo, models.Task{ID: "reopened", UserID: userID, Status: models.TaskStatusCompleted, CreatedAt: *dueDate("2030-01-01 00:00:00")})
+	complete(taskRepo, "reopened", userID, "2030-01-02 00:00:00")
+	taskRepo.SaveStatusChange(models.TaskStatusChange{TaskID: "reopened", UserID: userID, FromStatus: models.TaskStatusCompleted, ToStatus: models.TaskStatusTodo, ChangedAt: *dueDate("2030-01-03 00:00:00")})
+	complete(taskRepo, "reopened", userID, "2030-01-05 00:00:00")
+
+	saveTask(t, taskRepo, models.Task{ID: "quick", UserID: userID, Status: models.TaskStatusCompleted, CreatedAt: *dueDate("2030-01-01 00:00:00")})
+	complete(taskRepo, "quick", userID, "2030-01-01 12:00:00")
+
+	// Completed after the range
+	saveTask(t, taskRepo, models.Task{ID: "late", UserID: userID, Status: models.TaskStatusCompleted, CreatedAt: *dueDate("2030-01-01 00:00:00")})
+	complete(taskRepo, "late", userID, "2030-01-11 00:00:00")
+
+	// Never completed
+	saveTask(t, taskRepo, models.Task{ID: "open", UserID: userID, Status: models.TaskStatusTodo, CreatedAt: *dueDate("2030-01-01 00:00:00")})
+
+	stats, err := statsService.FindStats(context.Background(), &requests.StatsRequest{From: "2030-01-01", To: "2030-01-10"}, userID)
+	if err != nil {
+		t.Fatalf("FindStats: %v", err)
+	}
+
+	if want := (models.CycleTime{AverageSeconds: 64800, Tasks: 2}); stats.CycleTime != want {
+		t.Errorf("CycleTime = %+v, want %+v", stats.CycleTime, want)
+	}
+}
+
+func TestFindStatsUsesTheUserTimezone(t *testing.T) {
+	statsService, taskRepo, userID := newStatsService(t, "Asia/Tokyo")
+
+	// 23:00 and 01:00 in Tokyo, either side of midnight on 2030-01-02
+	saveTask(t, taskRepo, models.Task{ID: "before", UserID: userID, CreatedAt: *dueDate("2030-01-01 14:00:00")})
+	saveTask(t, taskRepo, models.Task{ID: "after", UserID: userID, CreatedAt: *dueDate("2030-01-01 16:00:00")})
+
+	// 23:30 on 2030-01-02 in Tokyo, still inside the range
+	complete(taskRepo, "after", userID, "2030-01-02 14:30:00")
+
+	stats, err := statsService.FindStats(context.Background(), &requests.StatsRequest{From: "2030-01-02", To: "2030-01-02"}, userID)
+	if err != nil {
+		t.Fatalf("FindStats: %v", err)
+	}
+
+	want := []models.StatsPeriod{{Period: "2030-01-02", Created: 1, Completed: 1}}
+	if !reflect.DeepEqual(stats.Periods, want) {
+		t.Errorf("Periods = %+v, want %+v", stats.Periods, want)
+	}
+
+	if stats.CycleTime.Tasks != 1 {
+		t.Errorf("CycleTime = %+v, want one task", stats.CycleTime)
+	}
+}
+
+func TestFindStatsRejectsInvalidRanges(t *testing.T) {
+	statsService, _, userID := newStatsService(t, "UTC")
+
+	for _, req := range []requests.StatsRequest{
+		{From: "2030-01-02", To: "2030-01-01"},
+		{From: "2029-01-01", To: "2030-01-03"},
+		{From: "01/01/2030"},
+	} {
+		if _, err := statsService.FindStats(context.Background(), &req, userID); !errors.Is(err, exceptions.ErrInvalidStatsRange) {
+			t.Errorf("FindStats(%+v) = %v, want %v", req, err, exceptions.ErrInvalidStatsRange)
+		}
+	}
+}
@@ -2,50 +2,150 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/GraphZC/sdd-task-management/domain/events"
 	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/history"
+	"github.com/GraphZC/sdd-task-management/domain/markdown"
 	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/recurrence"
 	"github.com/GraphZC/sdd-task-management/domain/repositories"
 	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/responses"
+	"github.com/GraphZC/sdd-task-management/domain/transfer"
+	"github.com/GraphZC/sdd-task-management/utils"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 )
 
+const (
+	// Upper bound of occurrences materialised for a single series in one run
+	maxMissedOccurrences = 100
+
+	exportPageSize = 500
+	maxImportRows  = 5000
+
+	// Due dates in notification text, with the zone they are shown in
+	dueDateLayout = "2006-01-02 15:04 MST"
+)
+
+// Patchable task fields, by JSON name, mapped to their request struct field
+var taskPatchFields = map[string]string{
+	"title":              "Title",
+	"description":        "Description",
+	"priority":           "Priority",
+	"dueDate":            "DueDate",
+	"recurrenceRule":     "RecurrenceRule",
+	"recurrenceTimezone": "RecurrenceTimezone",
+	"tags":               "Tags",
+	"projectId":          "ProjectID",
+	"estimateMinutes":    "EstimateMinutes",
+}
+
 type TaskUseCase interface {
 	CreateTask(ctx context.Context, req *requests.TaskCreateRequest, userID string) (*models.Task, error)
 	FindTaskByID(ctx context.Context, taskID string, userID string) (*models.Task, error)
+	FindTaskAsOf(ctx context.Context, taskID string, asOf time.Time, userID string) (*models.Task, error)
 	FindTaskByUserID(ctx context.Context, userID string) ([]models.Task, error)
+	FindTasksByQuery(ctx context.Context, req *requests.TaskListRequest, userID string) ([]models.Task, error)
 	DeleteTaskByID(ctx context.Context, taskID string, userID string) (*models.Task, error)
 	UpdateTaskByID(ctx context.Context, taskID string, req *requests.TaskUpdateRequest, userID string) (*models.Task, error)
+	PatchTaskByID(ctx context.Context, taskID string, req *requests.TaskPatchRequest, userID string) (*models.Task, error)
 	UpdateTaskStatusByID(ctx context.Context, taskID string, req *requests.TaskUpdateStatusRequest, userID string) (*models.Task, error)
+	UpdateChecklistItem(ctx context.Context, taskID string, index int, req *requests.TaskChecklistItemRequest, userID string) (*models.Task, error)
+	AssignTask(ctx context.Context, taskID string, req *requests.TaskAssignRequest, userID string) (*models.Task, error)
+	BulkUpdateTasks(ctx context.Context, req *requests.TaskBulkRequest, userID string) (*responses.TaskBulkResponse, error)
+	ExportTasks(ctx context.Context, format string, w io.Writer, userID string) error
+	ImportTasks(ctx context.Context, req *requests.TaskImportRequest, data io.Reader, userID string) (*responses.TaskImportResponse, error)
+	ImportTaskRows(ctx context.Context, rows []transfer.Row, dryRun bool, userID string) (*responses.TaskImportResponse, error)
+	MaterializeMissedOccurrences(ctx context.Context, now time.Time) (int, error)
+}
+
+type taskImportState struct {
+	seen     map[string]bool
+	projects map[string]*string
 }
 
 type taskService struct {
-	taskRepo repositories.TaskRepository
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	userRepo    repositories.UserRepository
+	outboxRepo  repositories.OutboxRepository
+	eventStore  repositories.TaskEventStore
+	unitOfWork  repositories.UnitOfWork
+	dispatcher  events.Dispatcher
 }
 
-func NewTaskService(taskRepo repositories.TaskRepository) TaskUseCase {
+func NewTaskService(taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, userRepo repositories.UserRepository, outboxRepo repositories.OutboxRepository, eventStore repositories.TaskEventStore, unitOfWork repositories.UnitOfWork, dispatcher events.Dispatcher) TaskUseCase {
 	return &taskService{
-		taskRepo: taskRepo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+		outboxRepo:  outboxRepo,
+		eventStore:  eventStore,
+		unitOfWork:  unitOfWork,
+		dispatcher:  dispatcher,
 	}
 }
 
 func (t *taskService) CreateTask(ctx context.Context, req *requests.TaskCreateRequest, userID string) (*models.Task, error) {
-	// Check priority
-	if req.Priority < 0 || req.Priority > 2 {
-		return nil, exceptions.ErrInvalidPriority
+	// Check priority, recurrence and project
+	if err := t.prepareTask(ctx, req, userID); err != nil {
+		return nil, err
 	}
 
-	// Create task
-	taskID, err := t.taskRepo.Create(ctx, req, userID)
-	if err != nil {
-		return nil, err
+	// Check external id is unique for the user
+	req.ExternalID = emptyToNil(req.ExternalID)
+	if req.ExternalID != nil {
+		existing, err := t.taskRepo.FindByExternalID(ctx, userID, *req.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Exports identify tasks without an external id by their own id
+		if existing == nil {
+			existing, err = t.taskRepo.FindByID(ctx, *req.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+
+			if existing != nil && existing.UserID != userID {
+				existing = nil
+			}
+		}
+
+		if existing != nil {
+			return nil, exceptions.ErrDuplicatedExternalID
+		}
 	}
 
-	// Find the task
-	task, err := t.taskRepo.FindByID(ctx, taskID)
+	// Create task and record its event in the same transaction
+	var task *models.Task
+	var event *models.TaskEvent
+	err := t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = t.taskRepo.Create(ctx, req, userID)
+		if err != nil {
+			return err
+		}
+
+		event = taskEvent(models.TaskEventCreated, task, userID, "")
+		return t.record(ctx, event)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	t.dispatch(ctx, event)
+
 	return task, nil
 }
 
@@ -69,10 +169,46 @@ func (t *taskService) FindTaskByID(ctx context.Context, taskID string, userID st
 	return task, nil
 }
 
+func (t *taskService) FindTaskAsOf(ctx context.Context, taskID string, asOf time.Time, userID string) (*models.Task, error) {
+	// Fold the events of the task up to the instant
+	stream, err := t.eventStore.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := history.Replay(history.Until(stream, asOf))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task existed then and belong to the user
+	if task == nil || task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
 func (t *taskService) FindTaskByUserID(ctx context.Context, userID string) ([]models.Task, error) {
 	return t.taskRepo.FindByUserID(ctx, userID)
 }
 
+func (t *taskService) FindTasksByQuery(ctx context.Context, req *requests.TaskListRequest, userID string) ([]models.Task, error) {
+	// Parse the filter and sort order
+	q, err := parseView(req.Query, req.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dates in the filter are days in the user's time zone
+	location, err := userLocation(ctx, t.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.taskRepo.FindByQuery(ctx, userID, q, time.Now().In(location))
+}
+
 func (t *taskService) DeleteTaskByID(ctx context.Context, taskID string, userID string) (*models.Task, error) {
 	// Find the task
 	task, err := t.taskRepo.FindByID(ctx, taskID)
@@ -90,19 +226,28 @@ func (t *taskService) DeleteTaskByID(ctx context.Context, taskID string, userID
 		return nil, exceptions.ErrTaskNotFound
 	}
 
-	// Delete task in database
-	err = t.taskRepo.DeleteByID(ctx, taskID)
+	// Delete task in database and record its event in the same transaction
+	event := taskEvent(models.TaskEventDeleted, task, userID, "")
+	err = t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := t.taskRepo.DeleteByID(ctx, taskID); err != nil {
+			return err
+		}
+
+		return t.record(ctx, event)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	t.dispatch(ctx, event)
+
 	return task, nil
 }
 
 func (t *taskService) UpdateTaskByID(ctx context.Context, taskID string, req *requests.TaskCreateRequest, userID string) (*models.Task, error) {
-	// Check priority
-	if req.Priority < 0 || req.Priority > 2 {
-		return nil, exceptions.ErrInvalidPriority
+	// Check priority, recurrence and project
+	if err := t.prepareTask(ctx, req, userID); err != nil {
+		return nil, err
 	}
 
 	// Find the task
@@ -121,16 +266,143 @@ func (t *taskService) UpdateTaskByID(ctx context.Context, taskID string, req *re
 		return nil, exceptions.ErrTaskNotFound
 	}
 
-	// Update task in database
-	err = t.taskRepo.UpdateByUD(ctx, taskID, req)
+	// Update task in database and record its event in the same transaction
+	var event *models.TaskEvent
+	err = t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := t.taskRepo.UpdateByUD(ctx, taskID, req); err != nil {
+			return err
+		}
+
+		// Find the updated task
+		var err error
+		task, err = t.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		event = taskEvent(models.TaskEventUpdated, task, userID, "")
+		return t.record(ctx, event)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update task
-	task.Title = req.Title
-	task.Description = req.Description
-	task.Priority = req.Priority
+	t.dispatch(ctx, event)
+
+	return task, nil
+}
+
+func (t *taskService) PatchTaskByID(ctx context.Context, taskID string, req *requests.TaskPatchRequest, userID string) (*models.Task, error) {
+	// Find the task
+	task, err := t.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task is exist
+	if task == nil {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	// Check task is belong to the user
+	if task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	// Apply the patch to the current state of the task
+	document, err := taskPatchDocument(task)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := applyPatch(document, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := changedPatchFields(document, patched)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing to write
+	if len(fields) == 0 {
+		return task, nil
+	}
+
+	var update requests.TaskUpdateRequest
+	if err := json.Unmarshal(patched, &update); err != nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidPatch, err.Error())
+	}
+
+	// Validate only the fields the patch changed
+	structFields := make([]string, len(fields))
+	for i, field := range fields {
+		structFields[i] = taskPatchFields[field]
+	}
+
+	if err := utils.ValidateStructPartial(&update, structFields...); err != nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidPatch, err.Message)
+	}
+
+	// Check priority
+	if slices.Contains(fields, "priority") && !validPriority(update.Priority) {
+		return nil, exceptions.ErrInvalidPriority
+	}
+
+	// Check recurrence, the rule and timezone are written together
+	if slices.Contains(fields, "dueDate") || slices.Contains(fields, "recurrenceRule") || slices.Contains(fields, "recurrenceTimezone") {
+		if err := normalizeRecurrence(&update); err != nil {
+			return nil, err
+		}
+
+		for _, field := range []string{"recurrenceRule", "recurrenceTimezone"} {
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	update.Tags = normalizeTags(update.Tags)
+
+	// Render the Markdown description
+	if slices.Contains(fields, "description") {
+		update.DescriptionHTML, err = markdown.Render(update.Description)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check project
+	if slices.Contains(fields, "projectId") {
+		update.ProjectID = emptyToNil(update.ProjectID)
+		if err := t.checkProject(ctx, update.ProjectID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update changed columns in database and record the event in the same transaction
+	var event *models.TaskEvent
+	err = t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := t.taskRepo.PatchByID(ctx, taskID, &update, fields); err != nil {
+			return err
+		}
+
+		// Find the updated task
+		var err error
+		task, err = t.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		event = taskEvent(models.TaskEventUpdated, task, userID, "")
+		return t.record(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.dispatch(ctx, event)
 
 	return task, nil
 }
@@ -157,14 +429,985 @@ func (t *taskService) UpdateTaskStatusByID(ctx context.Context, taskID string, r
 		return nil, exceptions.ErrTaskNotFound
 	}
 
-	// Update status in database
-	err = t.taskRepo.UpdateStatusByID(ctx, taskID, req.Status)
+	// Update status in database, a completed recurring task rolls forward in the same transaction
+	previousStatus := task.Status
+	var taskEvents []*models.TaskEvent
+	err = t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := t.taskRepo.UpdateStatusByID(ctx, taskID, req.Status); err != nil {
+			return err
+		}
+
+		// Update task
+		task.Status = req.Status
+
+		taskEvents = nil
+		if previousStatus != task.Status {
+			taskEvents = append(taskEvents, taskEvent(models.TaskEventStatusChanged, task, userID, previousStatus))
+		}
+
+		if req.Status == models.TaskStatusCompleted && task.RecurrenceRule != nil {
+			occurrence, err := t.createNextOccurrence(ctx, task)
+			if err != nil {
+				return err
+			}
+
+			taskEvents = append(taskEvents, taskEvent(models.TaskEventCreated, occurrence, userID, ""))
+		}
+
+		return t.record(ctx, taskEvents...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.dispatch(ctx, taskEvents...)
+
+	return task, nil
+}
+
+func (t *taskService) AssignTask(ctx context.Context, taskID string, req *requests.TaskAssignRequest, userID string) (*models.Task, error) {
+	// Find the task
+	task, err := t.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task is exist and belong to the user
+	if task == nil || task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	// Find the assignee, no email unassigns the task
+	var assigneeID *string
+	if req.Email != "" {
+		assignee, err := t.userRepo.FindByEmail(ctx, req.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		if assignee == nil {
+			return nil, exceptions.ErrUserNotFound
+		}
+
+		assigneeID = &assignee.ID
+	}
+
+	// Update assignee in database
+	var event *models.TaskEvent
+	err = t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := t.taskRepo.AssignByID(ctx, taskID, assigneeID); err != nil {
+			return err
+		}
+
+		task, err = t.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		event = taskEvent(models.TaskEventAssigned, task, userID, "")
+
+		return t.record(ctx, event)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update task
-	task.Status = req.Status
+	t.dispatch(ctx, event)
 
 	return task, nil
 }
+
+func (t *taskService) BulkUpdateTasks(ctx context.Context, req *requests.TaskBulkRequest, userID string) (*responses.TaskBulkResponse, error) {
+	response := &responses.TaskBulkResponse{
+		Atomic:  req.Atomic,
+		Results: make([]responses.TaskBulkResult, len(req.Operations)),
+	}
+
+	// Find every task touched by the batch
+	taskIDs := []string{}
+	for _, operation := range req.Operations {
+		if !slices.Contains(taskIDs, operation.TaskID) {
+			taskIDs = append(taskIDs, operation.TaskID)
+		}
+	}
+
+	tasks, err := t.taskRepo.FindByIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	originals := map[string]models.Task{}
+	for _, task := range tasks {
+		if task.UserID == userID {
+			originals[task.ID] = task
+		}
+	}
+
+	// Validate each operation before touching the database
+	valid := []requests.TaskBulkOperation{}
+	validIndexes := []int{}
+	deleting := map[string]bool{}
+	for i := range req.Operations {
+		operation := &req.Operations[i]
+		response.Results[i] = responses.TaskBulkResult{
+			Index:  i,
+			TaskID: operation.TaskID,
+			Action: operation.Action,
+		}
+
+		_, found := originals[operation.TaskID]
+		if !found || deleting[operation.TaskID] {
+			response.Results[i].Error = exceptions.ErrTaskNotFound.Error()
+			continue
+		}
+
+		if err := t.checkBulkOperation(ctx, operation, userID); err != nil {
+			response.Results[i].Error = err.Error()
+			continue
+		}
+
+		if operation.Action == models.TaskBulkActionDelete {
+			deleting[operation.TaskID] = true
+		}
+
+		valid = append(valid, *operation)
+		validIndexes = append(validIndexes, i)
+	}
+
+	// Nothing is applied when an atomic batch has an invalid operation
+	if req.Atomic && len(valid) != len(req.Operations) {
+		markRolledBack(response)
+		return response, nil
+	}
+
+	// Apply the batch and record its events in a single transaction
+	current := map[string]*models.Task{}
+	var taskEvents []*models.TaskEvent
+	rolledBack := false
+	err = t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		errs, err := t.taskRepo.ApplyBulk(ctx, valid, req.Atomic)
+		failed := false
+		for n, i := range validIndexes {
+			if errs[n] != nil {
+				response.Results[i].Error = errs[n].Error()
+				failed = true
+			}
+		}
+
+		if err != nil {
+			if req.Atomic && failed {
+				rolledBack = true
+				return nil
+			}
+
+			return err
+		}
+
+		// Only the operations that were applied change a task
+		deleted := map[string]bool{}
+		for n, operation := range valid {
+			if errs[n] == nil && operation.Action == models.TaskBulkActionDelete {
+				deleted[operation.TaskID] = true
+			}
+		}
+
+		// Find the tasks as they are after the batch
+		touched := []string{}
+		for n, operation := range valid {
+			if errs[n] == nil && !deleted[operation.TaskID] && !slices.Contains(touched, operation.TaskID) {
+				touched = append(touched, operation.TaskID)
+			}
+		}
+
+		updated, err := t.taskRepo.FindByIDs(ctx, touched)
+		if err != nil {
+			return err
+		}
+
+		for i := range updated {
+			current[updated[i].ID] = &updated[i]
+		}
+
+		// One event per task that changed
+		taskEvents = nil
+		for _, taskID := range taskIDs {
+			original, found := originals[taskID]
+			if !found {
+				continue
+			}
+
+			if deleted[taskID] {
+				taskEvents = append(taskEvents, taskEvent(models.TaskEventDeleted, &original, userID, ""))
+				continue
+			}
+
+			task := current[taskID]
+			if task == nil {
+				continue
+			}
+
+			if task.Status != original.Status {
+				taskEvents = append(taskEvents, taskEvent(models.TaskEventStatusChanged, task, userID, original.Status))
+
+				// Roll a completed recurring task forward
+				if task.Status == models.TaskStatusCompleted && task.RecurrenceRule != nil {
+					occurrence, err := t.createNextOccurrence(ctx, task)
+					if err != nil {
+						return err
+					}
+
+					taskEvents = append(taskEvents, taskEvent(models.TaskEventCreated, occurrence, userID, ""))
+				}
+			} else if slices.Contains(touched, taskID) {
+				taskEvents = append(taskEvents, taskEvent(models.TaskEventUpdated, task, userID, ""))
+			}
+		}
+
+		return t.record(ctx, taskEvents...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if rolledBack {
+		markRolledBack(response)
+		return response, nil
+	}
+
+	response.Committed = true
+
+	for i := range response.Results {
+		result := &response.Results[i]
+		if result.Error != "" {
+			response.Failed++
+			continue
+		}
+
+		result.Success = true
+		result.Task = current[result.TaskID]
+		response.Succeeded++
+	}
+
+	t.dispatch(ctx, taskEvents...)
+
+	return response, nil
+}
+
+func (t *taskService) ExportTasks(ctx context.Context, format string, w io.Writer, userID string) error {
+	encoder, err := transfer.NewEncoder(format, w)
+	if err != nil {
+		return err
+	}
+
+	// Projects are exported by name
+	projects, err := t.projectRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	projectNames := map[string]string{}
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+	}
+
+	// Page through the tasks so the whole list is never held in memory
+	afterID := ""
+	heads := map[string]string{}
+	for {
+		// Stop between pages once the caller gives up
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		tasks, err := t.taskRepo.FindByUserIDAfter(ctx, userID, afterID, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range tasks {
+			record := taskImportRecord(&tasks[i])
+
+			if tasks[i].ProjectID != nil {
+				record.Project = projectNames[*tasks[i].ProjectID]
+			}
+
+			// Only the latest occurrence keeps the rule, so a re-import starts one series
+			if tasks[i].SeriesID != nil {
+				headID, ok := heads[*tasks[i].SeriesID]
+				if !ok {
+					latest, err := t.taskRepo.FindLatestBySeriesID(ctx, *tasks[i].SeriesID)
+					if err != nil {
+						return err
+					}
+
+					if latest != nil {
+						headID = latest.ID
+					}
+					heads[*tasks[i].SeriesID] = headID
+				}
+
+				if headID != tasks[i].ID {
+					record.RecurrenceRule = ""
+					record.RecurrenceTimezone = ""
+				}
+			}
+
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+
+		if len(tasks) < exportPageSize {
+			break
+		}
+
+		afterID = tasks[len(tasks)-1].ID
+	}
+
+	return encoder.Close()
+}
+
+func (t *taskService) ImportTasks(ctx context.Context, req *requests.TaskImportRequest, data io.Reader, userID string) (*responses.TaskImportResponse, error) {
+	// Decode every row before creating anything
+	rows, err := transfer.Decode(req.Format, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidImport, err.Error())
+	}
+
+	return t.ImportTaskRows(ctx, rows, req.DryRun, userID)
+}
+
+func (t *taskService) ImportTaskRows(ctx context.Context, rows []transfer.Row, dryRun bool, userID string) (*responses.TaskImportResponse, error) {
+	if len(rows) > maxImportRows {
+		return nil, exceptions.ErrImportTooLarge
+	}
+
+	response := &responses.TaskImportResponse{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]responses.TaskImportRowResult, 0, len(rows)),
+	}
+
+	state := &taskImportState{
+		seen:     map[string]bool{},
+		projects: map[string]*string{},
+	}
+	for _, row := range rows {
+		result, err := t.importRow(ctx, &row, dryRun, state, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch result.Status {
+		case models.TaskImportStatusCreated:
+			response.Created++
+		case models.TaskImportStatusSkipped:
+			response.Skipped++
+		case models.TaskImportStatusFailed:
+			response.Failed++
+		}
+
+		response.Rows = append(response.Rows, *result)
+	}
+
+	return response, nil
+}
+
+func (t *taskService) importRow(ctx context.Context, row *transfer.Row, dryRun bool, state *taskImportState, userID string) (*responses.TaskImportRowResult, error) {
+	result := &responses.TaskImportRowResult{
+		Line:   row.Line,
+		Status: models.TaskImportStatusFailed,
+	}
+
+	if row.Err != nil {
+		result.Error = row.Err.Error()
+		return result, nil
+	}
+
+	record := row.Record
+	result.ExternalID = record.ExternalID
+
+	// Validate row
+	if err := utils.ValidateStruct(record); err != nil {
+		result.Error = err.Message
+		return result, nil
+	}
+
+	req := &requests.TaskCreateRequest{
+		Title:              record.Title,
+		Description:        record.Description,
+		Priority:           record.Priority,
+		DueDate:            record.DueDate,
+		RecurrenceRule:     record.RecurrenceRule,
+		RecurrenceTimezone: record.RecurrenceTimezone,
+		Tags:               record.Tags,
+		ExternalID:         emptyToNil(&record.ExternalID),
+	}
+
+	// Check priority and recurrence
+	if err := t.prepareTask(ctx, req, userID); err != nil {
+		if errors.Is(err, exceptions.ErrInvalidPriority) || errors.Is(err, exceptions.ErrInvalidRecurrenceRule) || errors.Is(err, exceptions.ErrRecurrenceWithoutDueDate) {
+			result.Error = err.Error()
+			return result, nil
+		}
+
+		return nil, err
+	}
+
+	// Skip rows whose external id was already imported
+	if req.ExternalID != nil {
+		if state.seen[*req.ExternalID] {
+			result.Status = models.TaskImportStatusSkipped
+			result.Error = exceptions.ErrDuplicatedExternalID.Error()
+			return result, nil
+		}
+		state.seen[*req.ExternalID] = true
+
+		existing, err := t.taskRepo.FindByExternalID(ctx, userID, *req.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Exports identify tasks without an external id by their own id
+		if existing == nil {
+			existing, err = t.taskRepo.FindByID(ctx, *req.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+
+			if existing != nil && existing.UserID != userID {
+				existing = nil
+			}
+		}
+
+		if existing != nil {
+			result.Status = models.TaskImportStatusSkipped
+			result.TaskID = existing.ID
+			return result, nil
+		}
+	}
+
+	// Put the task in its project, creating the project on first use
+	if name := strings.TrimSpace(record.Project); name != "" {
+		projectID, err := t.importProject(ctx, name, dryRun, state, userID)
+		if err != nil {
+			return nil, err
+		}
+		req.ProjectID = projectID
+	}
+
+	if dryRun {
+		result.Status = models.TaskImportStatusValid
+		return result, nil
+	}
+
+	// Create task together with its imported status and event
+	var task *models.Task
+	var event *models.TaskEvent
+	err := t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = t.taskRepo.Create(ctx, req, userID)
+		if err != nil {
+			return err
+		}
+
+		if record.Status != "" && record.Status != models.TaskStatusTodo {
+			if err := t.taskRepo.UpdateStatusByID(ctx, task.ID, record.Status); err != nil {
+				return err
+			}
+			task.Status = record.Status
+		}
+
+		event = taskEvent(models.TaskEventCreated, task, userID, "")
+		return t.record(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.dispatch(ctx, event)
+
+	result.Status = models.TaskImportStatusCreated
+	result.TaskID = task.ID
+
+	return result, nil
+}
+
+func (t *taskService) importProject(ctx context.Context, name string, dryRun bool, state *taskImportState, userID string) (*string, error) {
+	if projectID, ok := state.projects[name]; ok {
+		return projectID, nil
+	}
+
+	project, err := t.projectRepo.FindByName(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectID *string
+	switch {
+	case project != nil:
+		projectID = &project.ID
+	case !dryRun:
+		id, err := t.projectRepo.Create(ctx, &requests.ProjectCreateRequest{Name: name}, userID)
+		if err != nil {
+			return nil, err
+		}
+		projectID = &id
+	}
+
+	state.projects[name] = projectID
+
+	return projectID, nil
+}
+
+func (t *taskService) checkBulkOperation(ctx context.Context, operation *requests.TaskBulkOperation, userID string) error {
+	switch operation.Action {
+	case models.TaskBulkActionUpdateStatus:
+		if operation.Status != models.TaskStatusTodo && operation.Status != models.TaskStatusCompleted {
+			return exceptions.ErrInvalidStatus
+		}
+	case models.TaskBulkActionChangePriority:
+		if !validPriority(operation.Priority) {
+			return exceptions.ErrInvalidPriority
+		}
+	case models.TaskBulkActionAddTag, models.TaskBulkActionRemoveTag:
+		tags := normalizeTags([]string{operation.Tag})
+		if len(tags) == 0 {
+			return exceptions.ErrInvalidTag
+		}
+		operation.Tag = tags[0]
+	case models.TaskBulkActionMoveProject:
+		operation.ProjectID = emptyToNil(operation.ProjectID)
+		return t.checkProject(ctx, operation.ProjectID, userID)
+	}
+
+	return nil
+}
+
+func (t *taskService) prepareTask(ctx context.Context, req *requests.TaskCreateRequest, userID string) error {
+	// Check priority
+	if !validPriority(req.Priority) {
+		return exceptions.ErrInvalidPriority
+	}
+
+	// Check recurrence
+	if err := normalizeRecurrence(req); err != nil {
+		return err
+	}
+
+	req.Tags = normalizeTags(req.Tags)
+
+	// Render the Markdown description
+	descriptionHTML, err := markdown.Render(req.Description)
+	if err != nil {
+		return err
+	}
+	req.DescriptionHTML = descriptionHTML
+
+	// Check project, an empty project leaves the task without one
+	req.ProjectID = emptyToNil(req.ProjectID)
+	return t.checkProject(ctx, req.ProjectID, userID)
+}
+
+func (t *taskService) checkProject(ctx context.Context, projectID *string, userID string) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := t.projectRepo.FindByID(ctx, *projectID)
+	if err != nil {
+		return err
+	}
+
+	// Check project is exist and belong to the user
+	if project == nil || project.UserID != userID {
+		return exceptions.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func markRolledBack(response *responses.TaskBulkResponse) {
+	response.Succeeded = 0
+	response.Failed = len(response.Results)
+	for i := range response.Results {
+		if response.Results[i].Error == "" {
+			response.Results[i].Error = exceptions.ErrBulkOperationRolledBack.Error()
+		}
+	}
+}
+
+func (t *taskService) UpdateChecklistItem(ctx context.Context, taskID string, index int, req *requests.TaskChecklistItemRequest, userID string) (*models.Task, error) {
+	// Find the task
+	task, err := t.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task is exist
+	if task == nil {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	// Check task is belong to the user
+	if task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	// Rewrite the checkbox in the Markdown source
+	description, err := markdown.ToggleTaskItem(task.Description, index, *req.Checked)
+	if err == markdown.ErrTaskItemNotFound {
+		return nil, exceptions.ErrChecklistItemNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing to write
+	if description == task.Description {
+		return task, nil
+	}
+
+	descriptionHTML, err := markdown.Render(description)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update description in database and record the event in the same transaction
+	var event *models.TaskEvent
+	err = t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		err := t.taskRepo.PatchByID(ctx, taskID, &requests.TaskUpdateRequest{
+			Description:     description,
+			DescriptionHTML: descriptionHTML,
+		}, []string{"description"})
+		if err != nil {
+			return err
+		}
+
+		// Find the updated task
+		task, err = t.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		event = taskEvent(models.TaskEventUpdated, task, userID, "")
+		return t.record(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.dispatch(ctx, event)
+
+	return task, nil
+}
+
+func (t *taskService) MaterializeMissedOccurrences(ctx context.Context, now time.Time) (int, error) {
+	// Find the latest occurrence of every series that is already past due
+	tasks, err := t.taskRepo.FindRecurringDueBefore(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range tasks {
+		task := &tasks[i]
+
+		// Create every missed occurrence plus the next upcoming one
+		for n := 0; n < maxMissedOccurrences; n++ {
+			// Each occurrence commits together with its event
+			var occurrence *models.Task
+			var event *models.TaskEvent
+			err := t.unitOfWork.Do(ctx, func(ctx context.Context) error {
+				var err error
+				occurrence, err = t.createNextOccurrence(ctx, task)
+				if err != nil {
+					return err
+				}
+
+				event = taskEvent(models.TaskEventCreated, occurrence, "", "")
+				return t.record(ctx, event)
+			})
+			if err != nil {
+				// One broken series must not hold up the others
+				log.Println("❌ Error creating occurrence of task", task.ID, err)
+				break
+			}
+
+			if occurrence == nil || occurrence.DueDate == nil {
+				break
+			}
+
+			t.dispatch(ctx, event)
+			created++
+
+			if !occurrence.DueDate.Before(now) {
+				break
+			}
+
+			task = occurrence
+		}
+	}
+
+	return created, nil
+}
+
+// createNextOccurrence returns the occurrence it created, or nil when the series
+// has ended or a later occurrence already exists
+func (t *taskService) createNextOccurrence(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if task.DueDate == nil || task.SeriesID == nil {
+		return nil, nil
+	}
+
+	// Skip when a later occurrence already exists
+	latest, err := t.taskRepo.FindLatestBySeriesID(ctx, *task.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if latest != nil && latest.ID != task.ID && latest.DueDate != nil && latest.DueDate.After(*task.DueDate) {
+		return nil, nil
+	}
+
+	timezone := ""
+	if task.RecurrenceTimezone != nil {
+		timezone = *task.RecurrenceTimezone
+	}
+
+	rule, err := recurrence.Parse(*task.RecurrenceRule, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// The series has ended, keep it out of the next catch-up
+	next, ok := rule.Next(*task.DueDate)
+	if !ok {
+		return nil, t.taskRepo.EndRecurrenceByID(ctx, task.ID)
+	}
+
+	// Create the next occurrence
+	occurrenceID, err := t.taskRepo.CreateOccurrence(ctx, task, next)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.taskRepo.FindByID(ctx, occurrenceID)
+}
+
+// taskEvent describes a change to task, or returns nil without a task
+func taskEvent(eventType string, task *models.Task, actorID string, previousStatus string) *models.TaskEvent {
+	if task == nil {
+		return nil
+	}
+
+	return &models.TaskEvent{
+		Type:           eventType,
+		TaskID:         task.ID,
+		UserID:         task.UserID,
+		ActorID:        actorID,
+		PreviousStatus: previousStatus,
+		Task:           task,
+		OccurredAt:     time.Now().UTC(),
+	}
+}
+
+// record appends the events to the task streams and writes them to the outbox.
+// Callers run it in the unit of work making the change, so the events are kept
+// exactly when the change commits.
+func (t *taskService) record(ctx context.Context, taskEvents ...*models.TaskEvent) error {
+	for _, event := range taskEvents {
+		if event == nil {
+			continue
+		}
+
+		stream, err := t.eventStore.FindByTaskID(ctx, event.TaskID)
+		if err != nil {
+			return err
+		}
+
+		next, err := history.Next(stream, event)
+		if err != nil {
+			return err
+		}
+
+		if err := t.eventStore.Append(ctx, next); err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		if _, err := t.outboxRepo.Create(ctx, event.Type, event.TaskID, string(payload)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dispatch hands committed events to the subscribers in this process
+func (t *taskService) dispatch(ctx context.Context, taskEvents ...*models.TaskEvent) {
+	for _, event := range taskEvents {
+		if event != nil {
+			t.dispatcher.Dispatch(ctx, event)
+		}
+	}
+}
+
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	// Tags are case-insensitive and unique per task
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
+func taskPatchDocument(task *models.Task) ([]byte, error) {
+	document := map[string]any{
+		"title":              task.Title,
+		"description":        task.Description,
+		"priority":           task.Priority,
+		"dueDate":            task.DueDate,
+		"recurrenceRule":     task.RecurrenceRule,
+		"recurrenceTimezone": task.RecurrenceTimezone,
+		"tags":               task.Tags,
+		"projectId":          task.ProjectID,
+		"estimateMinutes":    task.EstimateMinutes,
+	}
+
+	if task.Tags == nil {
+		document["tags"] = []string{}
+	}
+
+	return json.Marshal(document)
+}
+
+func applyPatch(document []byte, req *requests.TaskPatchRequest) ([]byte, error) {
+	// RFC 7396 merge patch
+	if !req.JSONPatch {
+		patched, err := jsonpatch.MergePatch(document, req.Patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidPatch, err.Error())
+		}
+
+		return patched, nil
+	}
+
+	// RFC 6902 JSON patch
+	patch, err := jsonpatch.DecodePatch(req.Patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidPatch, err.Error())
+	}
+
+	patched, err := patch.Apply(document)
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return nil, exceptions.ErrPatchTestFailed
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidPatch, err.Error())
+	}
+
+	return patched, nil
+}
+
+func changedPatchFields(document []byte, patched []byte) ([]string, error) {
+	var before, after map[string]any
+	if err := json.Unmarshal(document, &before); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrInvalidPatch, err.Error())
+	}
+
+	for field := range after {
+		if _, ok := taskPatchFields[field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %s", exceptions.ErrInvalidPatch, field)
+		}
+	}
+
+	// A removed field is the same as null
+	fields := []string{}
+	for field := range taskPatchFields {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+
+	return fields, nil
+}
+
+func taskImportRecord(task *models.Task) *requests.TaskImportRecord {
+	// Tasks without an external id are identified by their own id on re-import
+	record := &requests.TaskImportRecord{
+		ExternalID:  task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		Tags:        task.Tags,
+	}
+
+	if task.ExternalID != nil {
+		record.ExternalID = *task.ExternalID
+	}
+
+	if task.RecurrenceRule != nil {
+		record.RecurrenceRule = *task.RecurrenceRule
+	}
+
+	if task.RecurrenceTimezone != nil {
+		record.RecurrenceTimezone = *task.RecurrenceTimezone
+	}
+
+	return record
+}
+
+func validPriority(priority int) bool {
+	return priority >= 0 && priority <= 2
+}
+
+func emptyToNil(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+
+	return value
+}
+
+func normalizeRecurrence(req *requests.TaskCreateRequest) error {
+	if req.RecurrenceRule == "" {
+		req.RecurrenceTimezone = ""
+		return nil
+	}
+
+	// Occurrences roll forward from the due date
+	if req.DueDate == nil {
+		return exceptions.ErrRecurrenceWithoutDueDate
+	}
+
+	rule, err := recurrence.Parse(req.RecurrenceRule, req.RecurrenceTimezone)
+	if err != nil {
+		return err
+	}
+
+	req.RecurrenceRule = rule.String()
+	req.RecurrenceTimezone = rule.Location.String()
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/memory"
+)
+
+const userID = "0190a6f0-0000-7000-8000-000000000001"
+
+func newTaskService() usecases.TaskUseCase {
+	return usecases.NewTaskService(memory.NewTaskMemoryRepository(), memory.NewProjectMemoryRepository(), memory.NewUserMemoryRepository(), memory.NewOutboxMemoryRepository(), memory.NewTaskEventMemoryStore(), memory.NewUnitOfWork(), events.NewDispatcher())
+}
+
+func dueDate(value string) *time.Time {
+	t, _ := time.Parse(time.DateTime, value)
+	return &t
+}
+
+func TestCreateTaskRejectsInvalidPriority(t *testing.T) {
+	taskService := newTaskService()
+
+	for _, priority := range []int{-1, 3, 9} {
+		_, err := taskService.CreateTask(context.Background(), &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: priority}, userID)
+		if !errors.Is(err, exceptions.ErrInvalidPriority) {
+			t.Fatalf("CreateTask(priority %d) = %v, want %v", priority, err, exceptions.ErrInvalidPriority)
+		}
+	}
+}
+
+func TestImportTasksSkipsExportedTasks(t *testing.T) {
+	ctx := context.Background()
+	taskService := newTaskService()
+
+	task, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	// A backup names tasks without an external id by their own id
+	data := strings.NewReader(`{"externalId":"` + task.ID + `","title":"Task","description":"Description","priority":0}`)
+	result, err := taskService.ImportTasks(ctx, &requests.TaskImportRequest{Format: "ndjson"}, data, userID)
+	if err != nil || result.Skipped != 1 || result.Created != 0 || result.Rows[0].TaskID != task.ID {
+		t.Fatalf("ImportTasks = %+v, %v, want the task skipped", result, err)
+	}
+
+	// Someone else importing the same backup gets their own copy
+	data = strings.NewReader(`{"externalId":"` + task.ID + `","title":"Task","description":"Description","priority":0}`)
+	result, err = taskService.ImportTasks(ctx, &requests.TaskImportRequest{Format: "ndjson"}, data, "someone-else")
+	if err != nil || result.Created != 1 {
+		t.Errorf("ImportTasks(other user) = %+v, %v, want the task created", result, err)
+	}
+}
+
+func TestImportTasksAcceptsLowPriority(t *testing.T) {
+	taskService := newTaskService()
+
+	data := strings.NewReader(`{"title":"Task","description":"Description","priority":0}`)
+	result, err := taskService.ImportTasks(context.Background(), &requests.TaskImportRequest{Format: "ndjson"}, data, userID)
+	if err != nil || result.Created != 1 || result.Failed != 0 {
+		t.Fatalf("ImportTasks = %+v, %v, want the priority 0 row created", result, err)
+	}
+}
+
+func TestCreateTaskRejectsDuplicatedExternalID(t *testing.T) {
+	ctx := context.Background()
+	taskService := newTaskService()
+
+	externalID := "ext-1"
+	req := requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow, ExternalID: &externalID}
+
+	first := req
+	if _, err := taskService.CreateTask(ctx, &first, userID); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	second := req
+	if _, err := taskService.CreateTask(ctx, &second, userID); !errors.Is(err, exceptions.ErrDuplicatedExternalID) {
+		t.Fatalf("CreateTask = %v, want %v", err, exceptions.ErrDuplicatedExternalID)
+	}
+}
+
+func TestCreateTaskNormalisesTagsAndRendersDescription(t *testing.T) {
+	taskService := newTaskService()
+
+	task, err := taskService.CreateTask(context.Background(), &requests.TaskCreateRequest{Title: "Task", Description: "**bold**", Priority: models.TaskPriorityLow, Tags: []string{"Work", "work", "api"}}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if len(task.Tags) != 2 || task.Tags[0] != "api" || task.Tags[1] != "work" {
+		t.Errorf("Tags = %v, want [api work]", task.Tags)
+	}
+
+	if task.DescriptionHTML != "<p><strong>bold</strong></p>\n" {
+		t.Errorf("DescriptionHTML = %q, want rendered Markdown", task.DescriptionHTML)
+	}
+}
+
+func TestFindTaskByIDHidesOtherUsersTasks(t *testing.T) {
+	ctx := context.Background()
+	taskService := newTaskService()
+
+	task, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if _, err := taskService.FindTaskByID(ctx, task.ID, "someone-else"); !errors.Is(err, exceptions.ErrTaskNotFound) {
+		t.Fatalf("FindTaskByID(other user) = %v, want %v", err, exceptions.ErrTaskNotFound)
+	}
+
+	if _, err := taskService.FindTaskByID(ctx, "missing", userID); !errors.Is(err, exceptions.ErrTaskNotFound) {
+		t.Fatalf("FindTaskByID(missing) = %v, want %v", err, exceptions.ErrTaskNotFound)
+	}
+}
+
+func TestCompletingRecurringTaskCreatesNextOccurrence(t *testing.T) {
+	ctx := context.Background()
+	taskService := newTaskService()
+
+	task, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Standup", Description: "Daily", Priority: models.TaskPriorityLow, DueDate: dueDate("2030-01-01 09:00:00"), RecurrenceRule: "DAILY"}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if _, err := taskService.UpdateTaskStatusByID(ctx, task.ID, &requests.TaskUpdateStatusRequest{Status: models.TaskStatusCompleted}, userID); err != nil {
+		t.Fatalf("UpdateTaskStatusByID: %v", err)
+	}
+
+	tasks, err := taskService.FindTaskByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("FindTaskByUserID: %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("FindTaskByUserID returned %d tasks, want the task and its next occurrence", len(tasks))
+	}
+
+	next := tasks[1]
+	if next.Status != models.TaskStatusTodo || next.DueDate == nil || !next.DueDate.Equal(*dueDate("2030-01-02 09:00:00")) {
+		t.Errorf("next occurrence = %+v, want TODO due 2030-01-02 09:00:00", next)
+	}
+}
+
+func TestMaterializeMissedOccurrencesCatchesUp(t *testing.T) {
+	ctx := context.Background()
+	taskService := newTaskService()
+
+	_, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Standup", Description: "Daily", Priority: models.TaskPriorityLow, DueDate: dueDate("2030-01-01 09:00:00"), RecurrenceRule: "DAILY"}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	// Three missed days plus the next upcoming one
+	created, err := taskService.MaterializeMissedOccurrences(ctx, *dueDate("2030-01-04 12:00:00"))
+	if err != nil {
+		t.Fatalf("MaterializeMissedOccurrences: %v", err)
+	}
+
+	if created != 4 {
+		t.Errorf("MaterializeMissedOccurrences created %d occurrences, want 4", created)
+	}
+
+	// A second run has nothing left to do
+	created, err = taskService.MaterializeMissedOccurrences(ctx, *dueDate("2030-01-04 12:00:00"))
+	if err != nil || created != 0 {
+		t.Errorf("MaterializeMissedOccurrences again = %d, %v, want 0, nil", created, err)
+	}
+}
+
+func TestMaterializeMissedOccurrencesSkipsBrokenAndEndedSeries(t *testing.T) {
+	ctx := context.Background()
+	taskRepo := memory.NewTaskMemoryRepository()
+	taskService := usecases.NewTaskService(taskRepo, nil, memory.NewUserMemoryRepository(), memory.NewOutboxMemoryRepository(), memory.NewTaskEventMemoryStore(), memory.NewUnitOfWork(), events.NewDispatcher())
+
+	// A rule that no longer parses, stored before validation caught it
+	broken, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Broken", Description: "Hourly", DueDate: dueDate("2030-01-01 09:00:00"), RecurrenceRule: "FREQ=HOURLY"}, userID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Sprint", Description: "Daily", Priority: models.TaskPriorityLow, DueDate: dueDate("2030-01-01 09:00:00"), RecurrenceRule: "FREQ=DAILY;UNTIL=20300102T235959Z"}, userID); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	created, err := taskService.MaterializeMissedOccurrences(ctx, *dueDate("2030-01-04 12:00:00"))
+	if err != nil || created != 1 {
+		t.Fatalf("MaterializeMissedOccurrences = %d, %v, want the last sprint day despite the broken series", created, err)
+	}
+
+	// The sprint has ended and is no longer picked up
+	due, err := taskRepo.FindRecurringDueBefore(ctx, *dueDate("2030-01-04 12:00:00"))
+	if err != nil || len(due) != 1 || due[0].ID != broken.ID {
+		t.Errorf("FindRecurringDueBefore = %+v, %v, want only the broken series", due, err)
+	}
+}
+
+func TestFindTaskAsOfReplaysHistory(t *testing.T) {
+	ctx := context.Background()
+	taskService := newTaskService()
+
+	before := time.Now()
+	time.Sleep(time.Millisecond)
+
+	task, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Draft", Description: "Description", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+	drafted := time.Now()
+	time.Sleep(time.Millisecond)
+
+	if _, err := taskService.UpdateTaskByID(ctx, task.ID, &requests.TaskUpdateRequest{Title: "Final", Description: "Description", Priority: models.TaskPriorityHigh}, userID); err != nil {
+		t.Fatalf("UpdateTaskByID: %v", err)
+	}
+
+	old, err := taskService.FindTaskAsOf(ctx, task.ID, drafted, userID)
+	if err != nil || old.Title != "Draft" || old.Priority != models.TaskPriorityLow {
+		t.Fatalf("FindTaskAsOf(drafted) = %+v, %v, want the draft", old, err)
+	}
+
+	current, err := taskService.FindTaskAsOf(ctx, task.ID, time.Now(), userID)
+	if err != nil || current.Title != "Final" || current.Priority != models.TaskPriorityHigh {
+		t.Fatalf("FindTaskAsOf(now) = %+v, %v, want the final task", current, err)
+	}
+
+	// The task did not exist yet, and never belonged to another user
+	if _, err := taskService.FindTaskAsOf(ctx, task.ID, before, userID); !errors.Is(err, exceptions.ErrTaskNotFound) {
+		t.Errorf("FindTaskAsOf(before) = %v, want %v", err, exceptions.ErrTaskNotFound)
+	}
+
+	if _, err := taskService.FindTaskAsOf(ctx, task.ID, time.Now(), "someone-else"); !errors.Is(err, exceptions.ErrTaskNotFound) {
+		t.Errorf("FindTaskAsOf(other user) = %v, want %v", err, exceptions.ErrTaskNotFound)
+	}
+}
+
+// failingDeleteRepository refuses every bulk delete and applies the rest
+type failingDeleteRepository struct {
+	repositories.TaskRepository
+}
+
+func (f *failingDeleteRepository) ApplyBulk(ctx context.Context, operations []requests.TaskBulkOperation, atomic bool) ([]error, error) {
+	errs := make([]error, len(operations))
+	applied := []requests.TaskBulkOperation{}
+	for i, operation := range operations {
+		if operation.Action == models.TaskBulkActionDelete {
+			errs[i] = errors.New("delete failed")
+			continue
+		}
+		applied = append(applied, operation)
+	}
+
+	if _, err := f.TaskRepository.ApplyBulk(ctx, applied, atomic); err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func TestBulkUpdateTasksRecordsOnlyAppliedOperations(t *testing.T) {
+	ctx := context.Background()
+	taskRepo := &failingDeleteRepository{memory.NewTaskMemoryRepository()}
+	eventStore := memory.NewTaskEventMemoryStore()
+	taskService := usecases.NewTaskService(taskRepo, nil, memory.NewUserMemoryRepository(), memory.NewOutboxMemoryRepository(), eventStore, memory.NewUnitOfWork(), events.NewDispatcher())
+
+	task, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	response, err := taskService.BulkUpdateTasks(ctx, &requests.TaskBulkRequest{Operations: []requests.TaskBulkOperation{
+		{TaskID: task.ID, Action: models.TaskBulkActionChangePriority, Priority: models.TaskPriorityHigh},
+		{TaskID: task.ID, Action: models.TaskBulkActionDelete},
+	}}, userID)
+	if err != nil || response.Succeeded != 1 || response.Failed != 1 {
+		t.Fatalf("BulkUpdateTasks = %+v, %v, want the priority change only", response, err)
+	}
+
+	// The task survived the failed delete, its stream says so
+	stream, err := eventStore.FindByTaskID(ctx, task.ID)
+	if err != nil || len(stream) != 2 || stream[1].Type != models.TaskEventUpdated {
+		t.Fatalf("FindByTaskID = %+v, %v, want created then updated", stream, err)
+	}
+
+	if response.Results[0].Task == nil || response.Results[0].Task.Priority != models.TaskPriorityHigh {
+		t.Errorf("Results[0].Task = %+v, want the task with its new priority", response.Results[0].Task)
+	}
+}
+
+func TestPatchTaskLowersPriorityToLow(t *testing.T) {
+	ctx := context.Background()
+	taskService := newTaskService()
+
+	task, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityHigh}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  requests.TaskPatchRequest
+	}{
+		{name: "MergePatch", req: requests.TaskPatchRequest{Patch: []byte(`{"priority":0}`)}},
+		{name: "JSONPatch", req: requests.TaskPatchRequest{Patch: []byte(`[{"op":"replace","path":"/priority","value":0}]`), JSONPatch: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := taskService.UpdateTaskByID(ctx, task.ID, &requests.TaskUpdateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityHigh}, userID); err != nil {
+				t.Fatalf("UpdateTaskByID: %v", err)
+			}
+
+			patched, err := taskService.PatchTaskByID(ctx, task.ID, &tt.req, userID)
+			if err != nil || patched.Priority != models.TaskPriorityLow {
+				t.Fatalf("PatchTaskByID = %+v, %v, want priority %d", patched, err, models.TaskPriorityLow)
+			}
+		})
+	}
+
+	// Out of range is still rejected
+	if _, err := taskService.PatchTaskByID(ctx, task.ID, &requests.TaskPatchRequest{Patch: []byte(`{"priority":3}`)}, userID); err == nil {
+		t.Errorf("PatchTaskByID(priority 3) = nil, want an error")
+	}
+}
+
+func TestExportTasksStopsOnceCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	taskService := newTaskService()
+
+	// More than a page, so the export reads the tasks twice
+	for i := range 501 {
+		if _, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: fmt.Sprintf("Task %d", i), Description: "Description"}, userID); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := taskService.ExportTasks(ctx, "ndjson", &out, userID); err != nil || strings.Count(out.String(), "\n") != 501 {
+		t.Fatalf("ExportTasks = %d lines, %v, want 501", strings.Count(out.String(), "\n"), err)
+	}
+
+	// The client going away part way through stops the next page being read
+	w := writerFunc(func(p []byte) (int, error) {
+		cancel()
+		return len(p), nil
+	})
+	if err := taskService.ExportTasks(ctx, "ndjson", w, userID); !errors.Is(err, context.Canceled) {
+		t.Errorf("ExportTasks = %v, want %v", err, context.Canceled)
+	}
+
+	// Write errors end the export
+	failed := writerFunc(func(p []byte) (int, error) {
+		return 0, io.ErrClosedPipe
+	})
+	if err := taskService.ExportTasks(context.Background(), "json", failed, userID); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("ExportTasks = %v, want %v", err, io.ErrClosedPipe)
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
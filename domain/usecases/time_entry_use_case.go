@@ -0,0 +1,202 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/responses"
+)
+
+type TimeEntryUseCase interface {
+	CreateTimeEntry(ctx context.Context, taskID string, req *requests.TimeEntryCreateRequest, userID string) (*models.TimeEntry, error)
+	FindTimeEntriesByTaskID(ctx context.Context, taskID string, userID string) ([]models.TimeEntry, error)
+	DeleteTimeEntryByID(ctx context.Context, taskID string, entryID string, userID string) (*models.TimeEntry, error)
+	StartTimer(ctx context.Context, taskID string, req *requests.TimerStartRequest, userID string) (*models.TimeEntry, error)
+	StopTimer(ctx context.Context, taskID string, userID string) (*models.TimeEntry, error)
+	Timesheet(ctx context.Context, req *requests.TimesheetRequest, userID string) (*responses.TimesheetResponse, error)
+}
+
+type timeEntryService struct {
+	timeEntryRepo repositories.TimeEntryRepository
+	taskRepo      repositories.TaskRepository
+	userRepo      repositories.UserRepository
+}
+
+func NewTimeEntryService(timeEntryRepo repositories.TimeEntryRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository) TimeEntryUseCase {
+	return &timeEntryService{
+		timeEntryRepo: timeEntryRepo,
+		taskRepo:      taskRepo,
+		userRepo:      userRepo,
+	}
+}
+
+func (t *timeEntryService) CreateTimeEntry(ctx context.Context, taskID string, req *requests.TimeEntryCreateRequest, userID string) (*models.TimeEntry, error) {
+	// Check time range
+	if !req.EndedAt.After(*req.StartedAt) {
+		return nil, exceptions.ErrInvalidTimeRange
+	}
+
+	// Find the task
+	if _, err := t.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Create time entry
+	entryID, err := t.timeEntryRepo.Create(ctx, req, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the time entry
+	return t.timeEntryRepo.FindByID(ctx, entryID)
+}
+
+func (t *timeEntryService) FindTimeEntriesByTaskID(ctx context.Context, taskID string, userID string) ([]models.TimeEntry, error) {
+	// Find the task
+	if _, err := t.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return t.timeEntryRepo.FindByTaskID(ctx, taskID)
+}
+
+func (t *timeEntryService) DeleteTimeEntryByID(ctx context.Context, taskID string, entryID string, userID string) (*models.TimeEntry, error) {
+	// Find the task
+	if _, err := t.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Find the time entry
+	entry, err := t.timeEntryRepo.FindByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check time entry is exist and belong to the task
+	if entry == nil || entry.TaskID != taskID {
+		return nil, exceptions.ErrTimeEntryNotFound
+	}
+
+	// Delete time entry in database
+	if err := t.timeEntryRepo.DeleteByID(ctx, entryID); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (t *timeEntryService) StartTimer(ctx context.Context, taskID string, req *requests.TimerStartRequest, userID string) (*models.TimeEntry, error) {
+	// Find the task
+	if _, err := t.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Only one timer runs per user
+	running, err := t.timeEntryRepo.FindRunningByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if running != nil {
+		return nil, exceptions.ErrTimerAlreadyRunning
+	}
+
+	// Start timer
+	entryID, err := t.timeEntryRepo.Start(ctx, taskID, userID, req.Note, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the time entry
+	return t.timeEntryRepo.FindByID(ctx, entryID)
+}
+
+func (t *timeEntryService) StopTimer(ctx context.Context, taskID string, userID string) (*models.TimeEntry, error) {
+	// Find the task
+	if _, err := t.findTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Check the running timer is on this task
+	running, err := t.timeEntryRepo.FindRunningByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if running == nil || running.TaskID != taskID {
+		return nil, exceptions.ErrTimerNotRunning
+	}
+
+	// Stop timer
+	if err := t.timeEntryRepo.Stop(ctx, running.ID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Find the time entry
+	return t.timeEntryRepo.FindByID(ctx, running.ID)
+}
+
+func (t *timeEntryService) Timesheet(ctx context.Context, req *requests.TimesheetRequest, userID string) (*responses.TimesheetResponse, error) {
+	// Days start at midnight in the user's time zone
+	location, err := userLocation(ctx, t.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := time.ParseInLocation(time.DateOnly, req.From, location)
+	if err != nil {
+		return nil, exceptions.ErrInvalidTimeRange
+	}
+
+	to, err := time.ParseInLocation(time.DateOnly, req.To, location)
+	if err != nil {
+		return nil, exceptions.ErrInvalidTimeRange
+	}
+
+	// Check time range, both days are included
+	if to.Before(from) {
+		return nil, exceptions.ErrInvalidTimeRange
+	}
+
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = models.TimesheetGroupByDay
+	}
+
+	// Aggregate time entries
+	rows, err := t.timeEntryRepo.Timesheet(ctx, userID, from, to.AddDate(0, 0, 1), groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.TimesheetResponse{
+		From:    req.From,
+		To:      req.To,
+		GroupBy: groupBy,
+		Rows:    rows,
+	}
+
+	for _, row := range rows {
+		response.TotalSeconds += row.Seconds
+	}
+
+	return response, nil
+}
+
+func (t *timeEntryService) findTask(ctx context.Context, taskID string, userID string) (*models.Task, error) {
+	task, err := t.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check task is exist and belong to the user
+	if task == nil || task.UserID != userID {
+		return nil, exceptions.ErrTaskNotFound
+	}
+
+	return task, nil
+}
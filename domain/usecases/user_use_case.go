@@ -2,6 +2,8 @@ package usecases
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
 	"github.com/GraphZC/sdd-task-management/configs"
@@ -16,6 +18,10 @@ import (
 type UserUseCase interface {
 	Register(ctx context.Context, req *requests.UserRegisterRequest) error
 	Login(ctx context.Context, req *requests.UserLoginRequest) (*responses.UserLoginResponse, error)
+	FindSettings(ctx context.Context, userID string) (*responses.UserSettingsResponse, error)
+	UpdateSettings(ctx context.Context, req *requests.UserSettingsUpdateRequest, userID string) (*responses.UserSettingsResponse, error)
+	RegenerateCalendarToken(ctx context.Context, userID string) (*responses.UserSettingsResponse, error)
+	RevokeCalendarToken(ctx context.Context, userID string) (*responses.UserSettingsResponse, error)
 }
 
 type userService struct {
@@ -94,8 +100,72 @@ func (u *userService) Login(ctx context.Context, req *requests.UserLoginRequest)
 		ID:        user.ID,
 		Name:      user.Name,
 		Email:     user.Email,
+		Timezone:  user.Timezone,
 		Token:     tokenString,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}, nil
 }
+
+func (u *userService) FindSettings(ctx context.Context, userID string) (*responses.UserSettingsResponse, error) {
+	// Find the user
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, exceptions.ErrUserNotFound
+	}
+
+	return &responses.UserSettingsResponse{
+		Timezone:      user.Timezone,
+		CalendarToken: user.CalendarToken,
+	}, nil
+}
+
+func (u *userService) UpdateSettings(ctx context.Context, req *requests.UserSettingsUpdateRequest, userID string) (*responses.UserSettingsResponse, error) {
+	// Due dates and reports are bounded by days in this time zone
+	if err := u.userRepo.UpdateTimezone(ctx, userID, req.Timezone); err != nil {
+		return nil, err
+	}
+
+	return u.FindSettings(ctx, userID)
+}
+
+func (u *userService) RegenerateCalendarToken(ctx context.Context, userID string) (*responses.UserSettingsResponse, error) {
+	// Generate token, the old calendar URL stops working
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	calendarToken := hex.EncodeToString(token)
+	if err := u.userRepo.UpdateCalendarToken(ctx, userID, &calendarToken); err != nil {
+		return nil, err
+	}
+
+	return u.FindSettings(ctx, userID)
+}
+
+func (u *userService) RevokeCalendarToken(ctx context.Context, userID string) (*responses.UserSettingsResponse, error) {
+	if err := u.userRepo.UpdateCalendarToken(ctx, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return u.FindSettings(ctx, userID)
+}
+
+// userLocation loads the time zone the user's days start in, UTC until they pick one
+func userLocation(ctx context.Context, userRepo repositories.UserRepository, userID string) (*time.Location, error) {
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil || user.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(user.Timezone)
+}
@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+type ViewUseCase interface {
+	CreateView(ctx context.Context, req *requests.ViewCreateRequest, userID string) (*models.View, error)
+	FindViewByID(ctx context.Context, viewID string, userID string) (*models.View, error)
+	FindViewsByUserID(ctx context.Context, userID string) ([]models.View, error)
+	UpdateViewByID(ctx context.Context, viewID string, req *requests.ViewUpdateRequest, userID string) (*models.View, error)
+	DeleteViewByID(ctx context.Context, viewID string, userID string) (*models.View, error)
+	FindViewTasks(ctx context.Context, viewID string, userID string) ([]models.Task, error)
+}
+
+type viewService struct {
+	viewRepo repositories.ViewRepository
+	taskRepo repositories.TaskRepository
+	userRepo repositories.UserRepository
+}
+
+func NewViewService(viewRepo repositories.ViewRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository) ViewUseCase {
+	return &viewService{
+		viewRepo: viewRepo,
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (v *viewService) CreateView(ctx context.Context, req *requests.ViewCreateRequest, userID string) (*models.View, error) {
+	// Check query and sort
+	if _, err := parseView(req.Query, req.Sort); err != nil {
+		return nil, err
+	}
+
+	// Check name is unique for the user
+	if err := v.checkName(ctx, req.Name, "", userID); err != nil {
+		return nil, err
+	}
+
+	// Create view
+	viewID, err := v.viewRepo.Create(ctx, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the view
+	return v.viewRepo.FindByID(ctx, viewID)
+}
+
+func (v *viewService) FindViewByID(ctx context.Context, viewID string, userID string) (*models.View, error) {
+	view, err := v.viewRepo.FindByID(ctx, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check view is exist and belong to the user
+	if view == nil || view.UserID != userID {
+		return nil, exceptions.ErrViewNotFound
+	}
+
+	return view, nil
+}
+
+func (v *viewService) FindViewsByUserID(ctx context.Context, userID string) ([]models.View, error) {
+	return v.viewRepo.FindByUserID(ctx, userID)
+}
+
+func (v *viewService) UpdateViewByID(ctx context.Context, viewID string, req *requests.ViewUpdateRequest, userID string) (*models.View, error) {
+	// Check query and sort
+	if _, err := parseView(req.Query, req.Sort); err != nil {
+		return nil, err
+	}
+
+	// Find the view
+	if _, err := v.FindViewByID(ctx, viewID, userID); err != nil {
+		return nil, err
+	}
+
+	// Check name is unique for the user
+	if err := v.checkName(ctx, req.Name, viewID, userID); err != nil {
+		return nil, err
+	}
+
+	// Update view in database
+	if err := v.viewRepo.UpdateByID(ctx, viewID, req); err != nil {
+		return nil, err
+	}
+
+	// Find the updated view
+	return v.viewRepo.FindByID(ctx, viewID)
+}
+
+func (v *viewService) DeleteViewByID(ctx context.Context, viewID string, userID string) (*models.View, error) {
+	// Find the view
+	view, err := v.FindViewByID(ctx, viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete view in database
+	if err := v.viewRepo.DeleteByID(ctx, viewID); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (v *viewService) FindViewTasks(ctx context.Context, viewID string, userID string) ([]models.Task, error) {
+	// Find the view
+	view, err := v.FindViewByID(ctx, viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := parseView(view.Query, view.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dates in the filter are days in the user's time zone
+	location, err := userLocation(ctx, v.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.taskRepo.FindByQuery(ctx, userID, q, time.Now().In(location))
+}
+
+func (v *viewService) checkName(ctx context.Context, name string, viewID string, userID string) error {
+	views, err := v.viewRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, view := range views {
+		if view.Name == name && view.ID != viewID {
+			return exceptions.ErrDuplicatedViewName
+		}
+	}
+
+	return nil
+}
+
+func parseView(input string, sort string) (*query.Query, error) {
+	q, err := query.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	q.Sort, err = query.ParseSort(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
@@ -0,0 +1,264 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/responses"
+)
+
+const (
+	webhookBatchSize        = 100
+	webhookDeliveryLogLimit = 50
+	webhookLease            = 2 * time.Minute
+	webhookMaxAttempts      = 8
+	webhookBaseBackoff      = 30 * time.Second
+)
+
+type WebhookUseCase interface {
+	CreateWebhook(ctx context.Context, req *requests.WebhookCreateRequest, userID string) (*responses.WebhookCreateResponse, error)
+	FindWebhooks(ctx context.Context, userID string) ([]models.Webhook, error)
+	DeleteWebhookByID(ctx context.Context, webhookID string, userID string) (*models.Webhook, error)
+	FindDeliveries(ctx context.Context, webhookID string, userID string) ([]models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID string, deliveryID string, userID string) (*models.WebhookDelivery, error)
+	HandleTaskEvent(ctx context.Context, event *models.TaskEvent)
+	DeliverPending(ctx context.Context, now time.Time) (int, error)
+	Wakeup() <-chan struct{}
+}
+
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	sender      notifiers.WebhookSender
+	wakeup      chan struct{}
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, sender notifiers.WebhookSender) WebhookUseCase {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		wakeup:      make(chan struct{}, 1),
+	}
+}
+
+func (w *webhookService) CreateWebhook(ctx context.Context, req *requests.WebhookCreateRequest, userID string) (*responses.WebhookCreateResponse, error) {
+	// Check the url is a public http or https address
+	if err := notifiers.ValidateDestination(req.URL); err != nil {
+		return nil, err
+	}
+
+	// Generate signing secret
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	// Create webhook
+	webhookID, err := w.webhookRepo.Create(ctx, req, userID, hex.EncodeToString(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the webhook
+	webhook, err := w.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil {
+		return nil, exceptions.ErrWebhookNotFound
+	}
+
+	return &responses.WebhookCreateResponse{
+		Webhook: *webhook,
+		Secret:  webhook.Secret,
+	}, nil
+}
+
+func (w *webhookService) FindWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	return w.webhookRepo.FindByUserID(ctx, userID)
+}
+
+func (w *webhookService) DeleteWebhookByID(ctx context.Context, webhookID string, userID string) (*models.Webhook, error) {
+	// Find the webhook
+	webhook, err := w.findWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete webhook in database
+	if err := w.webhookRepo.DeleteByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (w *webhookService) FindDeliveries(ctx context.Context, webhookID string, userID string) ([]models.WebhookDelivery, error) {
+	// Find the webhook
+	if _, err := w.findWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	return w.webhookRepo.FindDeliveriesByWebhookID(ctx, webhookID, webhookDeliveryLogLimit)
+}
+
+func (w *webhookService) Redeliver(ctx context.Context, webhookID string, deliveryID string, userID string) (*models.WebhookDelivery, error) {
+	// Find the webhook
+	if _, err := w.findWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	// Find the delivery
+	delivery, err := w.webhookRepo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check delivery is exist and belong to the webhook
+	if delivery == nil || delivery.WebhookID != webhookID {
+		return nil, exceptions.ErrWebhookDeliveryNotFound
+	}
+
+	// Queue a new delivery with the same payload
+	redeliveryID, err := w.webhookRepo.CreateDelivery(ctx, webhookID, delivery.EventType, delivery.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	w.signal()
+
+	return w.webhookRepo.FindDeliveryByID(ctx, redeliveryID)
+}
+
+func (w *webhookService) HandleTaskEvent(ctx context.Context, event *models.TaskEvent) {
+	// Find webhooks subscribed to the event
+	webhooks, err := w.webhookRepo.FindActiveByUserIDAndEvent(ctx, event.UserID, event.Type)
+	if err != nil {
+		log.Println("❌ Error finding webhooks", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Println("❌ Error encoding webhook payload", err)
+		return
+	}
+
+	// Persist the deliveries, the worker sends them in the background
+	for _, webhook := range webhooks {
+		if _, err := w.webhookRepo.CreateDelivery(ctx, webhook.ID, event.Type, string(payload)); err != nil {
+			log.Println("❌ Error queueing webhook delivery", err)
+		}
+	}
+
+	w.signal()
+}
+
+func (w *webhookService) DeliverPending(ctx context.Context, now time.Time) (int, error) {
+	// Find deliveries which are due
+	deliveries, err := w.webhookRepo.FindDueDeliveries(ctx, now, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		// Claim the delivery so other instances skip it
+		claimed, err := w.webhookRepo.ClaimDelivery(ctx, delivery.ID, now, now.Add(webhookLease))
+		if err != nil {
+			return delivered, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		ok, err := w.deliver(ctx, delivery, now)
+		if err != nil {
+			return delivered, err
+		}
+
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+func (w *webhookService) Wakeup() <-chan struct{} {
+	return w.wakeup
+}
+
+func (w *webhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) (bool, error) {
+	// Find the webhook, it may have been removed since
+	webhook, err := w.webhookRepo.FindByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return false, err
+	}
+
+	if webhook == nil {
+		return false, w.webhookRepo.MarkDeliveryFailed(ctx, delivery.ID, nil, exceptions.ErrWebhookNotFound.Error())
+	}
+
+	// Send the payload
+	status, sendErr := w.sender.Send(ctx, webhook.URL, webhook.Secret, delivery.EventType, delivery.ID, []byte(delivery.Payload))
+	if sendErr == nil && status >= 200 && status < 300 {
+		return true, w.webhookRepo.MarkDeliverySucceeded(ctx, delivery.ID, status, now)
+	}
+
+	var responseStatus *int
+	if sendErr == nil {
+		responseStatus = &status
+		sendErr = fmt.Errorf("endpoint responded with status %d", status)
+	}
+
+	// Give up once the attempts are used up, the claim already counted this one
+	attempts := delivery.Attempts + 1
+	if attempts >= webhookMaxAttempts {
+		return false, w.webhookRepo.MarkDeliveryFailed(ctx, delivery.ID, responseStatus, sendErr.Error())
+	}
+
+	// Back off exponentially between attempts
+	backoff := webhookBaseBackoff << (attempts - 1)
+
+	return false, w.webhookRepo.RescheduleDelivery(ctx, delivery.ID, responseStatus, sendErr.Error(), now.Add(backoff))
+}
+
+func (w *webhookService) signal() {
+	// Never block the caller, a pending signal is enough to wake the worker
+	select {
+	case w.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (w *webhookService) findWebhook(ctx context.Context, webhookID string, userID string) (*models.Webhook, error) {
+	webhook, err := w.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check webhook is exist and belong to the user
+	if webhook == nil || webhook.UserID != userID {
+		return nil, exceptions.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,78 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/internal/adapters/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func testCache(t *testing.T, c cache.Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+
+	if _, found, err := c.Get(ctx, "missing"); found || err != nil {
+		t.Fatalf("Get(missing) = %v, %v, want a miss", found, err)
+	}
+
+	if err := c.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	value, found, err := c.Get(ctx, "key")
+	if err != nil || !found || string(value) != "value" {
+		t.Fatalf("Get(key) = %q, %v, %v, want value", value, found, err)
+	}
+
+	if err := c.Delete(ctx, "key", "missing"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, found, _ := c.Get(ctx, "key"); found {
+		t.Fatal("Get after Delete found the key")
+	}
+
+	if err := c.Set(ctx, "expiring", []byte("value"), time.Second); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	advance(2 * time.Second)
+
+	if _, found, _ := c.Get(ctx, "expiring"); found {
+		t.Fatal("Get after the TTL found the key")
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	testCache(t, cache.NewLRUCache(10), func(d time.Duration) { time.Sleep(d) })
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRUCache(2)
+
+	c.Set(ctx, "a", []byte("a"), time.Minute)
+	c.Set(ctx, "b", []byte("b"), time.Minute)
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", []byte("c"), time.Minute)
+
+	if _, found, _ := c.Get(ctx, "b"); found {
+		t.Error("b was kept, want it evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, found, _ := c.Get(ctx, key); !found {
+			t.Errorf("%s was evicted, want it kept", key)
+		}
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	testCache(t, cache.NewRedisCache(client, "test:"), server.FastForward)
+}
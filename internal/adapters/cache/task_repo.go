@@ -0,0 +1,275 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+// TaskCachedRepository caches single tasks and each user's task list in front
+// of another TaskRepository. Writes through it drop the entries they touch once
+// their unit of work commits, reads inside a unit of work skip the cache. Misses
+// are filled from the primary, a lagging replica would put back the row the
+// write just dropped. Writes made elsewhere, such as a deleted project clearing
+// its tasks, show up once the TTL runs out.
+type TaskCachedRepository struct {
+	taskRepo repositories.TaskRepository
+	cache    Cache
+	ttl      time.Duration
+}
+
+func NewTaskCachedRepository(taskRepo repositories.TaskRepository, cache Cache, ttl time.Duration) repositories.TaskRepository {
+	return &TaskCachedRepository{
+		taskRepo: taskRepo,
+		cache:    cache,
+		ttl:      ttl,
+	}
+}
+
+func (t *TaskCachedRepository) Create(ctx context.Context, req *requests.TaskCreateRequest, userID string) (*models.Task, error) {
+	task, err := t.taskRepo.Create(ctx, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.invalidate(ctx, userID)
+
+	return task, nil
+}
+
+func (t *TaskCachedRepository) CreateOccurrence(ctx context.Context, task *models.Task, dueDate time.Time) (string, error) {
+	occurrenceID, err := t.taskRepo.CreateOccurrence(ctx, task, dueDate)
+	if err != nil {
+		return "", err
+	}
+
+	t.invalidate(ctx, task.UserID)
+
+	return occurrenceID, nil
+}
+
+func (t *TaskCachedRepository) Save(ctx context.Context, task *models.Task) error {
+	// The row may have belonged to another user before
+	userID := t.owner(ctx, task.ID)
+	if err := t.taskRepo.Save(ctx, task); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, userID, task.ID)
+	t.invalidate(ctx, task.UserID)
+
+	return nil
+}
+
+func (t *TaskCachedRepository) FindByID(ctx context.Context, taskID string) (*models.Task, error) {
+	var task *models.Task
+	if t.get(ctx, taskKey(taskID), &task) {
+		return task, nil
+	}
+
+	task, err := t.taskRepo.FindByID(database.OnPrimary(ctx), taskID)
+	if err != nil || task == nil {
+		return task, err
+	}
+
+	t.set(ctx, taskKey(taskID), task)
+
+	return task, nil
+}
+
+func (t *TaskCachedRepository) FindByIDs(ctx context.Context, taskIDs []string) ([]models.Task, error) {
+	return t.taskRepo.FindByIDs(ctx, taskIDs)
+}
+
+func (t *TaskCachedRepository) FindByUserID(ctx context.Context, userID string) ([]models.Task, error) {
+	var tasks []models.Task
+	if t.get(ctx, userTasksKey(userID), &tasks) {
+		return tasks, nil
+	}
+
+	tasks, err := t.taskRepo.FindByUserID(database.OnPrimary(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.set(ctx, userTasksKey(userID), tasks)
+
+	return tasks, nil
+}
+
+func (t *TaskCachedRepository) FindByUserIDAfter(ctx context.Context, userID string, afterID string, limit int) ([]models.Task, error) {
+	return t.taskRepo.FindByUserIDAfter(ctx, userID, afterID, limit)
+}
+
+func (t *TaskCachedRepository) FindByExternalID(ctx context.Context, userID string, externalID string) (*models.Task, error) {
+	return t.taskRepo.FindByExternalID(ctx, userID, externalID)
+}
+
+func (t *TaskCachedRepository) FindByQuery(ctx context.Context, userID string, q *query.Query, now time.Time) ([]models.Task, error) {
+	return t.taskRepo.FindByQuery(ctx, userID, q, now)
+}
+
+func (t *TaskCachedRepository) FindLatestBySeriesID(ctx context.Context, seriesID string) (*models.Task, error) {
+	return t.taskRepo.FindLatestBySeriesID(ctx, seriesID)
+}
+
+func (t *TaskCachedRepository) FindRecurringDueBefore(ctx context.Context, before time.Time) ([]models.Task, error) {
+	return t.taskRepo.FindRecurringDueBefore(ctx, before)
+}
+
+func (t *TaskCachedRepository) DeleteByID(ctx context.Context, taskID string) error {
+	userID := t.owner(ctx, taskID)
+	if err := t.taskRepo.DeleteByID(ctx, taskID); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, userID, taskID)
+
+	return nil
+}
+
+func (t *TaskCachedRepository) UpdateByUD(ctx context.Context, taskID string, req *requests.TaskUpdateRequest) error {
+	userID := t.owner(ctx, taskID)
+	if err := t.taskRepo.UpdateByUD(ctx, taskID, req); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, userID, taskID)
+
+	return nil
+}
+
+func (t *TaskCachedRepository) PatchByID(ctx context.Context, taskID string, req *requests.TaskUpdateRequest, fields []string) error {
+	userID := t.owner(ctx, taskID)
+	if err := t.taskRepo.PatchByID(ctx, taskID, req, fields); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, userID, taskID)
+
+	return nil
+}
+
+func (t *TaskCachedRepository) UpdateStatusByID(ctx context.Context, taskID string, status string) error {
+	userID := t.owner(ctx, taskID)
+	if err := t.taskRepo.UpdateStatusByID(ctx, taskID, status); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, userID, taskID)
+
+	return nil
+}
+
+func (t *TaskCachedRepository) EndRecurrenceByID(ctx context.Context, taskID string) error {
+	userID := t.owner(ctx, taskID)
+	if err := t.taskRepo.EndRecurrenceByID(ctx, taskID); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, userID, taskID)
+
+	return nil
+}
+
+func (t *TaskCachedRepository) AssignByID(ctx context.Context, taskID string, assigneeID *string) error {
+	userID := t.owner(ctx, taskID)
+	if err := t.taskRepo.AssignByID(ctx, taskID, assigneeID); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, userID, taskID)
+
+	return nil
+}
+
+func (t *TaskCachedRepository) ApplyBulk(ctx context.Context, operations []requests.TaskBulkOperation, atomic bool) ([]error, error) {
+	// Operations may span users, so each task drops its owner's list too
+	owners := map[string]string{}
+	for _, operation := range operations {
+		owners[operation.TaskID] = t.owner(ctx, operation.TaskID)
+	}
+
+	// Some operations may have been applied even when others failed
+	results, err := t.taskRepo.ApplyBulk(ctx, operations, atomic)
+	for taskID, userID := range owners {
+		t.invalidate(ctx, userID, taskID)
+	}
+
+	return results, err
+}
+
+// owner finds who a task belongs to before it changes, tasks never change owner
+// so a cached copy is good enough
+func (t *TaskCachedRepository) owner(ctx context.Context, taskID string) string {
+	task, err := t.FindByID(ctx, taskID)
+	if err != nil || task == nil {
+		return ""
+	}
+
+	return task.UserID
+}
+
+// invalidate drops the user's list and the given tasks once the write commits
+func (t *TaskCachedRepository) invalidate(ctx context.Context, userID string, taskIDs ...string) {
+	keys := []string{}
+	if userID != "" {
+		keys = append(keys, userTasksKey(userID))
+	}
+
+	for _, taskID := range taskIDs {
+		keys = append(keys, taskKey(taskID))
+	}
+
+	database.AfterCommit(ctx, func() {
+		if err := t.cache.Delete(ctx, keys...); err != nil {
+			log.Println("❌ Error invalidating cached tasks", err)
+		}
+	})
+}
+
+// get reports a hit, a cache that fails is treated as a miss
+func (t *TaskCachedRepository) get(ctx context.Context, key string, value any) bool {
+	// A unit of work reads its own uncommitted writes, which must not be cached
+	if database.InTransaction(ctx) {
+		return false
+	}
+
+	data, ok, err := t.cache.Get(ctx, key)
+	if err != nil {
+		log.Println("❌ Error reading cached tasks", err)
+		return false
+	}
+
+	return ok && json.Unmarshal(data, value) == nil
+}
+
+func (t *TaskCachedRepository) set(ctx context.Context, key string, value any) {
+	if database.InTransaction(ctx) {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	if err := t.cache.Set(ctx, key, data, t.ttl); err != nil {
+		log.Println("❌ Error caching tasks", err)
+	}
+}
+
+func taskKey(taskID string) string {
+	return "task:" + taskID
+}
+
+func userTasksKey(userID string) string {
+	return "tasks:user:" + userID
+}
@@ -0,0 +1,131 @@
+package cache_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/repositories/repositorytest"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/cache"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/memory"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqlite"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) (repositories.UserRepository, repositories.TaskRepository) {
+		return memory.NewUserMemoryRepository(), cache.NewTaskCachedRepository(memory.NewTaskMemoryRepository(), cache.NewLRUCache(100), time.Minute)
+	})
+}
+
+func TestWritesInvalidateCachedReads(t *testing.T) {
+	ctx := context.Background()
+	taskRepo := cache.NewTaskCachedRepository(memory.NewTaskMemoryRepository(), cache.NewLRUCache(100), time.Minute)
+
+	const userID = "0190a6f0-0000-7000-8000-000000000001"
+	task, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Fill the cache before the write
+	if _, err := taskRepo.FindByID(ctx, task.ID); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if _, err := taskRepo.FindByUserID(ctx, userID); err != nil {
+		t.Fatalf("FindByUserID: %v", err)
+	}
+
+	if err := taskRepo.UpdateStatusByID(ctx, task.ID, models.TaskStatusCompleted); err != nil {
+		t.Fatalf("UpdateStatusByID: %v", err)
+	}
+
+	found, err := taskRepo.FindByID(ctx, task.ID)
+	if err != nil || found.Status != models.TaskStatusCompleted {
+		t.Errorf("FindByID = %+v, %v, want the completed task", found, err)
+	}
+
+	tasks, err := taskRepo.FindByUserID(ctx, userID)
+	if err != nil || len(tasks) != 1 || tasks[0].Status != models.TaskStatusCompleted {
+		t.Errorf("FindByUserID = %+v, %v, want the completed task", tasks, err)
+	}
+}
+
+func openMigrated(t *testing.T, path string) *sqlx.DB {
+	ctx := context.Background()
+
+	db, err := sqlx.ConnectContext(ctx, "sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := sqlite.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestMissesAreFilledFromThePrimary(t *testing.T) {
+	ctx := context.Background()
+	primaryPath := filepath.Join(t.TempDir(), "primary.db")
+	primary := openMigrated(t, primaryPath)
+	replica := openMigrated(t, filepath.Join(t.TempDir(), "replica.db"))
+	pool := database.New(primary, replica)
+
+	userRepo := sqlite.NewUserSQLiteRepository(pool)
+	if err := userRepo.Create(ctx, &requests.UserRegisterRequest{Name: "Test User", Email: "cache@example.com", Password: "hashed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := userRepo.FindByEmail(database.OnPrimary(ctx), "cache@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	taskRepo := cache.NewTaskCachedRepository(sqlite.NewTaskSQLiteRepository(pool), cache.NewLRUCache(100), time.Minute)
+	task, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow}, user.ID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// The replica has the task but not the write that follows
+	conn, err := replica.Connx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, statement := range []string{"ATTACH DATABASE '" + primaryPath + "' AS source", "INSERT INTO users SELECT * FROM source.users", "INSERT INTO tasks SELECT * FROM source.tasks", "DETACH DATABASE source"} {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := taskRepo.UpdateStatusByID(ctx, task.ID, models.TaskStatusCompleted); err != nil {
+		t.Fatalf("UpdateStatusByID: %v", err)
+	}
+
+	// A request that has not written would read the stale replica
+	found, err := taskRepo.FindByID(database.WithSession(ctx), task.ID)
+	if err != nil || found == nil || found.Status != models.TaskStatusCompleted {
+		t.Fatalf("FindByID = %+v, %v, want the completed task", found, err)
+	}
+
+	tasks, err := taskRepo.FindByUserID(database.WithSession(ctx), user.ID)
+	if err != nil || len(tasks) != 1 || tasks[0].Status != models.TaskStatusCompleted {
+		t.Errorf("FindByUserID = %+v, %v, want the completed task", tasks, err)
+	}
+}
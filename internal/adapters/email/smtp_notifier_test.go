@@ -0,0 +1,150 @@
+package email_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/textproto"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/email"
+)
+
+// smtpSession is what the fake server received over one connection
+type smtpSession struct {
+	commands []string
+	data     string
+}
+
+// serveSMTP accepts a single connection and answers just enough of RFC 5321
+// for net/smtp to deliver one message, offering PLAIN auth but no STARTTLS.
+// Recipients get rcptReply.
+func serveSMTP(t *testing.T, rcptReply string) (string, string, <-chan smtpSession) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		session := smtpSession{}
+		defer func() { sessions <- session }()
+
+		text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			session.commands = append(session.commands, line)
+
+			switch verb, _, _ := strings.Cut(line, " "); strings.ToUpper(verb) {
+			case "EHLO":
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 AUTH PLAIN")
+			case "AUTH":
+				text.PrintfLine("235 Authenticated")
+			case "MAIL":
+				text.PrintfLine("250 OK")
+			case "RCPT":
+				text.PrintfLine("%s", rcptReply)
+			case "DATA":
+				text.PrintfLine("354 Go ahead")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				session.data = string(data)
+				text.PrintfLine("250 Queued")
+			case "QUIT":
+				text.PrintfLine("221 Bye")
+				return
+			default:
+				text.PrintfLine("502 Not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	return host, port, sessions
+}
+
+func TestSMTPNotifierSendsMessage(t *testing.T) {
+	host, port, sessions := serveSMTP(t, "250 OK")
+	notifier := email.NewSMTPNotifier(host, port, "user", "secret", "tasks@example.com")
+
+	err := notifier.Notify(context.Background(), &models.NotificationMessage{
+		Recipient: "owner@example.com",
+		Subject:   "Reminder: Ship it\r\nBcc: attacker@example.com",
+		Body:      "Due soon\n.hidden line",
+	})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	session := <-sessions
+
+	// The envelope, with the credentials sent as PLAIN
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00user\x00secret"))
+	want := []string{"AUTH PLAIN " + credentials, "MAIL FROM:<tasks@example.com>", "RCPT TO:<owner@example.com>", "DATA", "QUIT"}
+	if len(session.commands) < len(want) || !strings.HasPrefix(session.commands[0], "EHLO ") {
+		t.Fatalf("commands = %q, want EHLO followed by %q", session.commands, want)
+	}
+
+	for i, command := range want {
+		if got := session.commands[i+1]; !strings.HasPrefix(got, command) {
+			t.Errorf("command %d = %q, want %q", i+1, got, command)
+		}
+	}
+
+	headers, body, ok := strings.Cut(session.data, "\n\n")
+	if !ok {
+		t.Fatalf("message = %q, want headers and a body", session.data)
+	}
+
+	for _, header := range []string{"From: tasks@example.com", "To: owner@example.com", "Subject: Reminder: Ship it  Bcc: attacker@example.com", "MIME-Version: 1.0", "Content-Type: text/plain; charset=UTF-8"} {
+		if !strings.Contains(headers+"\n", header+"\n") {
+			t.Errorf("headers = %q, want %q", headers, header)
+		}
+	}
+
+	// Titles cannot smuggle in extra headers
+	if strings.Contains(headers, "\nBcc:") {
+		t.Errorf("headers = %q, want no Bcc header", headers)
+	}
+
+	if !strings.Contains(headers, "\nDate: ") {
+		t.Errorf("headers = %q, want a Date header", headers)
+	}
+
+	if body != "Due soon\n.hidden line\n" {
+		t.Errorf("body = %q, want the lines of the message", body)
+	}
+}
+
+func TestSMTPNotifierReportsRejectedRecipient(t *testing.T) {
+	host, port, sessions := serveSMTP(t, "550 No such user")
+	err := email.NewSMTPNotifier(host, port, "", "", "tasks@example.com").Notify(context.Background(), &models.NotificationMessage{Recipient: "nobody@example.com", Subject: "Subject", Body: "Body"})
+
+	var protocolErr *textproto.Error
+	if !errors.As(err, &protocolErr) || protocolErr.Code != 550 {
+		t.Errorf("Notify = %v, want the 550 from the server", err)
+	}
+
+	// Nothing is sent, and no credentials are offered when none are configured
+	if session := <-sessions; session.data != "" || slices.ContainsFunc(session.commands, func(command string) bool { return strings.HasPrefix(command, "AUTH") }) {
+		t.Errorf("session = %+v, want no AUTH and no message", session)
+	}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/GraphZC/sdd-task-management/domain/importers"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/transfer"
+)
+
+// Label words that raise or lower the priority of an issue
+var (
+	highLabels = []string{"critical", "urgent", "high", "p0", "p1"}
+	lowLabels  = []string{"low", "p3", "p4", "minor"}
+)
+
+// Issues as returned by the REST API or `gh issue list --json`
+type issue struct {
+	Number        int             `json:"number"`
+	Title         string          `json:"title"`
+	Body          string          `json:"body"`
+	State         string          `json:"state"`
+	Labels        []label         `json:"labels"`
+	Milestone     *milestone      `json:"milestone"`
+	Assignees     []any           `json:"assignees"`
+	Comments      json.RawMessage `json:"comments"`
+	HTMLURL       string          `json:"html_url"`
+	URL           string          `json:"url"`
+	PullRequest   json.RawMessage `json:"pull_request"`
+	IsPullRequest bool            `json:"isPullRequest"`
+}
+
+type milestone struct {
+	Title string     `json:"title"`
+	DueOn *time.Time `json:"due_on"`
+}
+
+// Labels are objects in both formats, but plain names are accepted too
+type label struct {
+	Name string
+}
+
+func (l *label) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &l.Name); err == nil {
+		return nil
+	}
+
+	var object struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	l.Name = object.Name
+
+	return nil
+}
+
+type GitHubImporter struct{}
+
+func NewGitHubImporter() importers.Importer {
+	return &GitHubImporter{}
+}
+
+func (g *GitHubImporter) Parse(r io.Reader, options *importers.Options) (*importers.Result, error) {
+	var issues []issue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, err
+	}
+
+	result := &importers.Result{}
+	for i, is := range issues {
+		// Pull requests are listed as issues by the REST API
+		if is.IsPullRequest || (len(is.PullRequest) > 0 && string(is.PullRequest) != "null") {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("#%d: pull request skipped", is.Number))
+			continue
+		}
+
+		repository := repositoryName(is.HTMLURL, is.URL)
+
+		// The repository becomes the project
+		project := repository
+		if options.Project != "" {
+			project = options.Project
+		}
+
+		record := &requests.TaskImportRecord{
+			ExternalID:  fmt.Sprintf("github:%s#%d", repository, is.Number),
+			Title:       is.Title,
+			Description: strings.TrimSpace(is.Body),
+			Status:      models.TaskStatusTodo,
+			Priority:    models.TaskPriorityMedium,
+			Project:     project,
+		}
+
+		if record.Description == "" {
+			record.Description = is.Title
+		}
+
+		if strings.EqualFold(is.State, "closed") {
+			record.Status = models.TaskStatusCompleted
+		}
+
+		// Labels become tags, the milestone a tag and a due date
+		for _, l := range is.Labels {
+			if l.Name == "" {
+				continue
+			}
+			record.Tags = append(record.Tags, l.Name)
+
+			if priority := labelPriority(l.Name); priority != 0 {
+				record.Priority = priority
+			}
+		}
+
+		if is.Milestone != nil {
+			record.Tags = append(record.Tags, is.Milestone.Title)
+			record.DueDate = is.Milestone.DueOn
+		}
+
+		result.Rows = append(result.Rows, transfer.Row{Line: i + 1, Record: record})
+
+		missing := []string{}
+		if len(is.Assignees) > 0 {
+			missing = append(missing, fmt.Sprintf("%d assignees", len(is.Assignees)))
+		}
+		if comments := countComments(is.Comments); comments > 0 {
+			missing = append(missing, fmt.Sprintf("%d comments", comments))
+		}
+		if len(missing) > 0 {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("#%d: %s not imported", is.Number, strings.Join(missing, ", ")))
+		}
+	}
+
+	return result, nil
+}
+
+// repositoryName finds "owner/repo" in the web or API URL of an issue
+func repositoryName(htmlURL string, apiURL string) string {
+	for _, raw := range []string{htmlURL, apiURL} {
+		parsed, err := url.Parse(raw)
+		if err != nil || parsed.Path == "" {
+			continue
+		}
+
+		parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		if len(parts) >= 2 && parts[0] == "repos" {
+			parts = parts[1:]
+		}
+
+		if len(parts) >= 2 {
+			return parts[0] + "/" + parts[1]
+		}
+	}
+
+	return "GitHub"
+}
+
+// Comments are a count in the REST API and a list in the gh CLI
+func countComments(raw json.RawMessage) int {
+	var count int
+	if err := json.Unmarshal(raw, &count); err == nil {
+		return count
+	}
+
+	var comments []json.RawMessage
+	if err := json.Unmarshal(raw, &comments); err == nil {
+		return len(comments)
+	}
+
+	return 0
+}
+
+func labelPriority(name string) int {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		switch {
+		case slices.Contains(highLabels, word):
+			return models.TaskPriorityHigh
+		case slices.Contains(lowLabels, word):
+			return models.TaskPriorityLow
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,35 @@
+package inapp
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+)
+
+type InAppNotifier struct {
+	service usecases.NotificationUseCase
+}
+
+func NewInAppNotifier(service usecases.NotificationUseCase) notifiers.Notifier {
+	return &InAppNotifier{
+		service: service,
+	}
+}
+
+func (i *InAppNotifier) Notify(ctx context.Context, msg *models.NotificationMessage) error {
+	var taskID *string
+	if msg.TaskID != "" {
+		taskID = &msg.TaskID
+	}
+
+	// Goes through the inbox so the user's preferences apply
+	return i.service.Notify(ctx, &models.Notification{
+		UserID: msg.UserID,
+		TaskID: taskID,
+		Type:   msg.Type,
+		Title:  msg.Subject,
+		Body:   msg.Body,
+	})
+}
@@ -0,0 +1,27 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/repositories/repositorytest"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/memory"
+)
+
+func TestConformance(t *testing.T) {
+	newRepos := func(t *testing.T) (repositories.UserRepository, repositories.TaskRepository) {
+		return memory.NewUserMemoryRepository(), memory.NewTaskMemoryRepository()
+	}
+
+	repositorytest.Run(t, newRepos)
+	repositorytest.RunNotificationRepository(t, newRepos, memory.NewNotificationMemoryRepository())
+
+	// The reporting repository reads the task repository it is built on
+	taskRepo := memory.NewTaskMemoryRepository()
+	sharedRepos := func(t *testing.T) (repositories.UserRepository, repositories.TaskRepository) {
+		return memory.NewUserMemoryRepository(), taskRepo
+	}
+
+	repositorytest.RunReportingRepository(t, sharedRepos, memory.NewReportingMemoryRepository(taskRepo))
+	repositorytest.RunTaskCommentRepository(t, newRepos, memory.NewTaskCommentMemoryRepository(), memory.NewTaskFollowerMemoryRepository())
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// NotificationMemoryRepository keeps notifications and preferences in maps, for
+// tests and running without a database
+type NotificationMemoryRepository struct {
+	mu            sync.RWMutex
+	notifications map[string]models.Notification
+	preferences   map[string]map[string]bool
+}
+
+func NewNotificationMemoryRepository() repositories.NotificationRepository {
+	return &NotificationMemoryRepository{
+		notifications: map[string]models.Notification{},
+		preferences:   map[string]map[string]bool{},
+	}
+}
+
+func (n *NotificationMemoryRepository) Create(ctx context.Context, notification *models.Notification) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.notifications[id.String()] = models.Notification{
+		ID:        id.String(),
+		UserID:    notification.UserID,
+		TaskID:    notification.TaskID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		CreatedAt: timestamp(),
+	}
+
+	return id.String(), nil
+}
+
+func (n *NotificationMemoryRepository) FindByID(ctx context.Context, notificationID string) (*models.Notification, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	notification, ok := n.notifications[notificationID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &notification, nil
+}
+
+func (n *NotificationMemoryRepository) FindByUserID(ctx context.Context, userID string, unreadOnly bool, limit int, offset int) ([]models.Notification, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	notifications := n.filter(userID, unreadOnly)
+
+	// Newest first, ids are time ordered
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].ID > notifications[j].ID
+	})
+
+	notifications = notifications[min(offset, len(notifications)):]
+
+	return notifications[:min(limit, len(notifications))], nil
+}
+
+func (n *NotificationMemoryRepository) CountByUserID(ctx context.Context, userID string, unreadOnly bool) (int, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return len(n.filter(userID, unreadOnly)), nil
+}
+
+func (n *NotificationMemoryRepository) MarkReadByID(ctx context.Context, notificationID string, readAt time.Time) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if notification, ok := n.notifications[notificationID]; ok && notification.ReadAt == nil {
+		notification.ReadAt = &readAt
+		n.notifications[notificationID] = notification
+	}
+
+	return nil
+}
+
+func (n *NotificationMemoryRepository) MarkAllReadByUserID(ctx context.Context, userID string, readAt time.Time) (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	marked := 0
+	for _, notification := range n.filter(userID, true) {
+		notification.ReadAt = &readAt
+		n.notifications[notification.ID] = notification
+		marked++
+	}
+
+	return marked, nil
+}
+
+func (n *NotificationMemoryRepository) FindPreferencesByUserID(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	preferences := []models.NotificationPreference{}
+	for notificationType, enabled := range n.preferences[userID] {
+		preferences = append(preferences, models.NotificationPreference{UserID: userID, Type: notificationType, Enabled: enabled})
+	}
+
+	return preferences, nil
+}
+
+func (n *NotificationMemoryRepository) UpsertPreference(ctx context.Context, userID string, notificationType string, enabled bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.preferences[userID] == nil {
+		n.preferences[userID] = map[string]bool{}
+	}
+	n.preferences[userID][notificationType] = enabled
+
+	return nil
+}
+
+func (n *NotificationMemoryRepository) filter(userID string, unreadOnly bool) []models.Notification {
+	notifications := []models.Notification{}
+	for _, notification := range n.notifications {
+		if notification.UserID == userID && (!unreadOnly || notification.ReadAt == nil) {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	return notifications
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// OutboxMemoryRepository keeps outbox messages in a map, for tests and running
+// without a database
+type OutboxMemoryRepository struct {
+	mu       sync.RWMutex
+	messages map[string]models.OutboxMessage
+}
+
+func NewOutboxMemoryRepository() repositories.OutboxRepository {
+	return &OutboxMemoryRepository{
+		messages: map[string]models.OutboxMessage{},
+	}
+}
+
+func (o *OutboxMemoryRepository) Create(ctx context.Context, eventType string, taskID string, payload string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := timestamp()
+	o.messages[id.String()] = models.OutboxMessage{
+		ID:        id.String(),
+		EventType: eventType,
+		TaskID:    taskID,
+		Payload:   payload,
+		Status:    models.OutboxStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return id.String(), nil
+}
+
+func (o *OutboxMemoryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]models.OutboxMessage, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	messages := []models.OutboxMessage{}
+	for _, message := range o.messages {
+		if claimable(&message, now) && (message.NextAttemptAt == nil || !message.NextAttemptAt.After(now)) {
+			messages = append(messages, message)
+		}
+	}
+
+	// Ids are time ordered like the SQL ORDER BY id
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+
+	return messages, nil
+}
+
+func (o *OutboxMemoryRepository) Claim(ctx context.Context, messageID string, now time.Time, lockedUntil time.Time) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	message, found := o.messages[messageID]
+	if !found || !claimable(&message, now) {
+		return false, nil
+	}
+
+	message.Status = models.OutboxStatusPublishing
+	message.LockedUntil = &lockedUntil
+	message.Attempts++
+	o.save(message)
+
+	return true, nil
+}
+
+func (o *OutboxMemoryRepository) MarkPublished(ctx context.Context, messageID string, publishedAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	message, found := o.messages[messageID]
+	if !found {
+		return nil
+	}
+
+	message.Status = models.OutboxStatusPublished
+	message.PublishedAt = &publishedAt
+	message.LastError = nil
+	message.LockedUntil = nil
+	o.save(message)
+
+	return nil
+}
+
+func (o *OutboxMemoryRepository) Reschedule(ctx context.Context, messageID string, lastError string, nextAttemptAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	message, found := o.messages[messageID]
+	if !found {
+		return nil
+	}
+
+	message.Status = models.OutboxStatusPending
+	message.LastError = &lastError
+	message.NextAttemptAt = &nextAttemptAt
+	message.LockedUntil = nil
+	o.save(message)
+
+	return nil
+}
+
+func (o *OutboxMemoryRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	deleted := 0
+	for id, message := range o.messages {
+		if message.Status == models.OutboxStatusPublished && message.PublishedAt.Before(before) {
+			delete(o.messages, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func (o *OutboxMemoryRepository) save(message models.OutboxMessage) {
+	message.UpdatedAt = timestamp()
+	o.messages[message.ID] = message
+}
+
+// claimable mirrors the SQL claim condition, a lock left by a crashed relay expires
+func claimable(message *models.OutboxMessage, now time.Time) bool {
+	if message.Status == models.OutboxStatusPending {
+		return true
+	}
+
+	return message.Status == models.OutboxStatusPublishing && message.LockedUntil != nil && message.LockedUntil.Before(now)
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/google/uuid"
+)
+
+// ProjectMemoryRepository keeps projects in a map, for tests and running
+// without a database
+type ProjectMemoryRepository struct {
+	mu       sync.RWMutex
+	projects map[string]models.Project
+}
+
+func NewProjectMemoryRepository() repositories.ProjectRepository {
+	return &ProjectMemoryRepository{
+		projects: map[string]models.Project{},
+	}
+}
+
+func (p *ProjectMemoryRepository) Create(ctx context.Context, req *requests.ProjectCreateRequest, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := timestamp()
+	p.projects[id.String()] = models.Project{
+		ID:        id.String(),
+		UserID:    userID,
+		Name:      req.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return id.String(), nil
+}
+
+func (p *ProjectMemoryRepository) FindByID(ctx context.Context, projectID string) (*models.Project, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	project, ok := p.projects[projectID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &project, nil
+}
+
+func (p *ProjectMemoryRepository) FindByUserID(ctx context.Context, userID string) ([]models.Project, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	projects := []models.Project{}
+	for _, project := range p.projects {
+		if project.UserID == userID {
+			projects = append(projects, project)
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
+
+func (p *ProjectMemoryRepository) FindByName(ctx context.Context, userID string, name string) (*models.Project, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, project := range p.projects {
+		if project.UserID == userID && project.Name == name {
+			return &project, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DeleteByID leaves tasks alone, they are kept by a separate repository
+func (p *ProjectMemoryRepository) DeleteByID(ctx context.Context, projectID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.projects, projectID)
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+)
+
+var _ repositories.ReportingRepository = (*ReportingMemoryRepository)(nil)
+
+// ReportingMemoryRepository computes the same aggregates as the SQL reporting
+// repository over the tasks and status history of a memory task repository
+type ReportingMemoryRepository struct {
+	tasks *TaskMemoryRepository
+}
+
+func NewReportingMemoryRepository(taskRepo repositories.TaskRepository) *ReportingMemoryRepository {
+	return &ReportingMemoryRepository{
+		tasks: taskRepo.(*TaskMemoryRepository),
+	}
+}
+
+func (r *ReportingMemoryRepository) CountByPeriod(ctx context.Context, userID string, from time.Time, to time.Time, interval string) ([]models.StatsPeriod, error) {
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	counts := map[string]*models.StatsPeriod{}
+	bucket := func(at time.Time) *models.StatsPeriod {
+		if at.Before(from) || !at.Before(to) {
+			return nil
+		}
+
+		period := models.StatsPeriodStart(at.In(from.Location()), interval).Format(time.DateOnly)
+		if counts[period] == nil {
+			counts[period] = &models.StatsPeriod{Period: period}
+		}
+
+		return counts[period]
+	}
+
+	for _, task := range r.tasks.tasks {
+		if task.UserID != userID {
+			continue
+		}
+
+		if count := bucket(task.CreatedAt); count != nil {
+			count.Created++
+		}
+	}
+
+	for _, change := range r.tasks.history {
+		if change.UserID != userID || change.ToStatus != models.TaskStatusCompleted {
+			continue
+		}
+
+		if count := bucket(change.ChangedAt); count != nil {
+			count.Completed++
+		}
+	}
+
+	periods := []models.StatsPeriod{}
+	for _, count := range counts {
+		periods = append(periods, *count)
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Period < periods[j].Period
+	})
+
+	return periods, nil
+}
+
+func (r *ReportingMemoryRepository) AverageCycleTime(ctx context.Context, userID string, from time.Time, to time.Time) (*models.CycleTime, error) {
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	// A task reopened and completed again counts from its first completion
+	completed := map[string]time.Time{}
+	for _, change := range r.tasks.history {
+		if change.UserID != userID || change.ToStatus != models.TaskStatusCompleted {
+			continue
+		}
+
+		if first, ok := completed[change.TaskID]; !ok || change.ChangedAt.Before(first) {
+			completed[change.TaskID] = change.ChangedAt
+		}
+	}
+
+	var total float64
+	cycleTime := &models.CycleTime{}
+	for taskID, completedAt := range completed {
+		task, ok := r.tasks.tasks[taskID]
+		if !ok || completedAt.Before(from) || !completedAt.Before(to) {
+			continue
+		}
+
+		total += completedAt.Sub(task.CreatedAt).Truncate(time.Second).Seconds()
+		cycleTime.Tasks++
+	}
+
+	if cycleTime.Tasks > 0 {
+		cycleTime.AverageSeconds = total / float64(cycleTime.Tasks)
+	}
+
+	return cycleTime, nil
+}
+
+func (r *ReportingMemoryRepository) CountOpenByPriority(ctx context.Context, userID string) ([]models.PriorityCount, error) {
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	counts := map[int]int64{}
+	for _, task := range r.tasks.tasks {
+		if task.UserID == userID && task.Status != models.TaskStatusCompleted {
+			counts[task.Priority]++
+		}
+	}
+
+	priorities := []models.PriorityCount{}
+	for priority, count := range counts {
+		priorities = append(priorities, models.PriorityCount{Priority: priority, Count: count})
+	}
+
+	sort.Slice(priorities, func(i, j int) bool {
+		return priorities[i].Priority > priorities[j].Priority
+	})
+
+	return priorities, nil
+}
+
+func (r *ReportingMemoryRepository) CountOverdue(ctx context.Context, userID string, now time.Time) (int64, error) {
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	var count int64
+	for _, task := range r.tasks.tasks {
+		if task.UserID != userID || task.Status == models.TaskStatusCompleted || task.DueDate == nil {
+			continue
+		}
+
+		if task.DueDate.Before(now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
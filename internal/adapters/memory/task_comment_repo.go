@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// TaskCommentMemoryRepository keeps comments in a map, for tests and running
+// without a database
+type TaskCommentMemoryRepository struct {
+	mu       sync.RWMutex
+	comments map[string]models.TaskComment
+}
+
+func NewTaskCommentMemoryRepository() repositories.TaskCommentRepository {
+	return &TaskCommentMemoryRepository{
+		comments: map[string]models.TaskComment{},
+	}
+}
+
+func (t *TaskCommentMemoryRepository) Create(ctx context.Context, taskID string, userID string, body string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.comments[id.String()] = models.TaskComment{
+		ID:        id.String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		Body:      body,
+		CreatedAt: timestamp(),
+	}
+
+	return id.String(), nil
+}
+
+func (t *TaskCommentMemoryRepository) FindByID(ctx context.Context, commentID string) (*models.TaskComment, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	comment, ok := t.comments[commentID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &comment, nil
+}
+
+func (t *TaskCommentMemoryRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskComment, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	comments := []models.TaskComment{}
+	for _, comment := range t.comments {
+		if comment.TaskID == taskID {
+			comments = append(comments, comment)
+		}
+	}
+
+	// Ids are time ordered like the SQL ORDER BY created_at, id
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].ID < comments[j].ID
+	})
+
+	return comments, nil
+}
+
+// TaskFollowerMemoryRepository keeps followers in a map per task, for tests and
+// running without a database
+type TaskFollowerMemoryRepository struct {
+	mu        sync.RWMutex
+	followers map[string][]models.TaskFollower
+}
+
+func NewTaskFollowerMemoryRepository() repositories.TaskFollowerRepository {
+	return &TaskFollowerMemoryRepository{
+		followers: map[string][]models.TaskFollower{},
+	}
+}
+
+func (t *TaskFollowerMemoryRepository) Create(ctx context.Context, taskID string, userID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Following twice keeps the first row
+	for _, follower := range t.followers[taskID] {
+		if follower.UserID == userID {
+			return nil
+		}
+	}
+
+	t.followers[taskID] = append(t.followers[taskID], models.TaskFollower{TaskID: taskID, UserID: userID, CreatedAt: timestamp()})
+
+	return nil
+}
+
+func (t *TaskFollowerMemoryRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskFollower, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return append([]models.TaskFollower{}, t.followers[taskID]...), nil
+}
+
+func (t *TaskFollowerMemoryRepository) DeleteByTaskIDAndUserID(ctx context.Context, taskID string, userID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	followers := []models.TaskFollower{}
+	for _, follower := range t.followers[taskID] {
+		if follower.UserID != userID {
+			followers = append(followers, follower)
+		}
+	}
+	t.followers[taskID] = followers
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// TaskEventMemoryStore keeps each stream in a slice, for tests and running
+// without a database
+type TaskEventMemoryStore struct {
+	mu      sync.RWMutex
+	streams map[string][]models.TaskStreamEvent
+}
+
+func NewTaskEventMemoryStore() repositories.TaskEventStore {
+	return &TaskEventMemoryStore{
+		streams: map[string][]models.TaskStreamEvent{},
+	}
+}
+
+func (t *TaskEventMemoryStore) Append(ctx context.Context, event *models.TaskStreamEvent) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Versions follow each other like the SQL unique key demands
+	stream := t.streams[event.TaskID]
+	if event.Version != len(stream)+1 {
+		return fmt.Errorf("task %s is at version %d, cannot append version %d", event.TaskID, len(stream), event.Version)
+	}
+
+	event.ID = id.String()
+	t.streams[event.TaskID] = append(stream, *event)
+
+	return nil
+}
+
+func (t *TaskEventMemoryStore) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskStreamEvent, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return append([]models.TaskStreamEvent{}, t.streams[taskID]...), nil
+}
+
+func (t *TaskEventMemoryStore) FindTaskIDsAfter(ctx context.Context, afterTaskID string, limit int) ([]string, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	taskIDs := []string{}
+	for taskID := range t.streams {
+		if taskID > afterTaskID {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Strings(taskIDs)
+
+	if len(taskIDs) > limit {
+		taskIDs = taskIDs[:limit]
+	}
+
+	return taskIDs, nil
+}
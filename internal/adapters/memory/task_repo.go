@@ -0,0 +1,514 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/google/uuid"
+)
+
+// TaskMemoryRepository keeps tasks in a map and mirrors what the SQL adapters
+// do on write, for tests and running without a database
+type TaskMemoryRepository struct {
+	mu      sync.RWMutex
+	tasks   map[string]models.Task
+	ended   map[string]string
+	history []models.TaskStatusChange
+}
+
+func NewTaskMemoryRepository() repositories.TaskRepository {
+	return &TaskMemoryRepository{
+		tasks: map[string]models.Task{},
+		ended: map[string]string{},
+	}
+}
+
+func (t *TaskMemoryRepository) Create(ctx context.Context, req *requests.TaskCreateRequest, userID string) (*models.Task, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// External ids are unique per user like the SQL index
+	if req.ExternalID != nil {
+		for _, task := range t.tasks {
+			if task.UserID == userID && task.ExternalID != nil && *task.ExternalID == *req.ExternalID {
+				return nil, exceptions.ErrDuplicatedExternalID
+			}
+		}
+	}
+
+	now := timestamp()
+	task := models.Task{
+		ID:                 id.String(),
+		UserID:             userID,
+		Title:              req.Title,
+		Description:        req.Description,
+		DescriptionHTML:    req.DescriptionHTML,
+		Priority:           req.Priority,
+		Status:             models.TaskStatusTodo,
+		DueDate:            truncateTime(req.DueDate),
+		RecurrenceRule:     emptyToNil(req.RecurrenceRule),
+		RecurrenceTimezone: emptyToNil(req.RecurrenceTimezone),
+		ProjectID:          clonePointer(req.ProjectID),
+		ExternalID:         clonePointer(req.ExternalID),
+		EstimateMinutes:    clonePointer(req.EstimateMinutes),
+		Tags:               sortedTags(req.Tags),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	// A recurring task starts its own series
+	if task.RecurrenceRule != nil {
+		task.SeriesID = clonePointer(&task.ID)
+	}
+
+	t.tasks[task.ID] = task
+
+	return cloneTask(&task), nil
+}
+
+func (t *TaskMemoryRepository) CreateOccurrence(ctx context.Context, task *models.Task, dueDate time.Time) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := timestamp()
+	occurrence := *cloneTask(task)
+	occurrence.ID = id.String()
+	occurrence.Status = models.TaskStatusTodo
+	occurrence.DueDate = truncateTime(&dueDate)
+	occurrence.ExternalID = nil
+	occurrence.Tags = sortedTags(task.Tags)
+	occurrence.CreatedAt = now
+	occurrence.UpdatedAt = now
+
+	t.tasks[occurrence.ID] = occurrence
+
+	return occurrence.ID, nil
+}
+
+func (t *TaskMemoryRepository) Save(ctx context.Context, task *models.Task) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	saved := *task
+	saved.DueDate = truncateTime(task.DueDate)
+	saved.Tags = sortedTags(task.Tags)
+	saved.CreatedAt = task.CreatedAt.UTC().Truncate(time.Second)
+	saved.UpdatedAt = task.UpdatedAt.UTC().Truncate(time.Second)
+	t.tasks[task.ID] = saved
+
+	return nil
+}
+
+// SaveStatusChange records a status change at a fixed time, for seeding the
+// history the reporting repository reads in tests
+func (t *TaskMemoryRepository) SaveStatusChange(change models.TaskStatusChange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.history = append(t.history, change)
+}
+
+func (t *TaskMemoryRepository) FindByID(ctx context.Context, taskID string) (*models.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	task, ok := t.tasks[taskID]
+	if !ok {
+		return nil, nil
+	}
+
+	return cloneTask(&task), nil
+}
+
+func (t *TaskMemoryRepository) FindByIDs(ctx context.Context, taskIDs []string) ([]models.Task, error) {
+	return t.filter(func(task *models.Task) bool {
+		return slices.Contains(taskIDs, task.ID)
+	}), nil
+}
+
+func (t *TaskMemoryRepository) FindByUserID(ctx context.Context, userID string) ([]models.Task, error) {
+	return t.filter(func(task *models.Task) bool {
+		return task.UserID == userID
+	}), nil
+}
+
+func (t *TaskMemoryRepository) FindByUserIDAfter(ctx context.Context, userID string, afterID string, limit int) ([]models.Task, error) {
+	// Ids are UUIDv7 so paging by id follows creation order
+	tasks := t.filter(func(task *models.Task) bool {
+		return task.UserID == userID && task.ID > afterID
+	})
+
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+
+	return tasks, nil
+}
+
+func (t *TaskMemoryRepository) FindByExternalID(ctx context.Context, userID string, externalID string) (*models.Task, error) {
+	tasks := t.filter(func(task *models.Task) bool {
+		return task.UserID == userID && task.ExternalID != nil && *task.ExternalID == externalID
+	})
+
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	return &tasks[0], nil
+}
+
+func (t *TaskMemoryRepository) FindByQuery(ctx context.Context, userID string, q *query.Query, now time.Time) ([]models.Task, error) {
+	tasks := t.filter(func(task *models.Task) bool {
+		return task.UserID == userID && q.Match(task, now)
+	})
+
+	query.SortTasks(tasks, q.Sort)
+
+	return tasks, nil
+}
+
+func (t *TaskMemoryRepository) FindLatestBySeriesID(ctx context.Context, seriesID string) (*models.Task, error) {
+	tasks := t.filter(func(task *models.Task) bool {
+		return task.SeriesID != nil && *task.SeriesID == seriesID
+	})
+
+	var latest *models.Task
+	for i := range tasks {
+		if latest == nil || laterDueDate(&tasks[i], latest) {
+			latest = &tasks[i]
+		}
+	}
+
+	return latest, nil
+}
+
+func (t *TaskMemoryRepository) FindRecurringDueBefore(ctx context.Context, before time.Time) ([]models.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// Only the latest occurrence of each series rolls forward, until the series ends
+	tasks := []models.Task{}
+	for _, task := range t.tasks {
+		if task.RecurrenceRule == nil || task.DueDate == nil || !task.DueDate.Before(before) {
+			continue
+		}
+
+		if rule, ok := t.ended[task.ID]; ok && rule == *task.RecurrenceRule {
+			continue
+		}
+
+		if t.hasLaterOccurrence(&task) {
+			continue
+		}
+
+		tasks = append(tasks, *cloneTask(&task))
+	}
+
+	sortByID(tasks)
+
+	return tasks, nil
+}
+
+func (t *TaskMemoryRepository) EndRecurrenceByID(ctx context.Context, taskID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The series rolls forward again once its rule changes
+	if task, ok := t.tasks[taskID]; ok && task.RecurrenceRule != nil {
+		t.ended[taskID] = *task.RecurrenceRule
+	}
+
+	return nil
+}
+
+func (t *TaskMemoryRepository) AssignByID(ctx context.Context, taskID string, assigneeID *string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if task, ok := t.tasks[taskID]; ok {
+		task.AssigneeID = assigneeID
+		task.UpdatedAt = timestamp()
+		t.tasks[taskID] = task
+	}
+
+	return nil
+}
+
+func (t *TaskMemoryRepository) DeleteByID(ctx context.Context, taskID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.deleteTask(taskID)
+
+	return nil
+}
+
+func (t *TaskMemoryRepository) UpdateByUD(ctx context.Context, taskID string, req *requests.TaskUpdateRequest) error {
+	fields := []string{"title", "description", "priority", "dueDate", "recurrenceRule", "projectId", "estimateMinutes"}
+
+	// Tags are left alone when the request doesn't mention them
+	if req.Tags != nil {
+		fields = append(fields, "tags")
+	}
+
+	return t.PatchByID(ctx, taskID, req, fields)
+}
+
+func (t *TaskMemoryRepository) PatchByID(ctx context.Context, taskID string, req *requests.TaskUpdateRequest, fields []string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	task, ok := t.tasks[taskID]
+	if !ok {
+		return nil
+	}
+
+	// Only the given fields are written
+	for _, field := range fields {
+		switch field {
+		case "title":
+			task.Title = req.Title
+		case "description":
+			task.Description = req.Description
+			task.DescriptionHTML = req.DescriptionHTML
+		case "priority":
+			task.Priority = req.Priority
+		case "dueDate":
+			task.DueDate = truncateTime(req.DueDate)
+		case "recurrenceRule":
+			// Keep the existing series when a rule is kept or added
+			task.RecurrenceRule = emptyToNil(req.RecurrenceRule)
+			task.RecurrenceTimezone = nil
+			if task.RecurrenceRule != nil {
+				task.RecurrenceTimezone = emptyToNil(req.RecurrenceTimezone)
+				if task.SeriesID == nil {
+					task.SeriesID = clonePointer(&task.ID)
+				}
+			}
+		case "projectId":
+			task.ProjectID = clonePointer(req.ProjectID)
+		case "estimateMinutes":
+			task.EstimateMinutes = clonePointer(req.EstimateMinutes)
+		case "tags":
+			task.Tags = sortedTags(req.Tags)
+		}
+	}
+
+	task.UpdatedAt = timestamp()
+	t.tasks[taskID] = task
+
+	return nil
+}
+
+func (t *TaskMemoryRepository) UpdateStatusByID(ctx context.Context, taskID string, status string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.updateStatus(taskID, status)
+
+	return nil
+}
+
+// ApplyBulk works on a copy of the store in atomic mode so the first failure
+// leaves everything untouched; otherwise a failing operation only fills its slot
+func (t *TaskMemoryRepository) ApplyBulk(ctx context.Context, operations []requests.TaskBulkOperation, atomic bool) ([]error, error) {
+	errs := make([]error, len(operations))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var snapshot map[string]models.Task
+	var history []models.TaskStatusChange
+	if atomic {
+		snapshot = maps.Clone(t.tasks)
+		history = slices.Clone(t.history)
+	}
+
+	for i := range operations {
+		err := t.applyBulkOperation(&operations[i])
+		if err == nil {
+			continue
+		}
+
+		errs[i] = err
+		if atomic {
+			t.tasks = snapshot
+			t.history = history
+			return errs, err
+		}
+	}
+
+	return errs, nil
+}
+
+func (t *TaskMemoryRepository) applyBulkOperation(operation *requests.TaskBulkOperation) error {
+	task, ok := t.tasks[operation.TaskID]
+
+	switch operation.Action {
+	case models.TaskBulkActionUpdateStatus:
+		t.updateStatus(operation.TaskID, operation.Status)
+		return nil
+	case models.TaskBulkActionChangePriority:
+		task.Priority = operation.Priority
+	case models.TaskBulkActionAddTag:
+		task.Tags = sortedTags(append(slices.Clone(task.Tags), operation.Tag))
+	case models.TaskBulkActionRemoveTag:
+		task.Tags = slices.DeleteFunc(slices.Clone(task.Tags), func(tag string) bool {
+			return tag == operation.Tag
+		})
+	case models.TaskBulkActionMoveProject:
+		task.ProjectID = clonePointer(operation.ProjectID)
+	case models.TaskBulkActionDelete:
+		t.deleteTask(operation.TaskID)
+		return nil
+	default:
+		return fmt.Errorf("unknown bulk action %q", operation.Action)
+	}
+
+	// Operations on a missing task change nothing, like an UPDATE matching no rows
+	if ok {
+		task.UpdatedAt = timestamp()
+		t.tasks[operation.TaskID] = task
+	}
+
+	return nil
+}
+
+// updateStatus records the transition in the status history like the SQL
+// stores, which the reporting repository reads
+func (t *TaskMemoryRepository) updateStatus(taskID string, status string) {
+	task, ok := t.tasks[taskID]
+	if !ok || task.Status == status {
+		return
+	}
+
+	now := timestamp()
+	t.history = append(t.history, models.TaskStatusChange{TaskID: taskID, UserID: task.UserID, FromStatus: task.Status, ToStatus: status, ChangedAt: now})
+
+	task.Status = status
+	task.UpdatedAt = now
+	t.tasks[taskID] = task
+}
+
+// deleteTask removes a task with its history, like the SQL foreign keys cascade
+func (t *TaskMemoryRepository) deleteTask(taskID string) {
+	delete(t.tasks, taskID)
+	delete(t.ended, taskID)
+
+	t.history = slices.DeleteFunc(t.history, func(change models.TaskStatusChange) bool {
+		return change.TaskID == taskID
+	})
+}
+
+func (t *TaskMemoryRepository) hasLaterOccurrence(task *models.Task) bool {
+	if task.SeriesID == nil {
+		return false
+	}
+
+	for _, other := range t.tasks {
+		if other.SeriesID != nil && *other.SeriesID == *task.SeriesID && laterDueDate(&other, task) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filter returns copies of the matching tasks ordered by id
+func (t *TaskMemoryRepository) filter(match func(task *models.Task) bool) []models.Task {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tasks := []models.Task{}
+	for _, task := range t.tasks {
+		if match(&task) {
+			tasks = append(tasks, *cloneTask(&task))
+		}
+	}
+
+	sortByID(tasks)
+
+	return tasks
+}
+
+func laterDueDate(a *models.Task, b *models.Task) bool {
+	if a.DueDate == nil {
+		return false
+	}
+
+	return b.DueDate == nil || a.DueDate.After(*b.DueDate)
+}
+
+func sortByID(tasks []models.Task) {
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// sortedTags matches the SQL adapters, which load tags ordered and never nil
+func sortedTags(tags []string) []string {
+	sorted := slices.Clone(tags)
+	if sorted == nil {
+		sorted = []string{}
+	}
+
+	slices.Sort(sorted)
+
+	return slices.Compact(sorted)
+}
+
+func cloneTask(task *models.Task) *models.Task {
+	clone := *task
+	clone.DueDate = clonePointer(task.DueDate)
+	clone.RecurrenceRule = clonePointer(task.RecurrenceRule)
+	clone.RecurrenceTimezone = clonePointer(task.RecurrenceTimezone)
+	clone.SeriesID = clonePointer(task.SeriesID)
+	clone.ProjectID = clonePointer(task.ProjectID)
+	clone.ExternalID = clonePointer(task.ExternalID)
+	clone.EstimateMinutes = clonePointer(task.EstimateMinutes)
+	clone.Tags = slices.Clone(task.Tags)
+
+	return &clone
+}
+
+// timestamp is the current time at the second precision SQL DATETIME columns keep
+func timestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func truncateTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	truncated := value.UTC().Truncate(time.Second)
+	return &truncated
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/search"
+)
+
+const (
+	snippetLength = 160
+	titleWeight   = 2
+)
+
+type indexedTask struct {
+	task        models.Task
+	title       []string
+	description []string
+}
+
+// TaskMemorySearchIndex does tokenised matching for tests and stores without full-text support
+type TaskMemorySearchIndex struct {
+	mu    sync.RWMutex
+	tasks map[string]*indexedTask
+}
+
+func NewTaskMemorySearchIndex() repositories.TaskSearchIndex {
+	return &TaskMemorySearchIndex{
+		tasks: map[string]*indexedTask{},
+	}
+}
+
+func (t *TaskMemorySearchIndex) Index(ctx context.Context, task *models.Task) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tasks[task.ID] = &indexedTask{
+		task:        *task,
+		title:       search.Tokenize(task.Title),
+		description: search.Tokenize(task.Description),
+	}
+
+	return nil
+}
+
+func (t *TaskMemorySearchIndex) Remove(ctx context.Context, taskID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tasks, taskID)
+
+	return nil
+}
+
+func (t *TaskMemorySearchIndex) Search(ctx context.Context, userID string, query string, limit int) ([]models.TaskSearchResult, error) {
+	terms := search.Tokenize(query)
+	if len(terms) == 0 {
+		return []models.TaskSearchResult{}, nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// Only the user's own tasks take part in ranking
+	var documents []*indexedTask
+	for _, document := range t.tasks {
+		if document.task.UserID == userID {
+			documents = append(documents, document)
+		}
+	}
+
+	// Rarer terms weigh more
+	idf := make(map[string]float64, len(terms))
+	for _, term := range terms {
+		frequency := 0
+		for _, document := range documents {
+			if countMatches(document.title, term)+countMatches(document.description, term) > 0 {
+				frequency++
+			}
+		}
+		idf[term] = math.Log(1 + float64(len(documents))/float64(frequency+1))
+	}
+
+	results := []models.TaskSearchResult{}
+	for _, document := range documents {
+		score := 0.0
+		for _, term := range terms {
+			matches := titleWeight*countMatches(document.title, term) + countMatches(document.description, term)
+			score += float64(matches) * idf[term]
+		}
+
+		if score == 0 {
+			continue
+		}
+
+		results = append(results, models.TaskSearchResult{
+			Task:           document.task,
+			Score:          score,
+			TitleHighlight: search.Highlight(document.task.Title, terms, 0),
+			Snippet:        search.Highlight(document.task.Description, terms, snippetLength),
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Task.ID < results[j].Task.ID
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+func countMatches(tokens []string, term string) int {
+	count := 0
+	for _, token := range tokens {
+		if search.Matches(token, term) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+)
+
+// UnitOfWork runs the function directly. The memory stores apply every call on
+// its own and cannot roll back, which is enough for use case tests.
+type UnitOfWork struct{}
+
+func NewUnitOfWork() repositories.UnitOfWork {
+	return &UnitOfWork{}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
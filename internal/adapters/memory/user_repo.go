@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/google/uuid"
+)
+
+// UserMemoryRepository keeps users in a map, for tests and running without a database
+type UserMemoryRepository struct {
+	mu    sync.RWMutex
+	users map[string]models.User
+}
+
+func NewUserMemoryRepository() repositories.UserRepository {
+	return &UserMemoryRepository{
+		users: map[string]models.User{},
+	}
+}
+
+func (u *UserMemoryRepository) Create(ctx context.Context, req *requests.UserRegisterRequest) error {
+	// Generate UUID
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// Emails are unique like the SQL column
+	for _, user := range u.users {
+		if user.Email == req.Email {
+			return exceptions.ErrDuplicatedEmail
+		}
+	}
+
+	now := timestamp()
+	u.users[id.String()] = models.User{
+		ID:        id.String(),
+		Name:      req.Name,
+		Email:     req.Email,
+		Password:  req.Password,
+		Timezone:  "UTC",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return nil
+}
+
+func (u *UserMemoryRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	return u.findOne(func(user *models.User) bool {
+		return user.ID == userID
+	})
+}
+
+func (u *UserMemoryRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return u.findOne(func(user *models.User) bool {
+		return user.Email == email
+	})
+}
+
+func (u *UserMemoryRepository) FindByCalendarToken(ctx context.Context, token string) (*models.User, error) {
+	return u.findOne(func(user *models.User) bool {
+		return user.CalendarToken != nil && *user.CalendarToken == token
+	})
+}
+
+func (u *UserMemoryRepository) UpdateCalendarToken(ctx context.Context, userID string, token *string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, ok := u.users[userID]
+	if !ok {
+		return nil
+	}
+
+	user.CalendarToken = clonePointer(token)
+	user.UpdatedAt = timestamp()
+	u.users[userID] = user
+
+	return nil
+}
+
+func (u *UserMemoryRepository) UpdateTimezone(ctx context.Context, userID string, timezone string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, ok := u.users[userID]
+	if !ok {
+		return nil
+	}
+
+	user.Timezone = timezone
+	user.UpdatedAt = timestamp()
+	u.users[userID] = user
+
+	return nil
+}
+
+func (u *UserMemoryRepository) findOne(match func(user *models.User) bool) (*models.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	for _, user := range u.users {
+		if match(&user) {
+			user.CalendarToken = clonePointer(user.CalendarToken)
+			return &user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	clone := *value
+	return &clone
+}
@@ -0,0 +1,54 @@
+package mysql_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/repositories/repositorytest"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/mysql"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// Runs against a throwaway database, e.g.
+// MYSQL_TEST_DSN="user:password@tcp(localhost:3306)/task-management-test?parseTime=true&loc=UTC&time_zone=%27%2B00%3A00%27"
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := sqlx.ConnectContext(ctx, "mysql", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := mysql.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := database.New(db)
+	newRepos := func(t *testing.T) (repositories.UserRepository, repositories.TaskRepository) {
+		return mysql.NewUserMySQLRepository(pool), mysql.NewTaskMySQLRepository(pool)
+	}
+
+	repositorytest.Run(t, newRepos)
+	repositorytest.RunUnitOfWork(t, newRepos, database.NewUnitOfWork(pool))
+	repositorytest.RunReminderRepository(t, newRepos, mysql.NewReminderMySQLRepository(pool))
+	repositorytest.RunNotificationRepository(t, newRepos, mysql.NewNotificationMySQLRepository(pool))
+	repositorytest.RunWebhookRepository(t, newRepos, mysql.NewWebhookMySQLRepository(pool))
+	repositorytest.RunTimeEntryRepository(t, newRepos, mysql.NewTimeEntryMySQLRepository(pool))
+	repositorytest.RunReportingRepository(t, newRepos, mysql.NewReportingMySQLRepository(pool))
+	repositorytest.RunTaskCommentRepository(t, newRepos, mysql.NewTaskCommentMySQLRepository(pool), mysql.NewTaskFollowerMySQLRepository(pool))
+}
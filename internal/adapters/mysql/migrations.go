@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"io/fs"
+
+	"github.com/GraphZC/sdd-task-management/internal/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	migrationLockName    = "sdd_task_management_schema_migrations"
+	migrationLockTimeout = 60
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// MySQL commits DDL implicitly, so migrations cannot run in a transaction
+var migrationDialect = migrations.Dialect{
+	CreateTable: "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP)",
+	Lock: func(ctx context.Context, conn *sqlx.Conn) error {
+		var acquired sql.NullInt64
+		if err := conn.GetContext(ctx, &acquired, "SELECT GET_LOCK(?, ?)", migrationLockName, migrationLockTimeout); err != nil {
+			return err
+		}
+
+		if !acquired.Valid || acquired.Int64 != 1 {
+			return errors.New("timed out waiting for the migration lock")
+		}
+
+		return nil
+	},
+	Unlock: func(ctx context.Context, conn *sqlx.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", migrationLockName)
+
+		return err
+	},
+}
+
+func NewMigrator(db *sqlx.DB) (*migrations.Migrator, error) {
+	source, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.New(db, source, migrationDialect)
+}
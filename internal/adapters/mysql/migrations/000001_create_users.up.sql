@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS users (
+    id CHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    calendar_token CHAR(64) NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY users_email_unique (email),
+    UNIQUE KEY users_calendar_token_unique (calendar_token)
+);
+
+-- Deployments that predate migrations created users and tasks by hand, with only
+-- the columns the first release used. IF NOT EXISTS keeps those tables, and the
+-- statement below adds whatever they lack, so an adopted table ends up like a
+-- freshly created one. On a fresh database there is nothing to add.
+SET SESSION group_concat_max_len = 65536;
+
+SET @ddl = COALESCE((
+    SELECT CONCAT('ALTER TABLE users ', GROUP_CONCAT(wanted.clause ORDER BY wanted.position SEPARATOR ', '))
+    FROM (
+    SELECT 1 AS position, 'COLUMN' AS kind, 'calendar_token' AS name, 'ADD COLUMN calendar_token CHAR(64) NULL' AS clause
+    UNION ALL SELECT 2, 'INDEX', 'users_email_unique', 'ADD UNIQUE KEY users_email_unique (email)'
+    UNION ALL SELECT 3, 'INDEX', 'users_calendar_token_unique', 'ADD UNIQUE KEY users_calendar_token_unique (calendar_token)'
+    ) wanted
+    WHERE (wanted.kind = 'COLUMN' AND NOT EXISTS (SELECT 1 FROM information_schema.columns c WHERE c.table_schema = DATABASE() AND c.table_name = 'users' AND c.column_name = wanted.name))
+        OR (wanted.kind = 'INDEX' AND NOT EXISTS (SELECT 1 FROM information_schema.statistics s WHERE s.table_schema = DATABASE() AND s.table_name = 'users' AND s.index_name = wanted.name))
+        OR (wanted.kind = 'FOREIGN KEY' AND NOT EXISTS (SELECT 1 FROM information_schema.table_constraints k WHERE k.table_schema = DATABASE() AND k.table_name = 'users' AND k.constraint_name = wanted.name))
+), 'DO 0');
+
+PREPARE adopt FROM @ddl;
+
+EXECUTE adopt;
+
+DEALLOCATE PREPARE adopt;
@@ -0,0 +1,10 @@
+CREATE TABLE projects (
+    id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY projects_user_name_unique (user_id, name),
+    CONSTRAINT projects_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,66 @@
+CREATE TABLE IF NOT EXISTS tasks (
+    id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    description_html TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'TODO',
+    priority TINYINT NOT NULL,
+    due_date DATETIME NULL,
+    recurrence_rule VARCHAR(255) NULL,
+    recurrence_timezone VARCHAR(64) NULL,
+    series_id CHAR(36) NULL,
+    project_id CHAR(36) NULL,
+    external_id VARCHAR(100) NULL,
+    estimate_minutes INT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY tasks_user_external_unique (user_id, external_id),
+    KEY tasks_user_status_index (user_id, status),
+    KEY tasks_user_due_date_index (user_id, due_date),
+    KEY tasks_series_index (series_id, due_date),
+    KEY tasks_recurrence_index (recurrence_rule, due_date),
+    FULLTEXT KEY tasks_title_fulltext (title),
+    FULLTEXT KEY tasks_title_description_fulltext (title, description),
+    CONSTRAINT tasks_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT tasks_project_fk FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE SET NULL
+);
+
+-- Deployments that predate migrations created users and tasks by hand, with only
+-- the columns the first release used. IF NOT EXISTS keeps those tables, and the
+-- statement below adds whatever they lack, so an adopted table ends up like a
+-- freshly created one. On a fresh database there is nothing to add.
+SET SESSION group_concat_max_len = 65536;
+
+SET @ddl = COALESCE((
+    SELECT CONCAT('ALTER TABLE tasks ', GROUP_CONCAT(wanted.clause ORDER BY wanted.position SEPARATOR ', '))
+    FROM (
+    SELECT 1 AS position, 'COLUMN' AS kind, 'description_html' AS name, 'ADD COLUMN description_html TEXT NOT NULL' AS clause
+    UNION ALL SELECT 2, 'COLUMN', 'due_date', 'ADD COLUMN due_date DATETIME NULL'
+    UNION ALL SELECT 3, 'COLUMN', 'recurrence_rule', 'ADD COLUMN recurrence_rule VARCHAR(255) NULL'
+    UNION ALL SELECT 4, 'COLUMN', 'recurrence_timezone', 'ADD COLUMN recurrence_timezone VARCHAR(64) NULL'
+    UNION ALL SELECT 5, 'COLUMN', 'series_id', 'ADD COLUMN series_id CHAR(36) NULL'
+    UNION ALL SELECT 6, 'COLUMN', 'project_id', 'ADD COLUMN project_id CHAR(36) NULL'
+    UNION ALL SELECT 7, 'COLUMN', 'external_id', 'ADD COLUMN external_id VARCHAR(100) NULL'
+    UNION ALL SELECT 8, 'COLUMN', 'estimate_minutes', 'ADD COLUMN estimate_minutes INT NULL'
+    UNION ALL SELECT 9, 'INDEX', 'tasks_user_external_unique', 'ADD UNIQUE KEY tasks_user_external_unique (user_id, external_id)'
+    UNION ALL SELECT 10, 'INDEX', 'tasks_user_status_index', 'ADD KEY tasks_user_status_index (user_id, status)'
+    UNION ALL SELECT 11, 'INDEX', 'tasks_user_due_date_index', 'ADD KEY tasks_user_due_date_index (user_id, due_date)'
+    UNION ALL SELECT 12, 'INDEX', 'tasks_series_index', 'ADD KEY tasks_series_index (series_id, due_date)'
+    UNION ALL SELECT 13, 'INDEX', 'tasks_recurrence_index', 'ADD KEY tasks_recurrence_index (recurrence_rule, due_date)'
+    UNION ALL SELECT 14, 'INDEX', 'tasks_title_fulltext', 'ADD FULLTEXT KEY tasks_title_fulltext (title)'
+    UNION ALL SELECT 15, 'INDEX', 'tasks_title_description_fulltext', 'ADD FULLTEXT KEY tasks_title_description_fulltext (title, description)'
+    UNION ALL SELECT 16, 'FOREIGN KEY', 'tasks_user_fk', 'ADD CONSTRAINT tasks_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'
+    UNION ALL SELECT 17, 'FOREIGN KEY', 'tasks_project_fk', 'ADD CONSTRAINT tasks_project_fk FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE SET NULL'
+    ) wanted
+    WHERE (wanted.kind = 'COLUMN' AND NOT EXISTS (SELECT 1 FROM information_schema.columns c WHERE c.table_schema = DATABASE() AND c.table_name = 'tasks' AND c.column_name = wanted.name))
+        OR (wanted.kind = 'INDEX' AND NOT EXISTS (SELECT 1 FROM information_schema.statistics s WHERE s.table_schema = DATABASE() AND s.table_name = 'tasks' AND s.index_name = wanted.name))
+        OR (wanted.kind = 'FOREIGN KEY' AND NOT EXISTS (SELECT 1 FROM information_schema.table_constraints k WHERE k.table_schema = DATABASE() AND k.table_name = 'tasks' AND k.constraint_name = wanted.name))
+), 'DO 0');
+
+PREPARE adopt FROM @ddl;
+
+EXECUTE adopt;
+
+DEALLOCATE PREPARE adopt;
@@ -0,0 +1,7 @@
+CREATE TABLE task_tags (
+    task_id CHAR(36) NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (task_id, tag),
+    KEY task_tags_tag_index (tag),
+    CONSTRAINT task_tags_task_fk FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+CREATE TABLE task_status_history (
+    id CHAR(36) NOT NULL,
+    task_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY task_status_history_task_index (task_id, changed_at),
+    KEY task_status_history_user_index (user_id, to_status, changed_at),
+    CONSTRAINT task_status_history_task_fk FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
@@ -0,0 +1,21 @@
+CREATE TABLE reminders (
+    id CHAR(36) NOT NULL,
+    task_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    remind_at DATETIME NULL,
+    offset_minutes INT NULL,
+    channel VARCHAR(20) NOT NULL,
+    target VARCHAR(2048) NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at DATETIME NULL,
+    locked_until DATETIME NULL,
+    sent_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY reminders_task_index (task_id),
+    KEY reminders_status_index (status, next_attempt_at),
+    CONSTRAINT reminders_task_fk FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
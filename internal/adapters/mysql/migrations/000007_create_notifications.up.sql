@@ -0,0 +1,22 @@
+CREATE TABLE notifications (
+    id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    task_id CHAR(36) NULL,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    read_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY notifications_user_index (user_id, read_at, created_at),
+    CONSTRAINT notifications_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT notifications_task_fk FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE SET NULL
+);
+
+CREATE TABLE notification_preferences (
+    user_id CHAR(36) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (user_id, type),
+    CONSTRAINT notification_preferences_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
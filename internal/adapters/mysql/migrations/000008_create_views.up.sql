@@ -0,0 +1,12 @@
+CREATE TABLE views (
+    id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL,
+    sort VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY views_user_index (user_id),
+    CONSTRAINT views_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
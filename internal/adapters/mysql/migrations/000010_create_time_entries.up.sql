@@ -0,0 +1,18 @@
+CREATE TABLE time_entries (
+    id CHAR(36) NOT NULL,
+    task_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME NULL,
+    duration_seconds BIGINT NOT NULL DEFAULT 0,
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    -- Only set while the timer runs, so each user has at most one running timer
+    running_user_id CHAR(36) AS (IF(ended_at IS NULL, user_id, NULL)) STORED,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY time_entries_running_unique (running_user_id),
+    KEY time_entries_task_index (task_id, started_at),
+    KEY time_entries_user_index (user_id, started_at),
+    CONSTRAINT time_entries_task_fk FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
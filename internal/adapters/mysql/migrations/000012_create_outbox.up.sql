@@ -0,0 +1,17 @@
+CREATE TABLE outbox (
+    id CHAR(36) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    task_id CHAR(36) NOT NULL,
+    payload MEDIUMTEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at DATETIME NULL,
+    locked_until DATETIME NULL,
+    published_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY outbox_status_index (status, next_attempt_at),
+    KEY outbox_published_index (published_at)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE task_events (
+    id CHAR(36) NOT NULL,
+    task_id CHAR(36) NOT NULL,
+    version INT NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    actor_id CHAR(36) NULL,
+    data MEDIUMTEXT NOT NULL,
+    occurred_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY task_events_task_version_unique (task_id, version)
+);
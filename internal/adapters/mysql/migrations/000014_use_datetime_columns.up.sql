@@ -0,0 +1,3 @@
+-- Nothing to do. SQLite declared its timestamps as TEXT until this version,
+-- these tables were created with DATETIME columns. The file keeps the versions
+-- in step across databases.
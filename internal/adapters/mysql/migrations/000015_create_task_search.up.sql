@@ -0,0 +1,3 @@
+-- Nothing to do. SQLite adds its full-text index at this version, search here
+-- reads the FULLTEXT index created with tasks in 000003.
+-- The file keeps the versions in step across databases.
@@ -0,0 +1,2 @@
+-- The rule a series ran out under, it rolls forward again once the rule changes
+ALTER TABLE tasks ADD COLUMN recurrence_ended_rule VARCHAR(255) NULL AFTER recurrence_timezone;
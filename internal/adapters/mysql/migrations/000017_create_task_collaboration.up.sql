@@ -0,0 +1,23 @@
+ALTER TABLE tasks ADD COLUMN assignee_id CHAR(36) NULL AFTER estimate_minutes, ADD CONSTRAINT tasks_assignee_fk FOREIGN KEY (assignee_id) REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE TABLE task_followers (
+    task_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id),
+    KEY task_followers_user_index (user_id),
+    CONSTRAINT task_followers_task_fk FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    CONSTRAINT task_followers_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE task_comments (
+    id CHAR(36) NOT NULL,
+    task_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY task_comments_task_index (task_id, created_at),
+    CONSTRAINT task_comments_task_fk FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    CONSTRAINT task_comments_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const outboxColumns = "id, event_type, task_id, payload, status, attempts, last_error, next_attempt_at, locked_until, published_at, created_at, updated_at"
+
+// OutboxMySQLRepository writes through the DB, so a message created with the
+// context of a unit of work commits or rolls back together with the task change
+type OutboxMySQLRepository struct {
+	db *database.DB
+}
+
+func NewOutboxMySQLRepository(db *database.DB) repositories.OutboxRepository {
+	return &OutboxMySQLRepository{
+		db: db,
+	}
+}
+
+func (o *OutboxMySQLRepository) Create(ctx context.Context, eventType string, taskID string, payload string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = o.db.ExecContext(ctx, "INSERT INTO outbox (id, event_type, task_id, payload, status) VALUES (?, ?, ?, ?, ?)", id.String(), eventType, taskID, payload, models.OutboxStatusPending)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (o *OutboxMySQLRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]models.OutboxMessage, error) {
+	// Messages left in PUBLISHING by a crashed instance are picked up again once their lock expires
+	var messages []models.OutboxMessage
+	err := o.db.SelectContext(ctx, &messages, "SELECT "+outboxColumns+" FROM outbox "+
+		"WHERE (status = ? OR (status = ? AND locked_until < ?)) "+
+		"AND (next_attempt_at IS NULL OR next_attempt_at <= ?) "+
+		"ORDER BY id LIMIT ?",
+		models.OutboxStatusPending, models.OutboxStatusPublishing, now, now, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (o *OutboxMySQLRepository) Claim(ctx context.Context, messageID string, now time.Time, lockedUntil time.Time) (bool, error) {
+	// Only one instance wins the conditional update
+	result, err := o.db.ExecContext(ctx, "UPDATE outbox SET status = ?, locked_until = ?, attempts = attempts + 1 WHERE id = ? AND (status = ? OR (status = ? AND locked_until < ?))", models.OutboxStatusPublishing, lockedUntil, messageID, models.OutboxStatusPending, models.OutboxStatusPublishing, now)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (o *OutboxMySQLRepository) MarkPublished(ctx context.Context, messageID string, publishedAt time.Time) error {
+	_, err := o.db.ExecContext(ctx, "UPDATE outbox SET status = ?, published_at = ?, last_error = NULL, locked_until = NULL WHERE id = ?", models.OutboxStatusPublished, publishedAt, messageID)
+
+	return err
+}
+
+func (o *OutboxMySQLRepository) Reschedule(ctx context.Context, messageID string, lastError string, nextAttemptAt time.Time) error {
+	_, err := o.db.ExecContext(ctx, "UPDATE outbox SET status = ?, last_error = ?, next_attempt_at = ?, locked_until = NULL WHERE id = ?", models.OutboxStatusPending, lastError, nextAttemptAt, messageID)
+
+	return err
+}
+
+func (o *OutboxMySQLRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := o.db.ExecContext(ctx, "DELETE FROM outbox WHERE status = ? AND published_at < ?", models.OutboxStatusPublished, before)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+
+	return int(deleted), err
+}
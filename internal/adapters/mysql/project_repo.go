@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const projectColumns = "id, user_id, name, created_at, updated_at"
+
+type ProjectMySQLRepository struct {
+	db *database.DB
+}
+
+func NewProjectMySQLRepository(db *database.DB) repositories.ProjectRepository {
+	return &ProjectMySQLRepository{
+		db: db,
+	}
+}
+
+func (p *ProjectMySQLRepository) Create(ctx context.Context, req *requests.ProjectCreateRequest, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = p.db.ExecContext(ctx, "INSERT INTO projects (id, user_id, name) VALUES (?, ?, ?)", id.String(), userID, req.Name)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (p *ProjectMySQLRepository) FindByID(ctx context.Context, projectID string) (*models.Project, error) {
+	var project models.Project
+	err := p.db.GetContext(ctx, &project, "SELECT "+projectColumns+" FROM projects WHERE id = ?", projectID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (p *ProjectMySQLRepository) FindByUserID(ctx context.Context, userID string) ([]models.Project, error) {
+	projects := []models.Project{}
+	err := p.db.SelectContext(ctx, &projects, "SELECT "+projectColumns+" FROM projects WHERE user_id = ? ORDER BY name", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (p *ProjectMySQLRepository) FindByName(ctx context.Context, userID string, name string) (*models.Project, error) {
+	var project models.Project
+	err := p.db.GetContext(ctx, &project, "SELECT "+projectColumns+" FROM projects WHERE user_id = ? AND name = ?", userID, name)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (p *ProjectMySQLRepository) DeleteByID(ctx context.Context, projectID string) error {
+	// Tasks in the project are kept and become unassigned
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET project_id = NULL WHERE project_id = ?", projectID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM projects WHERE id = ?", projectID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
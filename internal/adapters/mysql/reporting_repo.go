@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+type ReportingMySQLRepository struct {
+	db *database.DB
+}
+
+func NewReportingMySQLRepository(db *database.DB) repositories.ReportingRepository {
+	return &ReportingMySQLRepository{
+		db: db,
+	}
+}
+
+func (r *ReportingMySQLRepository) CountByPeriod(ctx context.Context, userID string, from time.Time, to time.Time, interval string) ([]models.StatsPeriod, error) {
+	created := periodExpression("created_at", interval, from)
+	completed := periodExpression("changed_at", interval, from)
+
+	periods := []models.StatsPeriod{}
+	err := r.db.SelectContext(ctx, &periods, fmt.Sprintf(`SELECT period, SUM(created) AS created, SUM(completed) AS completed FROM (
+		SELECT %s AS period, 1 AS created, 0 AS completed FROM tasks WHERE user_id = ? AND created_at >= ? AND created_at < ?
+		UNION ALL
+		SELECT %s AS period, 0 AS created, 1 AS completed FROM task_status_history WHERE user_id = ? AND to_status = ? AND changed_at >= ? AND changed_at < ?
+	) counts GROUP BY period ORDER BY period`, created, completed),
+		userID, from.UTC(), to.UTC(),
+		userID, models.TaskStatusCompleted, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (r *ReportingMySQLRepository) AverageCycleTime(ctx context.Context, userID string, from time.Time, to time.Time) (*models.CycleTime, error) {
+	// A task reopened and completed again counts from its first completion
+	var cycleTime models.CycleTime
+	err := r.db.GetContext(ctx, &cycleTime, `SELECT COALESCE(AVG(TIMESTAMPDIFF(SECOND, t.created_at, h.completed_at)), 0) AS average_seconds, COUNT(*) AS tasks
+		FROM tasks t
+		JOIN (SELECT task_id, MIN(changed_at) AS completed_at FROM task_status_history WHERE user_id = ? AND to_status = ? GROUP BY task_id) h ON h.task_id = t.id
+		WHERE h.completed_at >= ? AND h.completed_at < ?`,
+		userID, models.TaskStatusCompleted, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycleTime, nil
+}
+
+func (r *ReportingMySQLRepository) CountOpenByPriority(ctx context.Context, userID string) ([]models.PriorityCount, error) {
+	counts := []models.PriorityCount{}
+	err := r.db.SelectContext(ctx, &counts, "SELECT priority, COUNT(*) AS count FROM tasks WHERE user_id = ? AND status <> ? GROUP BY priority ORDER BY priority DESC", userID, models.TaskStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *ReportingMySQLRepository) CountOverdue(ctx context.Context, userID string, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM tasks WHERE user_id = ? AND status <> ? AND due_date IS NOT NULL AND due_date < ?", userID, models.TaskStatusCompleted, now.UTC())
+
+	return count, err
+}
+
+// periodExpression buckets a datetime column by day or by ISO week (Monday)
+// in the location of from
+func periodExpression(column string, interval string, from time.Time) string {
+	column = localDateTime(column, from)
+	if interval == models.StatsIntervalWeek {
+		return fmt.Sprintf("DATE_FORMAT(DATE_SUB(%s, INTERVAL WEEKDAY(%s) DAY), '%%Y-%%m-%%d')", column, column)
+	}
+
+	return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", column)
+}
+
+// localDateTime shifts a UTC datetime column into the location of at. The time
+// zone tables CONVERT_TZ needs are often not loaded, so the offset in effect at
+// at is used for the whole range.
+func localDateTime(column string, at time.Time) string {
+	_, offset := at.Zone()
+	if offset == 0 {
+		return column
+	}
+
+	return fmt.Sprintf("DATE_ADD(%s, INTERVAL %d SECOND)", column, offset)
+}
@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const taskCommentColumns = "id, task_id, user_id, body, created_at"
+
+type TaskCommentMySQLRepository struct {
+	db *database.DB
+}
+
+func NewTaskCommentMySQLRepository(db *database.DB) repositories.TaskCommentRepository {
+	return &TaskCommentMySQLRepository{
+		db: db,
+	}
+}
+
+func (t *TaskCommentMySQLRepository) Create(ctx context.Context, taskID string, userID string, body string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = t.db.ExecContext(ctx, "INSERT INTO task_comments (id, task_id, user_id, body) VALUES (?, ?, ?, ?)", id.String(), taskID, userID, body)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TaskCommentMySQLRepository) FindByID(ctx context.Context, commentID string) (*models.TaskComment, error) {
+	var comment models.TaskComment
+	err := t.db.GetContext(ctx, &comment, "SELECT "+taskCommentColumns+" FROM task_comments WHERE id = ?", commentID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (t *TaskCommentMySQLRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskComment, error) {
+	comments := []models.TaskComment{}
+	err := t.db.SelectContext(ctx, &comments, "SELECT "+taskCommentColumns+" FROM task_comments WHERE task_id = ? ORDER BY created_at, id", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+type TaskFollowerMySQLRepository struct {
+	db *database.DB
+}
+
+func NewTaskFollowerMySQLRepository(db *database.DB) repositories.TaskFollowerRepository {
+	return &TaskFollowerMySQLRepository{
+		db: db,
+	}
+}
+
+func (t *TaskFollowerMySQLRepository) Create(ctx context.Context, taskID string, userID string) error {
+	// Following twice keeps the first row
+	_, err := t.db.ExecContext(ctx, "INSERT INTO task_followers (task_id, user_id) SELECT id, ? FROM tasks WHERE id = ? AND NOT EXISTS (SELECT 1 FROM task_followers WHERE task_id = ? AND user_id = ?)", userID, taskID, taskID, userID)
+
+	return err
+}
+
+func (t *TaskFollowerMySQLRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskFollower, error) {
+	followers := []models.TaskFollower{}
+	err := t.db.SelectContext(ctx, &followers, "SELECT task_id, user_id, created_at FROM task_followers WHERE task_id = ? ORDER BY created_at, user_id", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return followers, nil
+}
+
+func (t *TaskFollowerMySQLRepository) DeleteByTaskIDAndUserID(ctx context.Context, taskID string, userID string) error {
+	_, err := t.db.ExecContext(ctx, "DELETE FROM task_followers WHERE task_id = ? AND user_id = ?", taskID, userID)
+
+	return err
+}
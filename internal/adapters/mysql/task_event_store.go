@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const taskEventColumns = "id, task_id, version, event_type, actor_id, data, occurred_at"
+
+// TaskEventMySQLStore keeps the streams in one table, the unique task and
+// version key stops two writers from appending the same version
+type TaskEventMySQLStore struct {
+	db *database.DB
+}
+
+func NewTaskEventMySQLStore(db *database.DB) repositories.TaskEventStore {
+	return &TaskEventMySQLStore{
+		db: db,
+	}
+}
+
+func (t *TaskEventMySQLStore) Append(ctx context.Context, event *models.TaskStreamEvent) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	_, err = t.db.ExecContext(ctx, "INSERT INTO task_events (id, task_id, version, event_type, actor_id, data, occurred_at) VALUES (?, ?, ?, ?, ?, ?, ?)", id.String(), event.TaskID, event.Version, event.Type, event.ActorID, event.Data, event.OccurredAt)
+	if err != nil {
+		return err
+	}
+
+	event.ID = id.String()
+
+	return nil
+}
+
+func (t *TaskEventMySQLStore) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskStreamEvent, error) {
+	events := []models.TaskStreamEvent{}
+	err := t.db.SelectContext(ctx, &events, "SELECT "+taskEventColumns+" FROM task_events WHERE task_id = ? ORDER BY version", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (t *TaskEventMySQLStore) FindTaskIDsAfter(ctx context.Context, afterTaskID string, limit int) ([]string, error) {
+	taskIDs := []string{}
+	err := t.db.SelectContext(ctx, &taskIDs, "SELECT DISTINCT task_id FROM task_events WHERE task_id > ? ORDER BY task_id LIMIT ?", afterTaskID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return taskIDs, nil
+}
@@ -3,41 +3,128 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
 	"github.com/GraphZC/sdd-task-management/domain/repositories"
 	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqltask"
+	"github.com/GraphZC/sdd-task-management/internal/database"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+// MySQL escapes LIKE with a backslash and compares DATETIME columns with time
+// arguments, so only the tag insert is its own
+var taskDialect = sqltask.Dialect{
+	BindType: sqlx.QUESTION,
+	AddTag:   "INSERT IGNORE INTO task_tags (task_id, tag) VALUES (?, ?)",
+}
+
+const taskColumns = "id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes, assignee_id, created_at, updated_at"
+
 type TaskMySQLRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
-func NewTaskMySQLRepository(db *sqlx.DB) repositories.TaskRepository {
+func NewTaskMySQLRepository(db *database.DB) repositories.TaskRepository {
 	return &TaskMySQLRepository{
 		db: db,
 	}
 }
 
-func (t *TaskMySQLRepository) Create(ctx context.Context, req *requests.TaskCreateRequest, userID string) (string, error) {
+func (t *TaskMySQLRepository) Create(ctx context.Context, req *requests.TaskCreateRequest, userID string) (*models.Task, error) {
 	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	// A recurring task starts its own series
+	var seriesID sql.NullString
+	if req.RecurrenceRule != "" {
+		seriesID = nullString(id.String())
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", id.String(), userID, req.Title, req.Description, req.DescriptionHTML, models.TaskStatusTodo, req.Priority, req.DueDate, nullString(req.RecurrenceRule), nullString(req.RecurrenceTimezone), seriesID, req.ProjectID, req.ExternalID, req.EstimateMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := taskDialect.ReplaceTags(ctx, tx, id.String(), req.Tags); err != nil {
+		return nil, err
+	}
+
+	// Read the row back in the transaction to get the generated timestamps
+	var task models.Task
+	if err := tx.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE id = ?", id.String()); err != nil {
+		return nil, err
+	}
+
+	if err := taskDialect.LoadTags(ctx, tx, []*models.Task{&task}); err != nil {
+		return nil, err
+	}
+
+	return &task, tx.Commit()
+}
+
+func (t *TaskMySQLRepository) CreateOccurrence(ctx context.Context, task *models.Task, dueDate time.Time) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return "", err
 	}
+	defer tx.Rollback()
 
-	_, err = t.db.ExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, status, priority) VALUES (?, ?, ?, ?, ?, ?)", id.String(), userID, req.Title, req.Description, models.TaskStatusTodo, req.Priority)
+	_, err = tx.ExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, estimate_minutes) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", id.String(), task.UserID, task.Title, task.Description, task.DescriptionHTML, models.TaskStatusTodo, task.Priority, dueDate, task.RecurrenceRule, task.RecurrenceTimezone, task.SeriesID, task.ProjectID, task.EstimateMinutes)
 	if err != nil {
 		return "", err
 	}
 
-	return id.String(), err
+	if err := taskDialect.ReplaceTags(ctx, tx, id.String(), task.Tags); err != nil {
+		return "", err
+	}
+
+	return id.String(), tx.Commit()
+}
+
+// Save writes the task as given, inserting it or replacing the stored row and
+// its tags. The projector restores tasks from their event streams with it.
+func (t *TaskMySQLRepository) Save(ctx context.Context, task *models.Task) error {
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes, assignee_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) AS saved "+
+		"ON DUPLICATE KEY UPDATE user_id = saved.user_id, title = saved.title, description = saved.description, description_html = saved.description_html, status = saved.status, priority = saved.priority, due_date = saved.due_date, recurrence_rule = saved.recurrence_rule, recurrence_timezone = saved.recurrence_timezone, series_id = saved.series_id, project_id = saved.project_id, external_id = saved.external_id, estimate_minutes = saved.estimate_minutes, assignee_id = saved.assignee_id, created_at = saved.created_at, updated_at = saved.updated_at",
+		task.ID, task.UserID, task.Title, task.Description, task.DescriptionHTML, task.Status, task.Priority, task.DueDate, task.RecurrenceRule, task.RecurrenceTimezone, task.SeriesID, task.ProjectID, task.ExternalID, task.EstimateMinutes, task.AssigneeID, task.CreatedAt, task.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := taskDialect.ReplaceTags(ctx, tx, task.ID, task.Tags); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (t *TaskMySQLRepository) FindByID(ctx context.Context, taskID string) (*models.Task, error) {
 	var task models.Task
-	err := t.db.GetContext(ctx, &task, "SELECT id, user_id, title, description, status, priority, created_at, updated_at FROM tasks WHERE id = ?", taskID)
+	err := t.db.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE id = ?", taskID)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -47,18 +134,103 @@ func (t *TaskMySQLRepository) FindByID(ctx context.Context, taskID string) (*mod
 		return nil, err
 	}
 
-	return &task, err
+	return &task, taskDialect.LoadTags(ctx, t.db, []*models.Task{&task})
 }
 
 func (t *TaskMySQLRepository) FindByUserID(ctx context.Context, userID string) ([]models.Task, error) {
 	var tasks []models.Task
-	err := t.db.SelectContext(ctx, &tasks, "SELECT id, user_id, title, description, status, priority, created_at, updated_at FROM tasks WHERE user_id = ?", userID)
+	err := t.db.SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ?", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskMySQLRepository) FindByUserIDAfter(ctx context.Context, userID string, afterID string, limit int) ([]models.Task, error) {
+	// Ids are UUIDv7 so paging by id follows creation order
+	tasks := []models.Task{}
+	err := t.db.SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ? AND id > ? ORDER BY id LIMIT ?", userID, afterID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskMySQLRepository) FindByExternalID(ctx context.Context, userID string, externalID string) (*models.Task, error) {
+	var task models.Task
+	err := t.db.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ? AND external_id = ?", userID, externalID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	return tasks, nil
+	return &task, taskDialect.LoadTags(ctx, t.db, []*models.Task{&task})
+}
+
+func (t *TaskMySQLRepository) FindByQuery(ctx context.Context, userID string, q *query.Query, now time.Time) ([]models.Task, error) {
+	where, args := taskDialect.CompileConditions(q.Conditions, now)
+
+	statement := "SELECT " + taskColumns + " FROM tasks WHERE user_id = ?"
+	if where != "" {
+		statement += " AND " + where
+	}
+	statement += " ORDER BY " + sqltask.CompileSort(q.Sort)
+
+	tasks := []models.Task{}
+	err := t.db.SelectContext(ctx, &tasks, statement, append([]any{userID}, args...)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskMySQLRepository) FindLatestBySeriesID(ctx context.Context, seriesID string) (*models.Task, error) {
+	var task models.Task
+	err := t.db.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE series_id = ? ORDER BY due_date DESC LIMIT 1", seriesID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, taskDialect.LoadTags(ctx, t.db, []*models.Task{&task})
+}
+
+func (t *TaskMySQLRepository) FindRecurringDueBefore(ctx context.Context, before time.Time) ([]models.Task, error) {
+	// Only the latest occurrence of each series rolls forward, until the series ends
+	var tasks []models.Task
+	err := t.db.SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks t WHERE t.recurrence_rule IS NOT NULL AND t.due_date < ? AND (t.recurrence_ended_rule IS NULL OR t.recurrence_ended_rule <> t.recurrence_rule) AND NOT EXISTS (SELECT 1 FROM tasks n WHERE n.series_id = t.series_id AND n.due_date > t.due_date)", before)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskMySQLRepository) EndRecurrenceByID(ctx context.Context, taskID string) error {
+	_, err := t.db.ExecContext(ctx, "UPDATE tasks SET recurrence_ended_rule = recurrence_rule WHERE id = ?", taskID)
+
+	return err
+}
+
+func (t *TaskMySQLRepository) AssignByID(ctx context.Context, taskID string, assigneeID *string) error {
+	_, err := t.db.ExecContext(ctx, "UPDATE tasks SET assignee_id = ? WHERE id = ?", assigneeID, taskID)
+
+	return err
 }
 
 func (t *TaskMySQLRepository) DeleteByID(ctx context.Context, taskID string) error {
@@ -68,13 +240,105 @@ func (t *TaskMySQLRepository) DeleteByID(ctx context.Context, taskID string) err
 }
 
 func (t *TaskMySQLRepository) UpdateByUD(ctx context.Context, taskID string, req *requests.TaskCreateRequest) error {
-	_, err := t.db.ExecContext(ctx, "UPDATE tasks SET title = ?, description = ?, priority = ? WHERE id = ?", req.Title, req.Description, req.Priority, taskID)
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	return err
+	// Keep the existing series when a rule is kept or added, drop it when the rule is removed
+	if req.RecurrenceRule != "" {
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET title = ?, description = ?, description_html = ?, priority = ?, due_date = ?, recurrence_rule = ?, recurrence_timezone = ?, series_id = COALESCE(series_id, id), project_id = ?, estimate_minutes = ? WHERE id = ?", req.Title, req.Description, req.DescriptionHTML, req.Priority, req.DueDate, req.RecurrenceRule, nullString(req.RecurrenceTimezone), req.ProjectID, req.EstimateMinutes, taskID)
+	} else {
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET title = ?, description = ?, description_html = ?, priority = ?, due_date = ?, recurrence_rule = NULL, recurrence_timezone = NULL, project_id = ?, estimate_minutes = ? WHERE id = ?", req.Title, req.Description, req.DescriptionHTML, req.Priority, req.DueDate, req.ProjectID, req.EstimateMinutes, taskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Tags are left alone when the request doesn't mention them
+	if req.Tags != nil {
+		if err := taskDialect.ReplaceTags(ctx, tx, taskID, req.Tags); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (t *TaskMySQLRepository) PatchByID(ctx context.Context, taskID string, req *requests.TaskUpdateRequest, fields []string) error {
+	// Only the columns behind the given fields are written
+	sets := []string{}
+	args := []any{}
+	updateTags := false
+	for _, field := range fields {
+		switch field {
+		case "title":
+			sets = append(sets, "title = ?")
+			args = append(args, req.Title)
+		case "description":
+			sets = append(sets, "description = ?", "description_html = ?")
+			args = append(args, req.Description, req.DescriptionHTML)
+		case "priority":
+			sets = append(sets, "priority = ?")
+			args = append(args, req.Priority)
+		case "dueDate":
+			sets = append(sets, "due_date = ?")
+			args = append(args, req.DueDate)
+		case "recurrenceRule":
+			if req.RecurrenceRule != "" {
+				sets = append(sets, "recurrence_rule = ?", "recurrence_timezone = ?", "series_id = COALESCE(series_id, id)")
+				args = append(args, req.RecurrenceRule, nullString(req.RecurrenceTimezone))
+			} else {
+				sets = append(sets, "recurrence_rule = NULL", "recurrence_timezone = NULL")
+			}
+		case "projectId":
+			sets = append(sets, "project_id = ?")
+			args = append(args, req.ProjectID)
+		case "estimateMinutes":
+			sets = append(sets, "estimate_minutes = ?")
+			args = append(args, req.EstimateMinutes)
+		case "tags":
+			updateTags = true
+		}
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(sets) > 0 {
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET "+strings.Join(sets, ", ")+" WHERE id = ?", append(args, taskID)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if updateTags {
+		if err := taskDialect.ReplaceTags(ctx, tx, taskID, req.Tags); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (t *TaskMySQLRepository) UpdateStatusByID(ctx context.Context, taskID string, status string) error {
-	_, err := t.db.ExecContext(ctx, "UPDATE tasks SET status = ? WHERE id = ?", status, taskID)
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	return err
+	if err := taskDialect.UpdateStatus(ctx, tx, taskID, status); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
 }
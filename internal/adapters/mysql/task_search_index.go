@@ -0,0 +1,80 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/search"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+const snippetLength = 160
+
+type taskSearchRow struct {
+	models.Task
+	Score float64 `db:"score"`
+}
+
+// TaskMySQLSearchIndex reads the FULLTEXT index on tasks(title, description),
+// which MySQL keeps up to date with every write to the table
+type TaskMySQLSearchIndex struct {
+	db *database.DB
+}
+
+func NewTaskMySQLSearchIndex(db *database.DB) repositories.TaskSearchIndex {
+	return &TaskMySQLSearchIndex{
+		db: db,
+	}
+}
+
+func (t *TaskMySQLSearchIndex) Index(ctx context.Context, task *models.Task) error {
+	return nil
+}
+
+func (t *TaskMySQLSearchIndex) Remove(ctx context.Context, taskID string) error {
+	return nil
+}
+
+func (t *TaskMySQLSearchIndex) Search(ctx context.Context, userID string, query string, limit int) ([]models.TaskSearchResult, error) {
+	terms := search.Tokenize(query)
+	if len(terms) == 0 {
+		return []models.TaskSearchResult{}, nil
+	}
+
+	// Natural language mode ranks by relevance, title matches are weighted higher
+	against := strings.Join(terms, " ")
+
+	var rows []taskSearchRow
+	err := t.db.SelectContext(ctx, &rows, "SELECT "+taskColumns+", "+
+		"MATCH(title) AGAINST (? IN NATURAL LANGUAGE MODE) * 2 + MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE) AS score "+
+		"FROM tasks WHERE user_id = ? AND MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE) "+
+		"ORDER BY score DESC LIMIT ?",
+		against, against, userID, against, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, len(rows))
+	for i := range rows {
+		tasks[i] = &rows[i].Task
+	}
+
+	if err := taskDialect.LoadTags(ctx, t.db, tasks); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.TaskSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, models.TaskSearchResult{
+			Task:           row.Task,
+			Score:          row.Score,
+			TitleHighlight: search.Highlight(row.Title, terms, 0),
+			Snippet:        search.Highlight(row.Description, terms, snippetLength),
+		})
+	}
+
+	return results, nil
+}
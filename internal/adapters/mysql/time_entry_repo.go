@@ -0,0 +1,150 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+)
+
+// duplicateEntry is the MySQL error number for a unique key violation
+const duplicateEntry = 1062
+
+const timeEntryColumns = "id, task_id, user_id, started_at, ended_at, duration_seconds, note, created_at, updated_at"
+
+type TimeEntryMySQLRepository struct {
+	db *database.DB
+}
+
+func NewTimeEntryMySQLRepository(db *database.DB) repositories.TimeEntryRepository {
+	return &TimeEntryMySQLRepository{
+		db: db,
+	}
+}
+
+func (t *TimeEntryMySQLRepository) Create(ctx context.Context, req *requests.TimeEntryCreateRequest, taskID string, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	duration := int64(req.EndedAt.Sub(*req.StartedAt) / time.Second)
+
+	_, err = t.db.ExecContext(ctx, "INSERT INTO time_entries (id, task_id, user_id, started_at, ended_at, duration_seconds, note) VALUES (?, ?, ?, ?, ?, ?, ?)", id.String(), taskID, userID, req.StartedAt.UTC(), req.EndedAt.UTC(), duration, req.Note)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TimeEntryMySQLRepository) Start(ctx context.Context, taskID string, userID string, note string, startedAt time.Time) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	// The unique key on running timers settles two starts racing each other
+	_, err = t.db.ExecContext(ctx, "INSERT INTO time_entries (id, task_id, user_id, started_at, duration_seconds, note) VALUES (?, ?, ?, ?, 0, ?)", id.String(), taskID, userID, startedAt.UTC(), note)
+	var mysqlErr *driver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry && strings.Contains(mysqlErr.Message, "time_entries_running_unique") {
+		return "", exceptions.ErrTimerAlreadyRunning
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TimeEntryMySQLRepository) Stop(ctx context.Context, entryID string, endedAt time.Time) error {
+	_, err := t.db.ExecContext(ctx, "UPDATE time_entries SET ended_at = ?, duration_seconds = GREATEST(TIMESTAMPDIFF(SECOND, started_at, ?), 0) WHERE id = ? AND ended_at IS NULL", endedAt.UTC(), endedAt.UTC(), entryID)
+
+	return err
+}
+
+func (t *TimeEntryMySQLRepository) FindByID(ctx context.Context, entryID string) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := t.db.GetContext(ctx, &entry, "SELECT "+timeEntryColumns+" FROM time_entries WHERE id = ?", entryID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (t *TimeEntryMySQLRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TimeEntry, error) {
+	entries := []models.TimeEntry{}
+	err := t.db.SelectContext(ctx, &entries, "SELECT "+timeEntryColumns+" FROM time_entries WHERE task_id = ? ORDER BY started_at", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (t *TimeEntryMySQLRepository) FindRunningByUserID(ctx context.Context, userID string) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := t.db.GetContext(ctx, &entry, "SELECT "+timeEntryColumns+" FROM time_entries WHERE user_id = ? AND ended_at IS NULL", userID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (t *TimeEntryMySQLRepository) DeleteByID(ctx context.Context, entryID string) error {
+	_, err := t.db.ExecContext(ctx, "DELETE FROM time_entries WHERE id = ?", entryID)
+
+	return err
+}
+
+func (t *TimeEntryMySQLRepository) Timesheet(ctx context.Context, userID string, from time.Time, to time.Time, groupBy string) ([]models.TimesheetRow, error) {
+	// Only finished entries count, grouped by the day they started on in from's location
+	var groupKey, joins string
+	switch groupBy {
+	case models.TimesheetGroupByDay:
+		groupKey = "DATE_FORMAT(" + localDateTime("e.started_at", from) + ", '%Y-%m-%d')"
+	case models.TimesheetGroupByProject:
+		groupKey = "COALESCE(p.name, '')"
+		joins = "JOIN tasks t ON t.id = e.task_id LEFT JOIN projects p ON p.id = t.project_id"
+	case models.TimesheetGroupByTag:
+		groupKey = "COALESCE(tt.tag, '')"
+		joins = "LEFT JOIN task_tags tt ON tt.task_id = e.task_id"
+	default:
+		return nil, fmt.Errorf("unknown timesheet group %q", groupBy)
+	}
+
+	statement := "SELECT " + groupKey + " AS group_key, SUM(e.duration_seconds) AS seconds, COUNT(*) AS entries FROM time_entries e " + joins + " WHERE e.user_id = ? AND e.ended_at IS NOT NULL AND e.started_at >= ? AND e.started_at < ? GROUP BY group_key ORDER BY group_key"
+
+	rows := []models.TimesheetRow{}
+	err := t.db.SelectContext(ctx, &rows, statement, userID, from.UTC(), to.UTC())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
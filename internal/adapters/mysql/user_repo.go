@@ -7,16 +7,18 @@ import (
 	"github.com/GraphZC/sdd-task-management/domain/models"
 	"github.com/GraphZC/sdd-task-management/domain/repositories"
 	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
+const userColumns = "id, name, email, password, calendar_token, timezone, created_at, updated_at"
+
 type UserMySQLRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
-func NewUserMySQLRepository(db *sqlx.DB) repositories.UserRepository {
+func NewUserMySQLRepository(db *database.DB) repositories.UserRepository {
 	return &UserMySQLRepository{
 		db: db,
 	}
@@ -29,14 +31,29 @@ func (u *UserMySQLRepository) Create(ctx context.Context, req *requests.UserRegi
 		return err
 	}
 
-	_, err = u.db.QueryContext(ctx, "INSERT INTO users (id, name, email, password) VALUES (?, ?, ?, ?)", id.String(), req.Name, req.Email, req.Password)
+	_, err = u.db.ExecContext(ctx, "INSERT INTO users (id, name, email, password) VALUES (?, ?, ?, ?)", id.String(), req.Name, req.Email, req.Password)
 
 	return err
 }
 
+func (u *UserMySQLRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	var user models.User
+	err := u.db.GetContext(ctx, &user, "SELECT "+userColumns+" FROM users WHERE id = ?", userID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (u *UserMySQLRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := u.db.GetContext(ctx, &user, "SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = ?", email)
+	err := u.db.GetContext(ctx, &user, "SELECT "+userColumns+" FROM users WHERE email = ?", email)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -48,3 +65,30 @@ func (u *UserMySQLRepository) FindByEmail(ctx context.Context, email string) (*m
 
 	return &user, nil
 }
+
+func (u *UserMySQLRepository) FindByCalendarToken(ctx context.Context, token string) (*models.User, error) {
+	var user models.User
+	err := u.db.GetContext(ctx, &user, "SELECT "+userColumns+" FROM users WHERE calendar_token = ?", token)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (u *UserMySQLRepository) UpdateCalendarToken(ctx context.Context, userID string, token *string) error {
+	_, err := u.db.ExecContext(ctx, "UPDATE users SET calendar_token = ? WHERE id = ?", token, userID)
+
+	return err
+}
+
+func (u *UserMySQLRepository) UpdateTimezone(ctx context.Context, userID string, timezone string) error {
+	_, err := u.db.ExecContext(ctx, "UPDATE users SET timezone = ? WHERE id = ?", timezone, userID)
+
+	return err
+}
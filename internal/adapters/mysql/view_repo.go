@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const viewColumns = "id, user_id, name, query, sort, created_at, updated_at"
+
+type ViewMySQLRepository struct {
+	db *database.DB
+}
+
+func NewViewMySQLRepository(db *database.DB) repositories.ViewRepository {
+	return &ViewMySQLRepository{
+		db: db,
+	}
+}
+
+func (v *ViewMySQLRepository) Create(ctx context.Context, req *requests.ViewCreateRequest, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = v.db.ExecContext(ctx, "INSERT INTO views (id, user_id, name, query, sort) VALUES (?, ?, ?, ?, ?)", id.String(), userID, req.Name, req.Query, req.Sort)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (v *ViewMySQLRepository) FindByID(ctx context.Context, viewID string) (*models.View, error) {
+	var view models.View
+	err := v.db.GetContext(ctx, &view, "SELECT "+viewColumns+" FROM views WHERE id = ?", viewID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+func (v *ViewMySQLRepository) FindByUserID(ctx context.Context, userID string) ([]models.View, error) {
+	views := []models.View{}
+	err := v.db.SelectContext(ctx, &views, "SELECT "+viewColumns+" FROM views WHERE user_id = ? ORDER BY name", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (v *ViewMySQLRepository) UpdateByID(ctx context.Context, viewID string, req *requests.ViewUpdateRequest) error {
+	_, err := v.db.ExecContext(ctx, "UPDATE views SET name = ?, query = ?, sort = ? WHERE id = ?", req.Name, req.Query, req.Sort, viewID)
+
+	return err
+}
+
+func (v *ViewMySQLRepository) DeleteByID(ctx context.Context, viewID string) error {
+	_, err := v.db.ExecContext(ctx, "DELETE FROM views WHERE id = ?", viewID)
+
+	return err
+}
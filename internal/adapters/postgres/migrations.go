@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+
+	"github.com/GraphZC/sdd-task-management/internal/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+// migrationLockKey is an arbitrary key for pg_advisory_lock shared by every instance
+const migrationLockKey = 7325010114
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationDialect = migrations.Dialect{
+	CreateTable: "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, name TEXT NOT NULL, applied_at TEXT NOT NULL DEFAULT to_char(now() AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI:SS'))",
+	Lock: func(ctx context.Context, conn *sqlx.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey)
+
+		return err
+	},
+	Unlock: func(ctx context.Context, conn *sqlx.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+		return err
+	},
+	// Postgres rolls back DDL, so a failed migration leaves nothing half applied
+	Transactional: true,
+}
+
+func NewMigrator(db *sqlx.DB) (*migrations.Migrator, error) {
+	source, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.New(db, source, migrationDialect)
+}
@@ -0,0 +1,32 @@
+CREATE TABLE tasks (
+    id UUID NOT NULL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    description_html TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'TODO',
+    priority SMALLINT NOT NULL,
+    due_date TIMESTAMPTZ NULL,
+    recurrence_rule TEXT NULL,
+    recurrence_timezone TEXT NULL,
+    series_id UUID NULL,
+    project_id UUID NULL REFERENCES projects (id) ON DELETE SET NULL,
+    external_id VARCHAR(100) NULL,
+    estimate_minutes INTEGER NULL,
+    search_vector TSVECTOR GENERATED ALWAYS AS (setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', description), 'B')) STORED,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, external_id)
+);
+
+CREATE INDEX tasks_user_status_index ON tasks (user_id, status);
+
+CREATE INDEX tasks_user_due_date_index ON tasks (user_id, due_date);
+
+CREATE INDEX tasks_series_index ON tasks (series_id, due_date);
+
+CREATE INDEX tasks_recurrence_index ON tasks (due_date) WHERE recurrence_rule IS NOT NULL;
+
+CREATE INDEX tasks_search_index ON tasks USING GIN (search_vector);
+
+CREATE TRIGGER tasks_updated_at BEFORE UPDATE ON tasks FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,7 @@
+CREATE TABLE task_tags (
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (task_id, tag)
+);
+
+CREATE INDEX task_tags_tag_index ON task_tags (tag);
@@ -0,0 +1,12 @@
+CREATE TABLE task_status_history (
+    id UUID NOT NULL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX task_status_history_task_index ON task_status_history (task_id, changed_at);
+
+CREATE INDEX task_status_history_user_index ON task_status_history (user_id, to_status, changed_at);
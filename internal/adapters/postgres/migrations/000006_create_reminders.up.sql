@@ -0,0 +1,23 @@
+CREATE TABLE reminders (
+    id UUID NOT NULL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    remind_at TIMESTAMPTZ NULL,
+    offset_minutes INTEGER NULL,
+    channel VARCHAR(20) NOT NULL,
+    target TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMPTZ NULL,
+    locked_until TIMESTAMPTZ NULL,
+    sent_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX reminders_task_index ON reminders (task_id);
+
+CREATE INDEX reminders_status_index ON reminders (status, next_attempt_at);
+
+CREATE TRIGGER reminders_updated_at BEFORE UPDATE ON reminders FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,13 @@
+CREATE TABLE views (
+    id UUID NOT NULL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL,
+    sort TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX views_user_index ON views (user_id);
+
+CREATE TRIGGER views_updated_at BEFORE UPDATE ON views FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,20 @@
+CREATE TABLE time_entries (
+    id UUID NOT NULL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ NULL,
+    duration_seconds BIGINT NOT NULL DEFAULT 0,
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Each user has at most one running timer
+CREATE UNIQUE INDEX time_entries_running_unique ON time_entries (user_id) WHERE ended_at IS NULL;
+
+CREATE INDEX time_entries_task_index ON time_entries (task_id, started_at);
+
+CREATE INDEX time_entries_user_index ON time_entries (user_id, started_at);
+
+CREATE TRIGGER time_entries_updated_at BEFORE UPDATE ON time_entries FOR EACH ROW EXECUTE FUNCTION set_updated_at();
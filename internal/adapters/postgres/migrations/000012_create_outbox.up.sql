@@ -0,0 +1,20 @@
+CREATE TABLE outbox (
+    id UUID NOT NULL PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    task_id UUID NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMPTZ NULL,
+    locked_until TIMESTAMPTZ NULL,
+    published_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX outbox_status_index ON outbox (status, next_attempt_at);
+
+CREATE INDEX outbox_published_index ON outbox (published_at);
+
+CREATE TRIGGER outbox_updated_at BEFORE UPDATE ON outbox FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,10 @@
+CREATE TABLE task_events (
+    id UUID NOT NULL PRIMARY KEY,
+    task_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    actor_id UUID NULL,
+    data TEXT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (task_id, version)
+);
@@ -0,0 +1 @@
+-- Nothing to do, see the up migration.
@@ -0,0 +1,20 @@
+ALTER TABLE tasks ADD COLUMN assignee_id UUID NULL REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE TABLE task_followers (
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX task_followers_user_index ON task_followers (user_id);
+
+CREATE TABLE task_comments (
+    id UUID NOT NULL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX task_comments_task_index ON task_comments (task_id, created_at);
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const notificationColumns = "id, user_id, task_id, type, title, body, read_at, created_at"
+
+type NotificationPostgresRepository struct {
+	db *database.DB
+}
+
+func NewNotificationPostgresRepository(db *database.DB) repositories.NotificationRepository {
+	return &NotificationPostgresRepository{
+		db: db,
+	}
+}
+
+func (n *NotificationPostgresRepository) Create(ctx context.Context, notification *models.Notification) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = n.db.ExecContext(ctx, "INSERT INTO notifications (id, user_id, task_id, type, title, body) VALUES ($1, $2, $3, $4, $5, $6)", id, notification.UserID, notification.TaskID, notification.Type, notification.Title, notification.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (n *NotificationPostgresRepository) FindByID(ctx context.Context, notificationID string) (*models.Notification, error) {
+	if !isUUID(notificationID) {
+		return nil, nil
+	}
+
+	var notification models.Notification
+	err := n.db.GetContext(ctx, &notification, "SELECT "+notificationColumns+" FROM notifications WHERE id = $1", notificationID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+func (n *NotificationPostgresRepository) FindByUserID(ctx context.Context, userID string, unreadOnly bool, limit int, offset int) ([]models.Notification, error) {
+	notifications := []models.Notification{}
+	err := n.db.SelectContext(ctx, &notifications, "SELECT "+notificationColumns+" FROM notifications WHERE user_id = $1 AND (NOT $2 OR notifications.read_at IS NULL) ORDER BY notifications.created_at DESC, id DESC LIMIT $3 OFFSET $4", userID, unreadOnly, limit, offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (n *NotificationPostgresRepository) CountByUserID(ctx context.Context, userID string, unreadOnly bool) (int, error) {
+	var count int
+	err := n.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)", userID, unreadOnly)
+
+	return count, err
+}
+
+func (n *NotificationPostgresRepository) MarkReadByID(ctx context.Context, notificationID string, readAt time.Time) error {
+	_, err := n.db.ExecContext(ctx, "UPDATE notifications SET read_at = $1 WHERE id = $2 AND read_at IS NULL", readAt, notificationID)
+
+	return err
+}
+
+func (n *NotificationPostgresRepository) MarkAllReadByUserID(ctx context.Context, userID string, readAt time.Time) (int, error) {
+	result, err := n.db.ExecContext(ctx, "UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL", readAt, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return int(affected), err
+}
+
+func (n *NotificationPostgresRepository) FindPreferencesByUserID(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := n.db.SelectContext(ctx, &preferences, "SELECT user_id, type, enabled FROM notification_preferences WHERE user_id = $1", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (n *NotificationPostgresRepository) UpsertPreference(ctx context.Context, userID string, notificationType string, enabled bool) error {
+	_, err := n.db.ExecContext(ctx, "INSERT INTO notification_preferences (user_id, type, enabled) VALUES ($1, $2, $3) ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled", userID, notificationType, enabled)
+
+	return err
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const outboxColumns = "id, event_type, task_id, payload, status, attempts, last_error, next_attempt_at, locked_until, published_at, created_at, updated_at"
+
+// OutboxPostgresRepository writes through the DB, so a message created with the
+// context of a unit of work commits or rolls back together with the task change
+type OutboxPostgresRepository struct {
+	db *database.DB
+}
+
+func NewOutboxPostgresRepository(db *database.DB) repositories.OutboxRepository {
+	return &OutboxPostgresRepository{
+		db: db,
+	}
+}
+
+func (o *OutboxPostgresRepository) Create(ctx context.Context, eventType string, taskID string, payload string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = o.db.ExecContext(ctx, "INSERT INTO outbox (id, event_type, task_id, payload, status) VALUES ($1, $2, $3, $4, $5)", id.String(), eventType, taskID, payload, models.OutboxStatusPending)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (o *OutboxPostgresRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]models.OutboxMessage, error) {
+	// Messages left in PUBLISHING by a crashed instance are picked up again once their lock expires
+	var messages []models.OutboxMessage
+	err := o.db.SelectContext(ctx, &messages, "SELECT "+outboxColumns+" FROM outbox "+
+		"WHERE (status = $1 OR (status = $2 AND locked_until < $3)) "+
+		"AND (next_attempt_at IS NULL OR next_attempt_at <= $3) "+
+		"ORDER BY id LIMIT $4",
+		models.OutboxStatusPending, models.OutboxStatusPublishing, now, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (o *OutboxPostgresRepository) Claim(ctx context.Context, messageID string, now time.Time, lockedUntil time.Time) (bool, error) {
+	// Only one instance wins the conditional update
+	result, err := o.db.ExecContext(ctx, "UPDATE outbox SET status = $1, locked_until = $2, attempts = attempts + 1 WHERE id = $3 AND (status = $4 OR (status = $1 AND locked_until < $5))", models.OutboxStatusPublishing, lockedUntil, messageID, models.OutboxStatusPending, now)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (o *OutboxPostgresRepository) MarkPublished(ctx context.Context, messageID string, publishedAt time.Time) error {
+	_, err := o.db.ExecContext(ctx, "UPDATE outbox SET status = $1, published_at = $2, last_error = NULL, locked_until = NULL WHERE id = $3", models.OutboxStatusPublished, publishedAt, messageID)
+
+	return err
+}
+
+func (o *OutboxPostgresRepository) Reschedule(ctx context.Context, messageID string, lastError string, nextAttemptAt time.Time) error {
+	_, err := o.db.ExecContext(ctx, "UPDATE outbox SET status = $1, last_error = $2, next_attempt_at = $3, locked_until = NULL WHERE id = $4", models.OutboxStatusPending, lastError, nextAttemptAt, messageID)
+
+	return err
+}
+
+func (o *OutboxPostgresRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := o.db.ExecContext(ctx, "DELETE FROM outbox WHERE status = $1 AND published_at < $2", models.OutboxStatusPublished, before)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+
+	return int(deleted), err
+}
@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqltask"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// taskDialect rebinds to $n placeholders, Postgres escapes LIKE with a
+// backslash and compares timestamptz columns with time arguments
+var taskDialect = sqltask.Dialect{
+	BindType: sqlx.DOLLAR,
+	AddTag:   "INSERT INTO task_tags (task_id, tag) VALUES (?, ?) ON CONFLICT DO NOTHING",
+	ValidID:  isUUID,
+}
+
+// isUUID guards lookups by id, Postgres rejects malformed UUIDs instead of
+// finding nothing
+func isUUID(value string) bool {
+	_, err := uuid.Parse(value)
+
+	return err == nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
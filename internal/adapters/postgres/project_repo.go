@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const projectColumns = "id, user_id, name, created_at, updated_at"
+
+type ProjectPostgresRepository struct {
+	db *database.DB
+}
+
+func NewProjectPostgresRepository(db *database.DB) repositories.ProjectRepository {
+	return &ProjectPostgresRepository{
+		db: db,
+	}
+}
+
+func (p *ProjectPostgresRepository) Create(ctx context.Context, req *requests.ProjectCreateRequest, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = p.db.ExecContext(ctx, "INSERT INTO projects (id, user_id, name) VALUES ($1, $2, $3)", id, userID, req.Name)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (p *ProjectPostgresRepository) FindByID(ctx context.Context, projectID string) (*models.Project, error) {
+	if !isUUID(projectID) {
+		return nil, nil
+	}
+
+	return p.findOne(ctx, "SELECT "+projectColumns+" FROM projects WHERE id = $1", projectID)
+}
+
+func (p *ProjectPostgresRepository) FindByUserID(ctx context.Context, userID string) ([]models.Project, error) {
+	projects := []models.Project{}
+	err := p.db.SelectContext(ctx, &projects, "SELECT "+projectColumns+" FROM projects WHERE user_id = $1 ORDER BY name", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (p *ProjectPostgresRepository) FindByName(ctx context.Context, userID string, name string) (*models.Project, error) {
+	return p.findOne(ctx, "SELECT "+projectColumns+" FROM projects WHERE user_id = $1 AND name = $2", userID, name)
+}
+
+func (p *ProjectPostgresRepository) DeleteByID(ctx context.Context, projectID string) error {
+	// Tasks in the project are kept and become unassigned
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET project_id = NULL WHERE project_id = $1", projectID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM projects WHERE id = $1", projectID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *ProjectPostgresRepository) findOne(ctx context.Context, statement string, args ...any) (*models.Project, error) {
+	var project models.Project
+	err := p.db.GetContext(ctx, &project, statement, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const reminderColumns = "r.id, r.task_id, r.user_id, r.remind_at, r.offset_minutes, r.channel, r.target, r.status, r.attempts, r.last_error, r.next_attempt_at, r.locked_until, r.sent_at, r.created_at, r.updated_at"
+
+type ReminderPostgresRepository struct {
+	db *database.DB
+}
+
+func NewReminderPostgresRepository(db *database.DB) repositories.ReminderRepository {
+	return &ReminderPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *ReminderPostgresRepository) Create(ctx context.Context, req *requests.ReminderCreateRequest, taskID string, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = r.db.ExecContext(ctx, "INSERT INTO reminders (id, task_id, user_id, remind_at, offset_minutes, channel, target, status) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", id, taskID, userID, req.RemindAt, req.OffsetMinutes, req.Channel, nullString(req.Target), models.ReminderStatusPending)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (r *ReminderPostgresRepository) FindByID(ctx context.Context, reminderID string) (*models.Reminder, error) {
+	if !isUUID(reminderID) {
+		return nil, nil
+	}
+
+	var reminder models.Reminder
+	err := r.db.GetContext(ctx, &reminder, "SELECT "+reminderColumns+" FROM reminders r WHERE r.id = $1", reminderID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reminder, nil
+}
+
+func (r *ReminderPostgresRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.Reminder, error) {
+	reminders := []models.Reminder{}
+	err := r.db.SelectContext(ctx, &reminders, "SELECT "+reminderColumns+" FROM reminders r WHERE r.task_id = $1 ORDER BY r.created_at", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (r *ReminderPostgresRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error) {
+	// Offset reminders follow the current due date of their task, and reminders
+	// left in SENDING by a crashed instance are picked up again once their lock expires
+	var reminders []models.Reminder
+	err := r.db.SelectContext(ctx, &reminders, "SELECT "+reminderColumns+" FROM reminders r JOIN tasks t ON t.id = r.task_id "+
+		"WHERE t.status <> $1 "+
+		"AND (r.status = $2 OR (r.status = $3 AND r.locked_until < $4)) "+
+		"AND (r.next_attempt_at IS NULL OR r.next_attempt_at <= $4) "+
+		"AND COALESCE(r.remind_at, t.due_date - make_interval(mins => r.offset_minutes)) <= $4 "+
+		"ORDER BY r.created_at LIMIT $5",
+		models.TaskStatusCompleted, models.ReminderStatusPending, models.ReminderStatusSending, now, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (r *ReminderPostgresRepository) DeleteByID(ctx context.Context, reminderID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM reminders WHERE id = $1", reminderID)
+
+	return err
+}
+
+func (r *ReminderPostgresRepository) Claim(ctx context.Context, reminderID string, now time.Time, lockedUntil time.Time) (bool, error) {
+	// Only one instance wins the conditional update
+	result, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = $1, locked_until = $2, attempts = attempts + 1 WHERE id = $3 AND (status = $4 OR (status = $1 AND locked_until < $5))", models.ReminderStatusSending, lockedUntil, reminderID, models.ReminderStatusPending, now)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (r *ReminderPostgresRepository) MarkSent(ctx context.Context, reminderID string, sentAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = $1, sent_at = $2, locked_until = NULL, last_error = NULL WHERE id = $3", models.ReminderStatusSent, sentAt, reminderID)
+
+	return err
+}
+
+func (r *ReminderPostgresRepository) Reschedule(ctx context.Context, reminderID string, lastError string, nextAttemptAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = $1, last_error = $2, next_attempt_at = $3, locked_until = NULL WHERE id = $4", models.ReminderStatusPending, lastError, nextAttemptAt, reminderID)
+
+	return err
+}
+
+func (r *ReminderPostgresRepository) MarkFailed(ctx context.Context, reminderID string, lastError string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = $1, last_error = $2, locked_until = NULL WHERE id = $3", models.ReminderStatusFailed, lastError, reminderID)
+
+	return err
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+type ReportingPostgresRepository struct {
+	db *database.DB
+}
+
+func NewReportingPostgresRepository(db *database.DB) repositories.ReportingRepository {
+	return &ReportingPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *ReportingPostgresRepository) CountByPeriod(ctx context.Context, userID string, from time.Time, to time.Time, interval string) ([]models.StatsPeriod, error) {
+	created := periodExpression("created_at", interval, "$5")
+	completed := periodExpression("changed_at", interval, "$5")
+
+	periods := []models.StatsPeriod{}
+	err := r.db.SelectContext(ctx, &periods, fmt.Sprintf(`SELECT period, SUM(created) AS created, SUM(completed) AS completed FROM (
+		SELECT %s AS period, 1 AS created, 0 AS completed FROM tasks WHERE user_id = $1 AND created_at >= $2 AND created_at < $3
+		UNION ALL
+		SELECT %s AS period, 0 AS created, 1 AS completed FROM task_status_history WHERE user_id = $1 AND to_status = $4 AND changed_at >= $2 AND changed_at < $3
+	) counts GROUP BY period ORDER BY period`, created, completed),
+		userID, from.UTC(), to.UTC(), models.TaskStatusCompleted, from.Location().String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (r *ReportingPostgresRepository) AverageCycleTime(ctx context.Context, userID string, from time.Time, to time.Time) (*models.CycleTime, error) {
+	// A task reopened and completed again counts from its first completion
+	var cycleTime models.CycleTime
+	err := r.db.GetContext(ctx, &cycleTime, `SELECT COALESCE(AVG(EXTRACT(EPOCH FROM (h.completed_at - t.created_at))), 0)::float8 AS average_seconds, COUNT(*) AS tasks
+		FROM tasks t
+		JOIN (SELECT task_id, MIN(changed_at) AS completed_at FROM task_status_history WHERE user_id = $1 AND to_status = $2 GROUP BY task_id) h ON h.task_id = t.id
+		WHERE h.completed_at >= $3 AND h.completed_at < $4`,
+		userID, models.TaskStatusCompleted, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycleTime, nil
+}
+
+func (r *ReportingPostgresRepository) CountOpenByPriority(ctx context.Context, userID string) ([]models.PriorityCount, error) {
+	counts := []models.PriorityCount{}
+	err := r.db.SelectContext(ctx, &counts, "SELECT priority, COUNT(*) AS count FROM tasks WHERE user_id = $1 AND status <> $2 GROUP BY priority ORDER BY priority DESC", userID, models.TaskStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *ReportingPostgresRepository) CountOverdue(ctx context.Context, userID string, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM tasks WHERE user_id = $1 AND status <> $2 AND due_date IS NOT NULL AND due_date < $3", userID, models.TaskStatusCompleted, now.UTC())
+
+	return count, err
+}
+
+// periodExpression buckets a timestamptz column by day or by ISO week (Monday)
+// in the time zone bound to the timezone placeholder
+func periodExpression(column string, interval string, timezone string) string {
+	if interval == models.StatsIntervalWeek {
+		return fmt.Sprintf("to_char(date_trunc('week', %s AT TIME ZONE %s), 'YYYY-MM-DD')", column, timezone)
+	}
+
+	return fmt.Sprintf("to_char(%s AT TIME ZONE %s, 'YYYY-MM-DD')", column, timezone)
+}
@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+)
+
+func (t *TaskPostgresRepository) FindByIDs(ctx context.Context, taskIDs []string) ([]models.Task, error) {
+	return taskDialect.FindByIDs(ctx, t.db, taskColumns, taskIDs)
+}
+
+func (t *TaskPostgresRepository) ApplyBulk(ctx context.Context, operations []requests.TaskBulkOperation, atomic bool) ([]error, error) {
+	return taskDialect.ApplyBulk(ctx, t.db, operations, atomic)
+}
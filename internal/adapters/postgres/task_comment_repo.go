@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const taskCommentColumns = "id, task_id, user_id, body, created_at"
+
+type TaskCommentPostgresRepository struct {
+	db *database.DB
+}
+
+func NewTaskCommentPostgresRepository(db *database.DB) repositories.TaskCommentRepository {
+	return &TaskCommentPostgresRepository{
+		db: db,
+	}
+}
+
+func (t *TaskCommentPostgresRepository) Create(ctx context.Context, taskID string, userID string, body string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = t.db.ExecContext(ctx, "INSERT INTO task_comments (id, task_id, user_id, body) VALUES ($1, $2, $3, $4)", id, taskID, userID, body)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TaskCommentPostgresRepository) FindByID(ctx context.Context, commentID string) (*models.TaskComment, error) {
+	if !isUUID(commentID) {
+		return nil, nil
+	}
+
+	var comment models.TaskComment
+	err := t.db.GetContext(ctx, &comment, "SELECT "+taskCommentColumns+" FROM task_comments WHERE id = $1", commentID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (t *TaskCommentPostgresRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskComment, error) {
+	comments := []models.TaskComment{}
+	err := t.db.SelectContext(ctx, &comments, "SELECT "+taskCommentColumns+" FROM task_comments WHERE task_id = $1 ORDER BY created_at, id", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+type TaskFollowerPostgresRepository struct {
+	db *database.DB
+}
+
+func NewTaskFollowerPostgresRepository(db *database.DB) repositories.TaskFollowerRepository {
+	return &TaskFollowerPostgresRepository{
+		db: db,
+	}
+}
+
+func (t *TaskFollowerPostgresRepository) Create(ctx context.Context, taskID string, userID string) error {
+	// Following twice keeps the first row
+	_, err := t.db.ExecContext(ctx, "INSERT INTO task_followers (task_id, user_id) VALUES ($1, $2) ON CONFLICT (task_id, user_id) DO NOTHING", taskID, userID)
+
+	return err
+}
+
+func (t *TaskFollowerPostgresRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskFollower, error) {
+	followers := []models.TaskFollower{}
+	err := t.db.SelectContext(ctx, &followers, "SELECT task_id, user_id, created_at FROM task_followers WHERE task_id = $1 ORDER BY created_at, user_id", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return followers, nil
+}
+
+func (t *TaskFollowerPostgresRepository) DeleteByTaskIDAndUserID(ctx context.Context, taskID string, userID string) error {
+	_, err := t.db.ExecContext(ctx, "DELETE FROM task_followers WHERE task_id = $1 AND user_id = $2", taskID, userID)
+
+	return err
+}
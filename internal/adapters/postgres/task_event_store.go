@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const taskEventColumns = "id, task_id, version, event_type, actor_id, data, occurred_at"
+
+// TaskEventPostgresStore keeps the streams in one table, the unique task and
+// version key stops two writers from appending the same version
+type TaskEventPostgresStore struct {
+	db *database.DB
+}
+
+func NewTaskEventPostgresStore(db *database.DB) repositories.TaskEventStore {
+	return &TaskEventPostgresStore{
+		db: db,
+	}
+}
+
+func (t *TaskEventPostgresStore) Append(ctx context.Context, event *models.TaskStreamEvent) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	_, err = t.db.ExecContext(ctx, "INSERT INTO task_events (id, task_id, version, event_type, actor_id, data, occurred_at) VALUES ($1, $2, $3, $4, $5, $6, $7)", id.String(), event.TaskID, event.Version, event.Type, event.ActorID, event.Data, event.OccurredAt)
+	if err != nil {
+		return err
+	}
+
+	event.ID = id.String()
+
+	return nil
+}
+
+func (t *TaskEventPostgresStore) FindByTaskID(ctx context.Context, taskID string) ([]models.TaskStreamEvent, error) {
+	events := []models.TaskStreamEvent{}
+	if !isUUID(taskID) {
+		return events, nil
+	}
+
+	err := t.db.SelectContext(ctx, &events, "SELECT "+taskEventColumns+" FROM task_events WHERE task_id = $1 ORDER BY version", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (t *TaskEventPostgresStore) FindTaskIDsAfter(ctx context.Context, afterTaskID string, limit int) ([]string, error) {
+	// The first page starts after the nil UUID
+	if afterTaskID == "" {
+		afterTaskID = uuid.Nil.String()
+	}
+
+	taskIDs := []string{}
+	err := t.db.SelectContext(ctx, &taskIDs, "SELECT DISTINCT task_id FROM task_events WHERE task_id > $1 ORDER BY task_id LIMIT $2", afterTaskID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return taskIDs, nil
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/search"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+const snippetLength = 160
+
+type taskSearchRow struct {
+	models.Task
+	Score float64 `db:"score"`
+}
+
+// TaskPostgresSearchIndex reads the generated tasks.search_vector column,
+// which Postgres keeps up to date with every write to the table
+type TaskPostgresSearchIndex struct {
+	db *database.DB
+}
+
+func NewTaskPostgresSearchIndex(db *database.DB) repositories.TaskSearchIndex {
+	return &TaskPostgresSearchIndex{
+		db: db,
+	}
+}
+
+func (t *TaskPostgresSearchIndex) Index(ctx context.Context, task *models.Task) error {
+	return nil
+}
+
+func (t *TaskPostgresSearchIndex) Remove(ctx context.Context, taskID string) error {
+	return nil
+}
+
+func (t *TaskPostgresSearchIndex) Search(ctx context.Context, userID string, query string, limit int) ([]models.TaskSearchResult, error) {
+	terms := search.Tokenize(query)
+	if len(terms) == 0 {
+		return []models.TaskSearchResult{}, nil
+	}
+
+	// Any term may match as a prefix, title matches are weighted higher by the vector
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+	tsquery := strings.Join(prefixes, " | ")
+
+	var rows []taskSearchRow
+	err := t.db.SelectContext(ctx, &rows, "SELECT "+taskColumns+", "+
+		"ts_rank(search_vector, to_tsquery('simple', $1))::float8 AS score "+
+		"FROM tasks WHERE user_id = $2 AND search_vector @@ to_tsquery('simple', $1) "+
+		"ORDER BY score DESC LIMIT $3",
+		tsquery, userID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, len(rows))
+	for i := range rows {
+		tasks[i] = &rows[i].Task
+	}
+
+	if err := taskDialect.LoadTags(ctx, t.db, tasks); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.TaskSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, models.TaskSearchResult{
+			Task:           row.Task,
+			Score:          row.Score,
+			TitleHighlight: search.Highlight(row.Title, terms, 0),
+			Snippet:        search.Highlight(row.Description, terms, snippetLength),
+		})
+	}
+
+	return results, nil
+}
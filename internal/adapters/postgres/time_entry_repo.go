@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation
+const uniqueViolation = "23505"
+
+const timeEntryColumns = "id, task_id, user_id, started_at, ended_at, duration_seconds, note, created_at, updated_at"
+
+type TimeEntryPostgresRepository struct {
+	db *database.DB
+}
+
+func NewTimeEntryPostgresRepository(db *database.DB) repositories.TimeEntryRepository {
+	return &TimeEntryPostgresRepository{
+		db: db,
+	}
+}
+
+func (t *TimeEntryPostgresRepository) Create(ctx context.Context, req *requests.TimeEntryCreateRequest, taskID string, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	duration := int64(req.EndedAt.Sub(*req.StartedAt) / time.Second)
+
+	_, err = t.db.ExecContext(ctx, "INSERT INTO time_entries (id, task_id, user_id, started_at, ended_at, duration_seconds, note) VALUES ($1, $2, $3, $4, $5, $6, $7)", id, taskID, userID, req.StartedAt.UTC(), req.EndedAt.UTC(), duration, req.Note)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TimeEntryPostgresRepository) Start(ctx context.Context, taskID string, userID string, note string, startedAt time.Time) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	// The unique index on running timers settles two starts racing each other
+	_, err = t.db.ExecContext(ctx, "INSERT INTO time_entries (id, task_id, user_id, started_at, duration_seconds, note) VALUES ($1, $2, $3, $4, 0, $5)", id, taskID, userID, startedAt.UTC(), note)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "time_entries_running_unique" {
+		return "", exceptions.ErrTimerAlreadyRunning
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TimeEntryPostgresRepository) Stop(ctx context.Context, entryID string, endedAt time.Time) error {
+	_, err := t.db.ExecContext(ctx, "UPDATE time_entries SET ended_at = $1, duration_seconds = GREATEST(EXTRACT(EPOCH FROM ($1 - started_at))::bigint, 0) WHERE id = $2 AND ended_at IS NULL", endedAt.UTC(), entryID)
+
+	return err
+}
+
+func (t *TimeEntryPostgresRepository) FindByID(ctx context.Context, entryID string) (*models.TimeEntry, error) {
+	if !isUUID(entryID) {
+		return nil, nil
+	}
+
+	var entry models.TimeEntry
+	err := t.db.GetContext(ctx, &entry, "SELECT "+timeEntryColumns+" FROM time_entries WHERE id = $1", entryID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (t *TimeEntryPostgresRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TimeEntry, error) {
+	entries := []models.TimeEntry{}
+	err := t.db.SelectContext(ctx, &entries, "SELECT "+timeEntryColumns+" FROM time_entries WHERE task_id = $1 ORDER BY time_entries.started_at", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (t *TimeEntryPostgresRepository) FindRunningByUserID(ctx context.Context, userID string) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := t.db.GetContext(ctx, &entry, "SELECT "+timeEntryColumns+" FROM time_entries WHERE user_id = $1 AND ended_at IS NULL", userID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (t *TimeEntryPostgresRepository) DeleteByID(ctx context.Context, entryID string) error {
+	_, err := t.db.ExecContext(ctx, "DELETE FROM time_entries WHERE id = $1", entryID)
+
+	return err
+}
+
+func (t *TimeEntryPostgresRepository) Timesheet(ctx context.Context, userID string, from time.Time, to time.Time, groupBy string) ([]models.TimesheetRow, error) {
+	// Only finished entries count, grouped by the day they started on in from's location
+	var groupKey, joins string
+	args := []any{userID, from.UTC(), to.UTC()}
+	switch groupBy {
+	case models.TimesheetGroupByDay:
+		groupKey = "to_char(e.started_at AT TIME ZONE $4, 'YYYY-MM-DD')"
+		args = append(args, from.Location().String())
+	case models.TimesheetGroupByProject:
+		groupKey = "COALESCE(p.name, '')"
+		joins = "JOIN tasks t ON t.id = e.task_id LEFT JOIN projects p ON p.id = t.project_id"
+	case models.TimesheetGroupByTag:
+		groupKey = "COALESCE(tt.tag, '')"
+		joins = "LEFT JOIN task_tags tt ON tt.task_id = e.task_id"
+	default:
+		return nil, fmt.Errorf("unknown timesheet group %q", groupBy)
+	}
+
+	statement := "SELECT " + groupKey + " AS group_key, SUM(e.duration_seconds)::bigint AS seconds, COUNT(*) AS entries FROM time_entries e " + joins + " WHERE e.user_id = $1 AND e.ended_at IS NOT NULL AND e.started_at >= $2 AND e.started_at < $3 GROUP BY group_key ORDER BY group_key"
+
+	rows := []models.TimesheetRow{}
+	err := t.db.SelectContext(ctx, &rows, statement, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const userColumns = "id, name, email, password, calendar_token, timezone, created_at, updated_at"
+
+type UserPostgresRepository struct {
+	db *database.DB
+}
+
+func NewUserPostgresRepository(db *database.DB) repositories.UserRepository {
+	return &UserPostgresRepository{
+		db: db,
+	}
+}
+
+func (u *UserPostgresRepository) Create(ctx context.Context, req *requests.UserRegisterRequest) error {
+	// Generate UUID
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	_, err = u.db.ExecContext(ctx, "INSERT INTO users (id, name, email, password) VALUES ($1, $2, $3, $4)", id, req.Name, req.Email, req.Password)
+
+	return err
+}
+
+func (u *UserPostgresRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	if !isUUID(userID) {
+		return nil, nil
+	}
+
+	return u.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1", userID)
+}
+
+func (u *UserPostgresRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return u.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE email = $1", email)
+}
+
+func (u *UserPostgresRepository) FindByCalendarToken(ctx context.Context, token string) (*models.User, error) {
+	return u.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE calendar_token = $1", token)
+}
+
+func (u *UserPostgresRepository) UpdateCalendarToken(ctx context.Context, userID string, token *string) error {
+	_, err := u.db.ExecContext(ctx, "UPDATE users SET calendar_token = $1 WHERE id = $2", token, userID)
+
+	return err
+}
+
+func (u *UserPostgresRepository) UpdateTimezone(ctx context.Context, userID string, timezone string) error {
+	_, err := u.db.ExecContext(ctx, "UPDATE users SET timezone = $1 WHERE id = $2", timezone, userID)
+
+	return err
+}
+
+func (u *UserPostgresRepository) findOne(ctx context.Context, statement string, args ...any) (*models.User, error) {
+	var user models.User
+	err := u.db.GetContext(ctx, &user, statement, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
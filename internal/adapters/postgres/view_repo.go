@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const viewColumns = "id, user_id, name, query, sort, created_at, updated_at"
+
+type ViewPostgresRepository struct {
+	db *database.DB
+}
+
+func NewViewPostgresRepository(db *database.DB) repositories.ViewRepository {
+	return &ViewPostgresRepository{
+		db: db,
+	}
+}
+
+func (v *ViewPostgresRepository) Create(ctx context.Context, req *requests.ViewCreateRequest, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = v.db.ExecContext(ctx, "INSERT INTO views (id, user_id, name, query, sort) VALUES ($1, $2, $3, $4, $5)", id, userID, req.Name, req.Query, req.Sort)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (v *ViewPostgresRepository) FindByID(ctx context.Context, viewID string) (*models.View, error) {
+	if !isUUID(viewID) {
+		return nil, nil
+	}
+
+	var view models.View
+	err := v.db.GetContext(ctx, &view, "SELECT "+viewColumns+" FROM views WHERE id = $1", viewID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+func (v *ViewPostgresRepository) FindByUserID(ctx context.Context, userID string) ([]models.View, error) {
+	views := []models.View{}
+	err := v.db.SelectContext(ctx, &views, "SELECT "+viewColumns+" FROM views WHERE user_id = $1 ORDER BY name", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (v *ViewPostgresRepository) UpdateByID(ctx context.Context, viewID string, req *requests.ViewUpdateRequest) error {
+	_, err := v.db.ExecContext(ctx, "UPDATE views SET name = $1, query = $2, sort = $3 WHERE id = $4", req.Name, req.Query, req.Sort, viewID)
+
+	return err
+}
+
+func (v *ViewPostgresRepository) DeleteByID(ctx context.Context, viewID string) error {
+	_, err := v.db.ExecContext(ctx, "DELETE FROM views WHERE id = $1", viewID)
+
+	return err
+}
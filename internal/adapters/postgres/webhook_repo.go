@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	webhookColumns         = "id, user_id, url, secret, events, active, created_at, updated_at"
+	webhookDeliveryColumns = "id, webhook_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, locked_until, delivered_at, created_at, updated_at"
+)
+
+type WebhookPostgresRepository struct {
+	db *database.DB
+}
+
+func NewWebhookPostgresRepository(db *database.DB) repositories.WebhookRepository {
+	return &WebhookPostgresRepository{
+		db: db,
+	}
+}
+
+func (w *WebhookPostgresRepository) Create(ctx context.Context, req *requests.WebhookCreateRequest, userID string, secret string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = w.db.ExecContext(ctx, "INSERT INTO webhooks (id, user_id, url, secret, events, active) VALUES ($1, $2, $3, $4, $5, TRUE)", id, userID, req.URL, secret, models.StringList(req.Events))
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (w *WebhookPostgresRepository) FindByID(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	if !isUUID(webhookID) {
+		return nil, nil
+	}
+
+	var webhook models.Webhook
+	err := w.db.GetContext(ctx, &webhook, "SELECT "+webhookColumns+" FROM webhooks WHERE id = $1", webhookID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *WebhookPostgresRepository) FindByUserID(ctx context.Context, userID string) ([]models.Webhook, error) {
+	webhooks := []models.Webhook{}
+	err := w.db.SelectContext(ctx, &webhooks, "SELECT "+webhookColumns+" FROM webhooks WHERE user_id = $1 ORDER BY webhooks.created_at", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookPostgresRepository) FindActiveByUserIDAndEvent(ctx context.Context, userID string, eventType string) ([]models.Webhook, error) {
+	// Events are stored as a comma separated list
+	var webhooks []models.Webhook
+	err := w.db.SelectContext(ctx, &webhooks, "SELECT "+webhookColumns+" FROM webhooks WHERE user_id = $1 AND active = TRUE AND $2 = ANY(string_to_array(events, ','))", userID, eventType)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookPostgresRepository) DeleteByID(ctx context.Context, webhookID string) error {
+	_, err := w.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = $1", webhookID)
+
+	return err
+}
+
+func (w *WebhookPostgresRepository) CreateDelivery(ctx context.Context, webhookID string, eventType string, payload string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = w.db.ExecContext(ctx, "INSERT INTO webhook_deliveries (id, webhook_id, event_type, payload, status) VALUES ($1, $2, $3, $4, $5)", id, webhookID, eventType, payload, models.WebhookDeliveryStatusPending)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (w *WebhookPostgresRepository) FindDeliveryByID(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	if !isUUID(deliveryID) {
+		return nil, nil
+	}
+
+	var delivery models.WebhookDelivery
+	err := w.db.GetContext(ctx, &delivery, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE id = $1", deliveryID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (w *WebhookPostgresRepository) FindDeliveriesByWebhookID(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	deliveries := []models.WebhookDelivery{}
+	err := w.db.SelectContext(ctx, &deliveries, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY webhook_deliveries.created_at DESC, id DESC LIMIT $2", webhookID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookPostgresRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	// Deliveries left in SENDING by a crashed instance are picked up again once their lock expires
+	var deliveries []models.WebhookDelivery
+	err := w.db.SelectContext(ctx, &deliveries, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries "+
+		"WHERE (status = $1 OR (status = $2 AND locked_until < $3)) "+
+		"AND (next_attempt_at IS NULL OR next_attempt_at <= $3) "+
+		"ORDER BY webhook_deliveries.created_at LIMIT $4",
+		models.WebhookDeliveryStatusPending, models.WebhookDeliveryStatusSending, now, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookPostgresRepository) ClaimDelivery(ctx context.Context, deliveryID string, now time.Time, lockedUntil time.Time) (bool, error) {
+	// Only one instance wins the conditional update
+	result, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = $1, locked_until = $2, attempts = attempts + 1 WHERE id = $3 AND (status = $4 OR (status = $1 AND locked_until < $5))", models.WebhookDeliveryStatusSending, lockedUntil, deliveryID, models.WebhookDeliveryStatusPending, now)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (w *WebhookPostgresRepository) MarkDeliverySucceeded(ctx context.Context, deliveryID string, responseStatus int, deliveredAt time.Time) error {
+	_, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = $1, response_status = $2, delivered_at = $3, last_error = NULL, locked_until = NULL WHERE id = $4", models.WebhookDeliveryStatusSucceeded, responseStatus, deliveredAt, deliveryID)
+
+	return err
+}
+
+func (w *WebhookPostgresRepository) RescheduleDelivery(ctx context.Context, deliveryID string, responseStatus *int, lastError string, nextAttemptAt time.Time) error {
+	_, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = $1, response_status = $2, last_error = $3, next_attempt_at = $4, locked_until = NULL WHERE id = $5", models.WebhookDeliveryStatusPending, responseStatus, lastError, nextAttemptAt, deliveryID)
+
+	return err
+}
+
+func (w *WebhookPostgresRepository) MarkDeliveryFailed(ctx context.Context, deliveryID string, responseStatus *int, lastError string) error {
+	_, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = $1, response_status = $2, last_error = $3, locked_until = NULL WHERE id = $4", models.WebhookDeliveryStatusFailed, responseStatus, lastError, deliveryID)
+
+	return err
+}
@@ -0,0 +1,52 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+const httpTimeout = 10 * time.Second
+
+// HTTPPublisher posts each message to a single endpoint, any 2xx response
+// counts as accepted
+type HTTPPublisher struct {
+	client *http.Client
+	url    string
+}
+
+func NewHTTPPublisher(url string) events.Publisher {
+	return &HTTPPublisher{
+		client: &http.Client{Timeout: httpTimeout},
+		url:    url,
+	}
+}
+
+func (h *HTTPPublisher) Publish(ctx context.Context, message *models.OutboxMessage) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, strings.NewReader(message.Payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, message.ID)
+	req.Header.Set(EventTypeHeader, message.EventType)
+	req.Header.Set(TaskIDHeader, message.TaskID)
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
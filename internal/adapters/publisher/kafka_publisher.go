@@ -0,0 +1,32 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher writes messages keyed by task id, so the events of one task
+// land on one partition in the order they were relayed
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(writer *kafka.Writer) events.Publisher {
+	return &KafkaPublisher{
+		writer: writer,
+	}
+}
+
+func (k *KafkaPublisher) Publish(ctx context.Context, message *models.OutboxMessage) error {
+	return k.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(message.TaskID),
+		Value: []byte(message.Payload),
+		Headers: []kafka.Header{
+			{Key: IdempotencyKeyHeader, Value: []byte(message.ID)},
+			{Key: EventTypeHeader, Value: []byte(message.EventType)},
+		},
+	})
+}
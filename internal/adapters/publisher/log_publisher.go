@@ -0,0 +1,23 @@
+package publisher
+
+import (
+	"context"
+	"log"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// LogPublisher writes messages to the log, for development and as a default
+// when no other system consumes the events
+type LogPublisher struct{}
+
+func NewLogPublisher() events.Publisher {
+	return &LogPublisher{}
+}
+
+func (l *LogPublisher) Publish(ctx context.Context, message *models.OutboxMessage) error {
+	log.Printf("📤 Published %s %s for task %s: %s\n", message.EventType, message.ID, message.TaskID, message.Payload)
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSPublisher publishes to a JetStream stream on <subject>.<event type>. The
+// message id doubles as the Nats-Msg-Id, so the stream drops a repeat that
+// arrives within its duplicate window.
+type NATSPublisher struct {
+	js      jetstream.JetStream
+	subject string
+}
+
+func NewNATSPublisher(js jetstream.JetStream, subject string) events.Publisher {
+	return &NATSPublisher{
+		js:      js,
+		subject: subject,
+	}
+}
+
+func (n *NATSPublisher) Publish(ctx context.Context, message *models.OutboxMessage) error {
+	msg := nats.NewMsg(n.subject + "." + message.EventType)
+	msg.Data = []byte(message.Payload)
+	msg.Header.Set(IdempotencyKeyHeader, message.ID)
+	msg.Header.Set(EventTypeHeader, message.EventType)
+	msg.Header.Set(TaskIDHeader, message.TaskID)
+
+	// PublishMsg waits for the stream to acknowledge the message
+	_, err := n.js.PublishMsg(ctx, msg, jetstream.WithMsgID(message.ID))
+
+	return err
+}
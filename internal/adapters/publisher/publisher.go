@@ -0,0 +1,9 @@
+package publisher
+
+// Every publisher sends the message id under IdempotencyKeyHeader, consumers
+// seeing the same key twice have already handled the message
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	EventTypeHeader      = "X-Event-Type"
+	TaskIDHeader         = "X-Task-ID"
+)
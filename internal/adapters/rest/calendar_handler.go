@@ -0,0 +1,68 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/calendar"
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarHandler interface {
+	Feed(c *fiber.Ctx) error
+}
+
+type calendarHandler struct {
+	service usecases.CalendarUseCase
+}
+
+func NewCalendarHandler(service usecases.CalendarUseCase) CalendarHandler {
+	return &calendarHandler{
+		service: service,
+	}
+}
+
+func (h *calendarHandler) Feed(c *fiber.Ctx) error {
+	// Get calendar token
+	token := c.Params("token")
+
+	// Parse query
+	req := new(requests.CalendarFeedRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if req.Kind == "" {
+		req.Kind = calendar.KindTodo
+	}
+
+	// Get tasks of the feed owner
+	user, tasks, err := h.service.FindCalendarTasks(c.Context(), token)
+	if err != nil {
+		switch err {
+		case exceptions.ErrCalendarNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Calendar not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+
+	return calendar.Encode(c.Response().BodyWriter(), user.Name+" tasks", req.Kind, tasks, time.Now())
+}
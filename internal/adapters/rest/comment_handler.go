@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CommentHandler interface {
+	CreateComment(c *fiber.Ctx) error
+	FindComments(c *fiber.Ctx) error
+}
+
+type commentHandler struct {
+	service usecases.CommentUseCase
+}
+
+func NewCommentHandler(service usecases.CommentUseCase) CommentHandler {
+	return &commentHandler{
+		service: service,
+	}
+}
+
+func (h *commentHandler) CreateComment(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Parse request
+	var req requests.TaskCommentCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Create comment
+	comment, err := h.service.CreateComment(c.Context(), taskID, &req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(comment)
+}
+
+func (h *commentHandler) FindComments(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get comments
+	comments, err := h.service.FindComments(c.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(comments)
+}
@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/internal/realtime"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	streamHeartbeat = 15 * time.Second
+	socketWriteWait = 10 * time.Second
+
+	// Sent before disconnecting a client that fell behind and missed events
+	resyncEvent = "resync"
+)
+
+type EventHandler interface {
+	Stream(c *fiber.Ctx) error
+	WebSocket(c *fiber.Ctx) error
+}
+
+type eventHandler struct {
+	hub realtime.Hub
+}
+
+func NewEventHandler(hub realtime.Hub) EventHandler {
+	return &eventHandler{
+		hub: hub,
+	}
+}
+
+func (e *eventHandler) Stream(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	events, unsubscribe := e.hub.Subscribe(userID)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+
+		// A failed flush means the client went away
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					fmt.Fprintf(w, "event: %s\ndata: {}\n\n", resyncEvent)
+					w.Flush()
+					return
+				}
+
+				if err := writeServerSentEvent(w, event); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+func (e *eventHandler) WebSocket(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	return websocket.New(func(conn *websocket.Conn) {
+		e.serveWebSocket(conn, userID)
+	})(c)
+}
+
+func (e *eventHandler) serveWebSocket(conn *websocket.Conn, userID string) {
+	events, unsubscribe := e.hub.Subscribe(userID)
+	defer unsubscribe()
+
+	// Clients only send control frames, reading detects when they disconnect
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// The connection is pooled once the handler returns, stop reading first
+	defer func() {
+		conn.Close()
+		<-closed
+	}()
+
+	ping := time.NewTicker(streamHeartbeat)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			if !ok {
+				conn.WriteJSON(fiber.Map{"type": resyncEvent})
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, resyncEvent))
+				return
+			}
+
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeServerSentEvent(w *bufio.Writer, event *models.TaskEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+
+	return err
+}
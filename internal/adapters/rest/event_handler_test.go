@@ -0,0 +1,116 @@
+package rest_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/rest"
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// closedHub hands subscribers its events followed by a closed channel, as the
+// hub does when a subscriber falls behind
+type closedHub struct {
+	events []*models.TaskEvent
+	userID string
+}
+
+func (h *closedHub) Start(ctx context.Context) error {
+	return nil
+}
+
+func (h *closedHub) Subscribe(userID string) (<-chan *models.TaskEvent, func()) {
+	h.userID = userID
+
+	events := make(chan *models.TaskEvent, len(h.events))
+	for _, event := range h.events {
+		events <- event
+	}
+	close(events)
+
+	return events, func() {}
+}
+
+func (h *closedHub) HandleTaskEvent(ctx context.Context, event *models.TaskEvent) {}
+
+func newEventApp(hub *closedHub) *fiber.App {
+	eventHandler := rest.NewEventHandler(hub)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(asUser)
+
+	app.Get("/events", eventHandler.Stream)
+	app.Get("/events/ws", eventHandler.WebSocket)
+
+	return app
+}
+
+func TestStreamSendsEventsThenResync(t *testing.T) {
+	hub := &closedHub{events: []*models.TaskEvent{{Type: models.TaskEventCreated, TaskID: "task", UserID: userID}}}
+
+	status, data := send(t, newEventApp(hub), http.MethodGet, "/events", "")
+	if status != fiber.StatusOK {
+		t.Fatalf("GET /events = %d %s, want %d", status, data, fiber.StatusOK)
+	}
+
+	if hub.userID != userID {
+		t.Errorf("subscribed as %q, want %q", hub.userID, userID)
+	}
+
+	body := string(data)
+	created := strings.Index(body, "event: task.created\ndata: {")
+	resync := strings.Index(body, "event: resync\ndata: {}\n\n")
+	if !strings.HasPrefix(body, ": connected\n\n") || created < 0 || resync < created {
+		t.Errorf("body = %q, want the created event then a resync", body)
+	}
+}
+
+func TestWebSocketSendsEventsThenResync(t *testing.T) {
+	hub := &closedHub{events: []*models.TaskEvent{{Type: models.TaskEventCreated, TaskID: "task", UserID: userID}}}
+	app := newEventApp(hub)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go app.Listener(ln)
+	t.Cleanup(func() { app.Shutdown() })
+
+	// Plain requests are asked to upgrade
+	status, _ := send(t, app, http.MethodGet, "/events/ws", "")
+	if status != fiber.StatusUpgradeRequired {
+		t.Errorf("GET /events/ws = %d, want %d", status, fiber.StatusUpgradeRequired)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s/events/ws", ln.Addr()), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	var event models.TaskEvent
+	if err := conn.ReadJSON(&event); err != nil || event.Type != models.TaskEventCreated || event.TaskID != "task" {
+		t.Errorf("ReadJSON = %+v, %v, want the created event", event, err)
+	}
+
+	var resync map[string]string
+	if err := conn.ReadJSON(&resync); err != nil || resync["type"] != "resync" {
+		t.Errorf("ReadJSON = %v, %v, want a resync", resync, err)
+	}
+
+	_, _, err = conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseTryAgainLater) {
+		t.Errorf("ReadMessage = %v, want a try again later close", err)
+	}
+
+	if hub.userID != userID {
+		t.Errorf("subscribed as %q, want %q", hub.userID, userID)
+	}
+}
@@ -0,0 +1,13 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+)
+
+type CancelWriter = cancelWriter
+
+// NewCancelWriter wraps w the way ExportTasks wraps the response stream
+func NewCancelWriter(w *bufio.Writer, cancel context.CancelFunc) *CancelWriter {
+	return &cancelWriter{writer: w, cancel: cancel}
+}
@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FollowerHandler interface {
+	AddFollower(c *fiber.Ctx) error
+	FindFollowers(c *fiber.Ctx) error
+	RemoveFollower(c *fiber.Ctx) error
+}
+
+type followerHandler struct {
+	service usecases.FollowerUseCase
+}
+
+func NewFollowerHandler(service usecases.FollowerUseCase) FollowerHandler {
+	return &followerHandler{
+		service: service,
+	}
+}
+
+func (f *followerHandler) AddFollower(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Parse request
+	var req requests.TaskFollowerCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Add follower
+	followers, err := f.service.AddFollower(c.Context(), taskID, &req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrUserNotFound:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(followers)
+}
+
+func (f *followerHandler) FindFollowers(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get followers
+	followers, err := f.service.FindFollowers(c.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(followers)
+}
+
+func (f *followerHandler) RemoveFollower(c *fiber.Ctx) error {
+	// Get task and follower ID
+	taskID := c.Params("taskID")
+	followerID := c.Params("userID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Remove follower
+	followers, err := f.service.RemoveFollower(c.Context(), taskID, followerID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(followers)
+}
@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportHandler interface {
+	ImportFromSource(c *fiber.Ctx) error
+}
+
+type importHandler struct {
+	service usecases.ImportUseCase
+}
+
+func NewImportHandler(service usecases.ImportUseCase) ImportHandler {
+	return &importHandler{
+		service: service,
+	}
+}
+
+func (i *importHandler) ImportFromSource(c *fiber.Ctx) error {
+	// Get import source
+	source := c.Params("source")
+
+	// Parse query
+	req := new(requests.TaskSourceImportRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Read the export from a multipart upload or from the raw body
+	var data io.Reader = bytes.NewReader(c.Body())
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		defer file.Close()
+
+		data = file
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Import tasks
+	result, err := i.service.ImportFromSource(c.Context(), source, req, data, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, exceptions.ErrUnsupportedImportSource):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, exceptions.ErrInvalidImport):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, exceptions.ErrImportTooLarge):
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
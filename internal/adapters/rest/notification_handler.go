@@ -0,0 +1,151 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler interface {
+	FindNotifications(c *fiber.Ctx) error
+	MarkNotificationRead(c *fiber.Ctx) error
+	MarkAllNotificationsRead(c *fiber.Ctx) error
+	FindPreferences(c *fiber.Ctx) error
+	UpdatePreferences(c *fiber.Ctx) error
+}
+
+type notificationHandler struct {
+	service usecases.NotificationUseCase
+}
+
+func NewNotificationHandler(service usecases.NotificationUseCase) NotificationHandler {
+	return &notificationHandler{
+		service: service,
+	}
+}
+
+func (n *notificationHandler) FindNotifications(c *fiber.Ctx) error {
+	// Parse query
+	req := new(requests.NotificationListRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate query
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get notifications
+	notifications, err := n.service.FindNotifications(c.Context(), req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(notifications)
+}
+
+func (n *notificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	// Get notification ID
+	notificationID := c.Params("notificationID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Mark notification as read
+	notification, err := n.service.MarkNotificationRead(c.Context(), notificationID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrNotificationNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Notification not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(notification)
+}
+
+func (n *notificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Mark all notifications as read
+	updated, err := n.service.MarkAllNotificationsRead(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"updated": updated,
+	})
+}
+
+func (n *notificationHandler) FindPreferences(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get preferences
+	preferences, err := n.service.FindPreferences(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"preferences": preferences,
+	})
+}
+
+func (n *notificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	// Parse request
+	var req *requests.NotificationPreferencesUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Update preferences
+	preferences, err := n.service.UpdatePreferences(c.Context(), req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrInvalidNotificationType:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"preferences": preferences,
+	})
+}
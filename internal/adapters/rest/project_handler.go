@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProjectHandler interface {
+	CreateProject(c *fiber.Ctx) error
+	FindProjectsByUserID(c *fiber.Ctx) error
+	DeleteProjectByID(c *fiber.Ctx) error
+}
+
+type projectHandler struct {
+	service usecases.ProjectUseCase
+}
+
+func NewProjectHandler(service usecases.ProjectUseCase) ProjectHandler {
+	return &projectHandler{
+		service: service,
+	}
+}
+
+func (p *projectHandler) CreateProject(c *fiber.Ctx) error {
+	// Parse request
+	var req *requests.ProjectCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Create project
+	project, err := p.service.CreateProject(c.Context(), req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrDuplicatedProjectName:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Project name already used",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(project)
+}
+
+func (p *projectHandler) FindProjectsByUserID(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get projects
+	projects, err := p.service.FindProjectsByUserID(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(projects)
+}
+
+func (p *projectHandler) DeleteProjectByID(c *fiber.Ctx) error {
+	// Get project ID
+	projectID := c.Params("projectID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Delete project
+	project, err := p.service.DeleteProjectByID(c.Context(), projectID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrProjectNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Project not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(project)
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReminderHandler interface {
+	CreateReminder(c *fiber.Ctx) error
+	FindRemindersByTaskID(c *fiber.Ctx) error
+	DeleteReminderByID(c *fiber.Ctx) error
+}
+
+type reminderHandler struct {
+	service usecases.ReminderUseCase
+}
+
+func NewReminderHandler(service usecases.ReminderUseCase) ReminderHandler {
+	return &reminderHandler{
+		service: service,
+	}
+}
+
+func (r *reminderHandler) CreateReminder(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Parse request
+	var req *requests.ReminderCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Create reminder
+	reminder, err := r.service.CreateReminder(c.Context(), taskID, req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrInvalidReminderTime, exceptions.ErrReminderWithoutDueDate, exceptions.ErrInvalidReminderTarget:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(reminder)
+}
+
+func (r *reminderHandler) FindRemindersByTaskID(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get reminders
+	reminders, err := r.service.FindRemindersByTaskID(c.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(reminders)
+}
+
+func (r *reminderHandler) DeleteReminderByID(c *fiber.Ctx) error {
+	// Get task and reminder ID
+	taskID := c.Params("taskID")
+	reminderID := c.Params("reminderID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Delete reminder
+	reminder, err := r.service.DeleteReminderByID(c.Context(), taskID, reminderID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrReminderNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Reminder not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(reminder)
+}
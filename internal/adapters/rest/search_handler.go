@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SearchHandler interface {
+	SearchTasks(c *fiber.Ctx) error
+}
+
+type searchHandler struct {
+	service usecases.SearchUseCase
+}
+
+func NewSearchHandler(service usecases.SearchUseCase) SearchHandler {
+	return &searchHandler{
+		service: service,
+	}
+}
+
+func (s *searchHandler) SearchTasks(c *fiber.Ctx) error {
+	// Parse query
+	req := new(requests.TaskSearchRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate query
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Search tasks
+	results, err := s.service.SearchTasks(c.Context(), req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(results)
+}
@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatsHandler interface {
+	FindStats(c *fiber.Ctx) error
+}
+
+type statsHandler struct {
+	service usecases.StatsUseCase
+}
+
+func NewStatsHandler(service usecases.StatsUseCase) StatsHandler {
+	return &statsHandler{
+		service: service,
+	}
+}
+
+func (s *statsHandler) FindStats(c *fiber.Ctx) error {
+	// Parse query
+	req := new(requests.StatsRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get stats
+	stats, err := s.service.FindStats(c.Context(), req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrInvalidStatsRange:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
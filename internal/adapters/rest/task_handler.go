@@ -1,8 +1,19 @@
 package rest
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
 	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
 	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/transfer"
 	"github.com/GraphZC/sdd-task-management/domain/usecases"
 	"github.com/GraphZC/sdd-task-management/utils"
 	"github.com/gofiber/fiber/v2"
@@ -14,7 +25,13 @@ type TaskHandler interface {
 	FindTaskByUserID(c *fiber.Ctx) error
 	DeleteTaskByID(c *fiber.Ctx) error
 	UpdateTaskByID(c *fiber.Ctx) error
+	PatchTaskByID(c *fiber.Ctx) error
 	UpdateTaskStatusByID(c *fiber.Ctx) error
+	UpdateChecklistItem(c *fiber.Ctx) error
+	AssignTask(c *fiber.Ctx) error
+	BulkUpdateTasks(c *fiber.Ctx) error
+	ExportTasks(c *fiber.Ctx) error
+	ImportTasks(c *fiber.Ctx) error
 }
 
 type taskHandler struct {
@@ -29,9 +46,9 @@ func NewTaskHandler(service usecases.TaskUseCase) TaskHandler {
 
 func (t *taskHandler) CreateTask(c *fiber.Ctx) error {
 	// Parse request
-	var req *requests.TaskCreateRequest
-	if err := c.BodyParser(&req); err != nil {
-		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+	req := new(requests.TaskCreateRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -47,9 +64,16 @@ func (t *taskHandler) CreateTask(c *fiber.Ctx) error {
 	// Create task
 	task, err := t.service.CreateTask(c.Context(), req, userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		switch err {
+		case exceptions.ErrInvalidPriority, exceptions.ErrInvalidRecurrenceRule, exceptions.ErrRecurrenceWithoutDueDate, exceptions.ErrProjectNotFound, exceptions.ErrDuplicatedExternalID:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(task)
@@ -59,11 +83,37 @@ func (t *taskHandler) FindTaskByID(c *fiber.Ctx) error {
 	// Get task ID
 	taskID := c.Params("taskID")
 
+	// Parse query
+	req := new(requests.TaskFindRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
 	// Find id from jwt
 	userID := utils.GetUserIDFromJWT(c)
 
-	// Get task
-	task, err := t.service.FindTaskByID(c.Context(), taskID, userID)
+	// Get task, as it was at the given instant when asked for one
+	var task *models.Task
+	var err error
+	if req.AsOf == "" {
+		task, err = t.service.FindTaskByID(c.Context(), taskID, userID)
+	} else {
+		asOf, parseErr := time.Parse(time.RFC3339, req.AsOf)
+		if parseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": parseErr.Error(),
+			})
+		}
+
+		task, err = t.service.FindTaskAsOf(c.Context(), taskID, asOf, userID)
+	}
 	if err != nil {
 		switch err {
 		case exceptions.ErrTaskNotFound:
@@ -81,15 +131,37 @@ func (t *taskHandler) FindTaskByID(c *fiber.Ctx) error {
 }
 
 func (t *taskHandler) FindTaskByUserID(c *fiber.Ctx) error {
+	// Parse query
+	req := new(requests.TaskListRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	// Find id from jwt
 	userID := utils.GetUserIDFromJWT(c)
 
-	// Get tasks
-	tasks, err := t.service.FindTaskByUserID(c.Context(), userID)
+	// Get tasks, filtered when a query or sort order is given
+	var tasks []models.Task
+	var err error
+	if req.Query == "" && req.Sort == "" {
+		tasks, err = t.service.FindTaskByUserID(c.Context(), userID)
+	} else {
+		tasks, err = t.service.FindTasksByQuery(c.Context(), req, userID)
+	}
+
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		switch {
+		case errors.Is(err, exceptions.ErrInvalidQuery), errors.Is(err, exceptions.ErrInvalidSort):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(tasks)
@@ -125,9 +197,9 @@ func (t *taskHandler) UpdateTaskByID(c *fiber.Ctx) error {
 	taskID := c.Params("taskID")
 
 	// Parse request
-	var req *requests.TaskUpdateRequest
-	if err := c.BodyParser(&req); err != nil {
-		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+	req := new(requests.TaskUpdateRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -148,6 +220,60 @@ func (t *taskHandler) UpdateTaskByID(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Task not found",
 			})
+		case exceptions.ErrInvalidPriority, exceptions.ErrInvalidRecurrenceRule, exceptions.ErrRecurrenceWithoutDueDate, exceptions.ErrProjectNotFound:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(task)
+}
+
+func (t *taskHandler) PatchTaskByID(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Pick the patch format from the content type
+	req := &requests.TaskPatchRequest{
+		Patch: c.Body(),
+	}
+
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	switch {
+	case strings.HasPrefix(contentType, "application/json-patch+json"):
+		req.JSONPatch = true
+	case strings.HasPrefix(contentType, "application/merge-patch+json"), strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+		req.JSONPatch = false
+	default:
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"error": "Unsupported patch format",
+		})
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Patch task
+	task, err := t.service.PatchTaskByID(c.Context(), taskID, req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, exceptions.ErrTaskNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case errors.Is(err, exceptions.ErrPatchTestFailed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, exceptions.ErrInvalidPatch), errors.Is(err, exceptions.ErrInvalidPriority), errors.Is(err, exceptions.ErrInvalidRecurrenceRule), errors.Is(err, exceptions.ErrRecurrenceWithoutDueDate), errors.Is(err, exceptions.ErrProjectNotFound):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": err.Error(),
@@ -195,3 +321,248 @@ func (t *taskHandler) UpdateTaskStatusByID(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(task)
 }
+
+func (t *taskHandler) UpdateChecklistItem(c *fiber.Ctx) error {
+	// Get task ID and checklist item index
+	taskID := c.Params("taskID")
+
+	index, err := c.ParamsInt("index")
+	if err != nil || index < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid checklist item index",
+		})
+	}
+
+	// Parse request
+	var req requests.TaskChecklistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Toggle checklist item
+	task, err := t.service.UpdateChecklistItem(c.Context(), taskID, index, &req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrChecklistItemNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Checklist item not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(task)
+}
+
+func (t *taskHandler) AssignTask(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Parse request
+	var req requests.TaskAssignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Assign task
+	task, err := t.service.AssignTask(c.Context(), taskID, &req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrUserNotFound:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(task)
+}
+
+func (t *taskHandler) BulkUpdateTasks(c *fiber.Ctx) error {
+	// Parse request
+	var req *requests.TaskBulkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Apply operations
+	result, err := t.service.BulkUpdateTasks(c.Context(), req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// An atomic batch that was rolled back changed nothing
+	if !result.Committed {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+func (t *taskHandler) ExportTasks(c *fiber.Ctx) error {
+	// Parse query
+	req := new(requests.TaskExportRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if req.Format == "" {
+		req.Format = transfer.FormatJSON
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	c.Set(fiber.HeaderContentType, transfer.ContentType(req.Format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"tasks.%s\"", req.Format))
+
+	// The fiber context is released before the body is streamed, the request
+	// context lives until the response is written
+	ctx, cancel := context.WithCancel(c.Context())
+
+	// Stream tasks, the status is already sent so errors can only be logged
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		stream := &cancelWriter{writer: w, cancel: cancel}
+		if err := t.service.ExportTasks(ctx, req.Format, stream, userID); err != nil {
+			log.Printf("export tasks: %v", err)
+			return
+		}
+
+		stream.Flush()
+	})
+
+	return nil
+}
+
+// cancelWriter cancels the export once a write or flush fails, the client has
+// gone away and there is no point reading more tasks
+type cancelWriter struct {
+	writer *bufio.Writer
+	cancel context.CancelFunc
+}
+
+func (c *cancelWriter) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	if err != nil {
+		c.cancel()
+	}
+
+	return n, err
+}
+
+func (c *cancelWriter) Flush() error {
+	err := c.writer.Flush()
+	if err != nil {
+		c.cancel()
+	}
+
+	return err
+}
+
+func (t *taskHandler) ImportTasks(c *fiber.Ctx) error {
+	// Parse query
+	req := new(requests.TaskImportRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Fall back to the content type when no format is given
+	if req.Format == "" {
+		contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+		switch {
+		case strings.HasPrefix(contentType, "text/csv"):
+			req.Format = transfer.FormatCSV
+		case strings.HasPrefix(contentType, "application/x-ndjson"):
+			req.Format = transfer.FormatNDJSON
+		default:
+			req.Format = transfer.FormatJSON
+		}
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Import tasks
+	result, err := t.service.ImportTasks(c.Context(), req, bytes.NewReader(c.Body()), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, exceptions.ErrInvalidImport):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, exceptions.ErrImportTooLarge):
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
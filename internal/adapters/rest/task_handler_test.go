@@ -0,0 +1,219 @@
+package rest_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/memory"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/rest"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const userID = "0190a6f0-0000-7000-8000-000000000001"
+
+// asUser authenticates every request as userID, as the jwt middleware would
+func asUser(c *fiber.Ctx) error {
+	c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{"id": userID}})
+	return c.Next()
+}
+
+// newTaskApp serves the task routes as userID, as the jwt middleware would
+func newTaskApp() *fiber.App {
+	taskService := usecases.NewTaskService(memory.NewTaskMemoryRepository(), memory.NewProjectMemoryRepository(), memory.NewUserMemoryRepository(), memory.NewOutboxMemoryRepository(), memory.NewTaskEventMemoryStore(), memory.NewUnitOfWork(), events.NewDispatcher())
+	taskHandler := rest.NewTaskHandler(taskService)
+
+	app := fiber.New()
+	app.Use(asUser)
+
+	app.Post("/task", taskHandler.CreateTask)
+	app.Get("/task/export", taskHandler.ExportTasks)
+	app.Get("/task/:taskID", taskHandler.FindTaskByID)
+	app.Put("/task/:taskID", taskHandler.UpdateTaskByID)
+	app.Put("/task/:taskID/checklist/:index", taskHandler.UpdateChecklistItem)
+
+	return app
+}
+
+// send makes a request against app and returns the status and body
+func send(t *testing.T, app *fiber.App, method string, target string, body string) (int, []byte) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, target, err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, target, err)
+	}
+
+	return res.StatusCode, data
+}
+
+// createTask creates a task from body and returns it
+func createTask(t *testing.T, app *fiber.App, body string) models.Task {
+	t.Helper()
+
+	status, data := send(t, app, http.MethodPost, "/task", body)
+	if status != fiber.StatusCreated {
+		t.Fatalf("POST /task = %d %s, want %d", status, data, fiber.StatusCreated)
+	}
+
+	var task models.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	return task
+}
+
+func TestCreateAndUpdateLowPriorityTask(t *testing.T) {
+	app := newTaskApp()
+
+	task := createTask(t, app, `{"title":"Task","description":"Description","priority":0}`)
+	if task.Priority != models.TaskPriorityLow {
+		t.Fatalf("Priority = %d, want %d", task.Priority, models.TaskPriorityLow)
+	}
+
+	status, data := send(t, app, http.MethodPut, "/task/"+task.ID, `{"title":"Task","description":"Description","priority":2}`)
+	if status != fiber.StatusOK {
+		t.Fatalf("PUT /task = %d %s, want %d", status, data, fiber.StatusOK)
+	}
+
+	status, data = send(t, app, http.MethodPut, "/task/"+task.ID, `{"title":"Task","description":"Description","priority":0}`)
+	if status != fiber.StatusOK {
+		t.Fatalf("PUT /task = %d %s, want %d", status, data, fiber.StatusOK)
+	}
+
+	var updated models.Task
+	if err := json.Unmarshal(data, &updated); err != nil || updated.Priority != models.TaskPriorityLow {
+		t.Errorf("PUT /task = %s, %v, want priority %d", data, err, models.TaskPriorityLow)
+	}
+}
+
+func TestCreateTaskRejectsInvalidBodies(t *testing.T) {
+	app := newTaskApp()
+
+	for _, body := range []string{
+		`{"title":"Task","description":"Description","priority":3}`,
+		`{"title":"Task","description":"Description","priority":-1}`,
+		`{"description":"Description","priority":0}`,
+		`null`,
+		`{`,
+	} {
+		if status, data := send(t, app, http.MethodPost, "/task", body); status != fiber.StatusBadRequest {
+			t.Errorf("POST /task %s = %d %s, want %d", body, status, data, fiber.StatusBadRequest)
+		}
+	}
+}
+
+func TestUpdateChecklistItemRejectsMissingChecked(t *testing.T) {
+	app := newTaskApp()
+	task := createTask(t, app, `{"title":"Task","description":"- [ ] one","priority":1}`)
+
+	for _, body := range []string{`null`, `{}`, `{"checked":null}`} {
+		if status, data := send(t, app, http.MethodPut, "/task/"+task.ID+"/checklist/0", body); status != fiber.StatusBadRequest {
+			t.Errorf("PUT checklist %s = %d %s, want %d", body, status, data, fiber.StatusBadRequest)
+		}
+	}
+
+	status, data := send(t, app, http.MethodPut, "/task/"+task.ID+"/checklist/0", `{"checked":true}`)
+	if status != fiber.StatusOK {
+		t.Fatalf("PUT checklist = %d %s, want %d", status, data, fiber.StatusOK)
+	}
+
+	var updated models.Task
+	if err := json.Unmarshal(data, &updated); err != nil || updated.Description != "- [x] one" {
+		t.Errorf("PUT checklist = %s, %v, want the item checked", data, err)
+	}
+}
+
+func TestExportTasksStreamsEveryFormat(t *testing.T) {
+	app := newTaskApp()
+	createTask(t, app, `{"title":"One","description":"Description","priority":0}`)
+	createTask(t, app, `{"title":"Two","description":"Description","priority":2}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/task/export?format=ndjson", nil)
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("GET /task/export: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK || res.Header.Get(fiber.HeaderContentType) != "application/x-ndjson" {
+		t.Fatalf("GET /task/export = %d %s, want %d ndjson", res.StatusCode, res.Header.Get(fiber.HeaderContentType), fiber.StatusOK)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 {
+		t.Errorf("ndjson export = %q, want two lines", data)
+	}
+
+	status, data := send(t, app, http.MethodGet, "/task/export", "")
+	var records []map[string]any
+	if err := json.Unmarshal(data, &records); status != fiber.StatusOK || err != nil || len(records) != 2 {
+		t.Errorf("json export = %d %s, %v, want two records", status, data, err)
+	}
+
+	status, data = send(t, app, http.MethodGet, "/task/export?format=csv", "")
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); status != fiber.StatusOK || len(lines) != 3 {
+		t.Errorf("csv export = %d %q, want a header and two rows", status, data)
+	}
+
+	if status, data := send(t, app, http.MethodGet, "/task/export?format=xml", ""); status != fiber.StatusBadRequest {
+		t.Errorf("xml export = %d %s, want %d", status, data, fiber.StatusBadRequest)
+	}
+}
+
+type brokenWriter struct{}
+
+func (brokenWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestExportCancelsWhenTheClientGoesAway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Small enough that the first record spills out of the buffer
+	w := rest.NewCancelWriter(bufio.NewWriterSize(brokenWriter{}, 16), cancel)
+	if _, err := w.Write([]byte(`{"title":"Task","description":"Description"}`)); err == nil {
+		t.Fatal("Write succeeded on a broken connection")
+	}
+
+	if ctx.Err() == nil {
+		t.Error("export context not cancelled after a failed write")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	w = rest.NewCancelWriter(bufio.NewWriter(brokenWriter{}), cancel)
+	if _, err := w.Write([]byte("{}")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if err := w.Flush(); err == nil || ctx.Err() == nil {
+		t.Errorf("Flush = %v, context %v, want an error and the export cancelled", err, ctx.Err())
+	}
+}
@@ -0,0 +1,257 @@
+package rest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TimeEntryHandler interface {
+	CreateTimeEntry(c *fiber.Ctx) error
+	FindTimeEntriesByTaskID(c *fiber.Ctx) error
+	DeleteTimeEntryByID(c *fiber.Ctx) error
+	StartTimer(c *fiber.Ctx) error
+	StopTimer(c *fiber.Ctx) error
+	Timesheet(c *fiber.Ctx) error
+}
+
+type timeEntryHandler struct {
+	service usecases.TimeEntryUseCase
+}
+
+func NewTimeEntryHandler(service usecases.TimeEntryUseCase) TimeEntryHandler {
+	return &timeEntryHandler{
+		service: service,
+	}
+}
+
+func (t *timeEntryHandler) CreateTimeEntry(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Parse request
+	var req *requests.TimeEntryCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Create time entry
+	entry, err := t.service.CreateTimeEntry(c.Context(), taskID, req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrInvalidTimeRange:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(entry)
+}
+
+func (t *timeEntryHandler) FindTimeEntriesByTaskID(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get time entries
+	entries, err := t.service.FindTimeEntriesByTaskID(c.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(entries)
+}
+
+func (t *timeEntryHandler) DeleteTimeEntryByID(c *fiber.Ctx) error {
+	// Get task and time entry ID
+	taskID := c.Params("taskID")
+	entryID := c.Params("entryID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Delete time entry
+	entry, err := t.service.DeleteTimeEntryByID(c.Context(), taskID, entryID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrTimeEntryNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Time entry not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(entry)
+}
+
+func (t *timeEntryHandler) StartTimer(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Parse request, the body is optional
+	req := new(requests.TimerStartRequest)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Start timer
+	entry, err := t.service.StartTimer(c.Context(), taskID, req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrTimerAlreadyRunning:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(entry)
+}
+
+func (t *timeEntryHandler) StopTimer(c *fiber.Ctx) error {
+	// Get task ID
+	taskID := c.Params("taskID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Stop timer
+	entry, err := t.service.StopTimer(c.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrTaskNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Task not found",
+			})
+		case exceptions.ErrTimerNotRunning:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(entry)
+}
+
+func (t *timeEntryHandler) Timesheet(c *fiber.Ctx) error {
+	// Parse query
+	req := new(requests.TimesheetRequest)
+	if err := c.QueryParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Build timesheet
+	timesheet, err := t.service.Timesheet(c.Context(), req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrInvalidTimeRange:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	if req.Format != "csv" {
+		return c.Status(fiber.StatusOK).JSON(timesheet)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"timesheet-%s-%s.csv\"", timesheet.From, timesheet.To))
+
+	w := csv.NewWriter(c.Response().BodyWriter())
+	w.Write([]string{timesheet.GroupBy, "seconds", "hours", "entries"})
+	for _, row := range timesheet.Rows {
+		w.Write([]string{
+			row.Group,
+			strconv.FormatInt(row.Seconds, 10),
+			strconv.FormatFloat(float64(row.Seconds)/3600, 'f', 2, 64),
+			strconv.Itoa(row.Entries),
+		})
+	}
+	w.Write([]string{"total", strconv.FormatInt(timesheet.TotalSeconds, 10), strconv.FormatFloat(float64(timesheet.TotalSeconds)/3600, 'f', 2, 64), ""})
+	w.Flush()
+
+	return w.Error()
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"github.com/GraphZC/sdd-task-management/domain/exceptions"
 	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/responses"
 	"github.com/GraphZC/sdd-task-management/domain/usecases"
 	"github.com/GraphZC/sdd-task-management/utils"
 	"github.com/gofiber/fiber/v2"
@@ -11,6 +12,10 @@ import (
 type UserHandler interface {
 	Register(c *fiber.Ctx) error
 	Login(c *fiber.Ctx) error
+	FindSettings(c *fiber.Ctx) error
+	UpdateSettings(c *fiber.Ctx) error
+	RegenerateCalendarToken(c *fiber.Ctx) error
+	RevokeCalendarToken(c *fiber.Ctx) error
 }
 
 type userHandler struct {
@@ -88,3 +93,79 @@ func (u *userHandler) Login(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(user)
 
 }
+
+func (u *userHandler) FindSettings(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get settings
+	settings, err := u.service.FindSettings(c.Context(), userID)
+
+	return settingsResponse(c, settings, err)
+}
+
+func (u *userHandler) UpdateSettings(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Parse request
+	var req *requests.UserSettingsUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Update settings
+	settings, err := u.service.UpdateSettings(c.Context(), req, userID)
+
+	return settingsResponse(c, settings, err)
+}
+
+func (u *userHandler) RegenerateCalendarToken(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Regenerate calendar token
+	settings, err := u.service.RegenerateCalendarToken(c.Context(), userID)
+
+	return settingsResponse(c, settings, err)
+}
+
+func (u *userHandler) RevokeCalendarToken(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Revoke calendar token
+	settings, err := u.service.RevokeCalendarToken(c.Context(), userID)
+
+	return settingsResponse(c, settings, err)
+}
+
+func settingsResponse(c *fiber.Ctx, settings *responses.UserSettingsResponse, err error) error {
+	if err != nil {
+		switch err {
+		case exceptions.ErrUserNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	// The feed URL is built from the host the request came in on
+	if settings.CalendarToken != nil {
+		calendarURL := c.BaseURL() + "/calendar/" + *settings.CalendarToken + ".ics"
+		settings.CalendarURL = &calendarURL
+	}
+
+	return c.Status(fiber.StatusOK).JSON(settings)
+}
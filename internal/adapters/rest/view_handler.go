@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"errors"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ViewHandler interface {
+	CreateView(c *fiber.Ctx) error
+	FindViewByID(c *fiber.Ctx) error
+	FindViewsByUserID(c *fiber.Ctx) error
+	UpdateViewByID(c *fiber.Ctx) error
+	DeleteViewByID(c *fiber.Ctx) error
+	FindViewTasks(c *fiber.Ctx) error
+}
+
+type viewHandler struct {
+	service usecases.ViewUseCase
+}
+
+func NewViewHandler(service usecases.ViewUseCase) ViewHandler {
+	return &viewHandler{
+		service: service,
+	}
+}
+
+func (v *viewHandler) CreateView(c *fiber.Ctx) error {
+	// Parse request
+	var req *requests.ViewCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Create view
+	view, err := v.service.CreateView(c.Context(), req, userID)
+	if err != nil {
+		return viewError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(view)
+}
+
+func (v *viewHandler) FindViewByID(c *fiber.Ctx) error {
+	// Get view ID
+	viewID := c.Params("viewID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get view
+	view, err := v.service.FindViewByID(c.Context(), viewID, userID)
+	if err != nil {
+		return viewError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(view)
+}
+
+func (v *viewHandler) FindViewsByUserID(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get views
+	views, err := v.service.FindViewsByUserID(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(views)
+}
+
+func (v *viewHandler) UpdateViewByID(c *fiber.Ctx) error {
+	// Get view ID
+	viewID := c.Params("viewID")
+
+	// Parse request
+	var req *requests.ViewUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Update view
+	view, err := v.service.UpdateViewByID(c.Context(), viewID, req, userID)
+	if err != nil {
+		return viewError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(view)
+}
+
+func (v *viewHandler) DeleteViewByID(c *fiber.Ctx) error {
+	// Get view ID
+	viewID := c.Params("viewID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Delete view
+	view, err := v.service.DeleteViewByID(c.Context(), viewID, userID)
+	if err != nil {
+		return viewError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(view)
+}
+
+func (v *viewHandler) FindViewTasks(c *fiber.Ctx) error {
+	// Get view ID
+	viewID := c.Params("viewID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get tasks matching the view
+	tasks, err := v.service.FindViewTasks(c.Context(), viewID, userID)
+	if err != nil {
+		return viewError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tasks)
+}
+
+func viewError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, exceptions.ErrViewNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "View not found",
+		})
+	case errors.Is(err, exceptions.ErrDuplicatedViewName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "View name already used",
+		})
+	case errors.Is(err, exceptions.ErrInvalidQuery), errors.Is(err, exceptions.ErrInvalidSort):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
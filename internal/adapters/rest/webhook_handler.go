@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler interface {
+	CreateWebhook(c *fiber.Ctx) error
+	FindWebhooks(c *fiber.Ctx) error
+	DeleteWebhookByID(c *fiber.Ctx) error
+	FindDeliveries(c *fiber.Ctx) error
+	Redeliver(c *fiber.Ctx) error
+}
+
+type webhookHandler struct {
+	service usecases.WebhookUseCase
+}
+
+func NewWebhookHandler(service usecases.WebhookUseCase) WebhookHandler {
+	return &webhookHandler{
+		service: service,
+	}
+}
+
+func (w *webhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	// Parse request
+	var req *requests.WebhookCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Create webhook
+	webhook, err := w.service.CreateWebhook(c.Context(), req, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrInvalidDestination:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(webhook)
+}
+
+func (w *webhookHandler) FindWebhooks(c *fiber.Ctx) error {
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get webhooks
+	webhooks, err := w.service.FindWebhooks(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(webhooks)
+}
+
+func (w *webhookHandler) DeleteWebhookByID(c *fiber.Ctx) error {
+	// Get webhook ID
+	webhookID := c.Params("webhookID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Delete webhook
+	webhook, err := w.service.DeleteWebhookByID(c.Context(), webhookID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrWebhookNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Webhook not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(webhook)
+}
+
+func (w *webhookHandler) FindDeliveries(c *fiber.Ctx) error {
+	// Get webhook ID
+	webhookID := c.Params("webhookID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Get deliveries
+	deliveries, err := w.service.FindDeliveries(c.Context(), webhookID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrWebhookNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Webhook not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(deliveries)
+}
+
+func (w *webhookHandler) Redeliver(c *fiber.Ctx) error {
+	// Get webhook and delivery ID
+	webhookID := c.Params("webhookID")
+	deliveryID := c.Params("deliveryID")
+
+	// Find id from jwt
+	userID := utils.GetUserIDFromJWT(c)
+
+	// Queue redelivery
+	delivery, err := w.service.Redeliver(c.Context(), webhookID, deliveryID, userID)
+	if err != nil {
+		switch err {
+		case exceptions.ErrWebhookNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Webhook not found",
+			})
+		case exceptions.ErrWebhookDeliveryNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Webhook delivery not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(delivery)
+}
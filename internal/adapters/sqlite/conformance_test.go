@@ -0,0 +1,49 @@
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/repositories/repositorytest"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/mysql"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqlite"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+func TestConformance(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlx.ConnectContext(ctx, "sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate", filepath.Join(t.TempDir(), "tasks.db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := sqlite.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := database.New(db)
+	newRepos := func(t *testing.T) (repositories.UserRepository, repositories.TaskRepository) {
+		return sqlite.NewUserSQLiteRepository(pool), sqlite.NewTaskSQLiteRepository(pool)
+	}
+
+	repositorytest.Run(t, newRepos)
+	repositorytest.RunUnitOfWork(t, newRepos, database.NewUnitOfWork(pool))
+	repositorytest.RunReminderRepository(t, newRepos, sqlite.NewReminderSQLiteRepository(pool))
+	repositorytest.RunNotificationRepository(t, newRepos, sqlite.NewNotificationSQLiteRepository(pool))
+	repositorytest.RunWebhookRepository(t, newRepos, sqlite.NewWebhookSQLiteRepository(pool))
+	repositorytest.RunTimeEntryRepository(t, newRepos, sqlite.NewTimeEntrySQLiteRepository(pool))
+	repositorytest.RunReportingRepository(t, newRepos, sqlite.NewReportingSQLiteRepository(pool))
+	repositorytest.RunTaskCommentRepository(t, newRepos, mysql.NewTaskCommentMySQLRepository(pool), mysql.NewTaskFollowerMySQLRepository(pool))
+}
@@ -0,0 +1,67 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/mysql"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqlite"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+func TestRebuildTasksFromEventStreams(t *testing.T) {
+	ctx := context.Background()
+	pool := openPool(t)
+	userRepo := sqlite.NewUserSQLiteRepository(pool)
+	taskRepo := sqlite.NewTaskSQLiteRepository(pool)
+	eventStore := mysql.NewTaskEventMySQLStore(pool)
+	userID := registerUser(t, userRepo)
+
+	taskService := usecases.NewTaskService(taskRepo, nil, userRepo, mysql.NewOutboxMySQLRepository(pool), eventStore, database.NewUnitOfWork(pool), events.NewDispatcher())
+	kept, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Kept", Description: "Description", Priority: models.TaskPriorityLow, Tags: []string{"work"}}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if _, err := taskService.UpdateTaskStatusByID(ctx, kept.ID, &requests.TaskUpdateStatusRequest{Status: models.TaskStatusCompleted}, userID); err != nil {
+		t.Fatalf("UpdateTaskStatusByID: %v", err)
+	}
+
+	deleted, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Deleted", Description: "Description", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if _, err := taskService.DeleteTaskByID(ctx, deleted.ID, userID); err != nil {
+		t.Fatalf("DeleteTaskByID: %v", err)
+	}
+
+	// Lose the projection, then rebuild it from the streams
+	if _, err := pool.ExecContext(ctx, "DELETE FROM tasks"); err != nil {
+		t.Fatal(err)
+	}
+
+	rebuilt, err := usecases.NewProjectionService(eventStore, taskRepo, mysql.NewProjectMySQLRepository(pool)).RebuildTasks(ctx)
+	if err != nil || rebuilt != 2 {
+		t.Fatalf("RebuildTasks = %d, %v, want 2, nil", rebuilt, err)
+	}
+
+	task, err := taskService.FindTaskByID(ctx, kept.ID, userID)
+	if err != nil {
+		t.Fatalf("FindTaskByID(kept): %v", err)
+	}
+
+	if task.Title != "Kept" || task.Status != models.TaskStatusCompleted || len(task.Tags) != 1 || task.Tags[0] != "work" {
+		t.Errorf("rebuilt task = %+v, want the task as last changed", task)
+	}
+
+	if _, err := taskService.FindTaskByID(ctx, deleted.ID, userID); !errors.Is(err, exceptions.ErrTaskNotFound) {
+		t.Errorf("FindTaskByID(deleted) = %v, want %v", err, exceptions.ErrTaskNotFound)
+	}
+}
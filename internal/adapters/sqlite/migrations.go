@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+
+	"github.com/GraphZC/sdd-task-management/internal/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// SQLite has no advisory locks. The database file is served by one process
+// and SQLite already serialises writers, so locking is a no-op. Migrations run
+// outside a transaction because the table rebuilds turn foreign keys off, which
+// SQLite ignores inside one.
+var migrationDialect = migrations.Dialect{
+	CreateTable: "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY, name TEXT NOT NULL, applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP)",
+	Lock: func(ctx context.Context, conn *sqlx.Conn) error {
+		return nil
+	},
+	Unlock: func(ctx context.Context, conn *sqlx.Conn) error {
+		return nil
+	},
+}
+
+func NewMigrator(db *sqlx.DB) (*migrations.Migrator, error) {
+	source, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.New(db, source, migrationDialect)
+}
@@ -0,0 +1,29 @@
+CREATE TABLE tasks (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    description_html TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'TODO',
+    priority INTEGER NOT NULL,
+    due_date TEXT NULL,
+    recurrence_rule TEXT NULL,
+    recurrence_timezone TEXT NULL,
+    series_id TEXT NULL,
+    project_id TEXT NULL REFERENCES projects (id) ON DELETE SET NULL,
+    external_id TEXT NULL,
+    estimate_minutes INTEGER NULL,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, external_id)
+);
+
+CREATE INDEX tasks_user_status_index ON tasks (user_id, status);
+
+CREATE INDEX tasks_user_due_date_index ON tasks (user_id, due_date);
+
+CREATE INDEX tasks_series_index ON tasks (series_id, due_date);
+
+CREATE INDEX tasks_recurrence_index ON tasks (recurrence_rule, due_date);
+
+CREATE TRIGGER tasks_updated_at AFTER UPDATE ON tasks FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE tasks SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
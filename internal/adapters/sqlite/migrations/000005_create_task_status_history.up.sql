@@ -0,0 +1,12 @@
+CREATE TABLE task_status_history (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX task_status_history_task_index ON task_status_history (task_id, changed_at);
+
+CREATE INDEX task_status_history_user_index ON task_status_history (user_id, to_status, changed_at);
@@ -0,0 +1,23 @@
+CREATE TABLE reminders (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    remind_at TEXT NULL,
+    offset_minutes INTEGER NULL,
+    channel TEXT NOT NULL,
+    target TEXT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TEXT NULL,
+    locked_until TEXT NULL,
+    sent_at TEXT NULL,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX reminders_task_index ON reminders (task_id);
+
+CREATE INDEX reminders_status_index ON reminders (status, next_attempt_at);
+
+CREATE TRIGGER reminders_updated_at AFTER UPDATE ON reminders FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE reminders SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
@@ -0,0 +1,13 @@
+CREATE TABLE views (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    query TEXT NOT NULL,
+    sort TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX views_user_index ON views (user_id);
+
+CREATE TRIGGER views_updated_at AFTER UPDATE ON views FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE views SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
@@ -0,0 +1,36 @@
+CREATE TABLE webhooks (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL,
+    active INTEGER NOT NULL DEFAULT 1,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX webhooks_user_index ON webhooks (user_id, active);
+
+CREATE TABLE webhook_deliveries (
+    id TEXT NOT NULL PRIMARY KEY,
+    webhook_id TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER NULL,
+    last_error TEXT NULL,
+    next_attempt_at TEXT NULL,
+    locked_until TEXT NULL,
+    delivered_at TEXT NULL,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX webhook_deliveries_webhook_index ON webhook_deliveries (webhook_id, created_at);
+
+CREATE INDEX webhook_deliveries_status_index ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TRIGGER webhooks_updated_at AFTER UPDATE ON webhooks FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE webhooks SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER webhook_deliveries_updated_at AFTER UPDATE ON webhook_deliveries FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE webhook_deliveries SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
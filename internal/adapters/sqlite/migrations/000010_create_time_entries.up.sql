@@ -0,0 +1,20 @@
+CREATE TABLE time_entries (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    started_at TEXT NOT NULL,
+    ended_at TEXT NULL,
+    duration_seconds INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Each user has at most one running timer
+CREATE UNIQUE INDEX time_entries_running_unique ON time_entries (user_id) WHERE ended_at IS NULL;
+
+CREATE INDEX time_entries_task_index ON time_entries (task_id, started_at);
+
+CREATE INDEX time_entries_user_index ON time_entries (user_id, started_at);
+
+CREATE TRIGGER time_entries_updated_at AFTER UPDATE ON time_entries FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE time_entries SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
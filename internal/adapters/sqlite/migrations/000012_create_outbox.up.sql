@@ -0,0 +1,20 @@
+CREATE TABLE outbox (
+    id TEXT NOT NULL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    task_id TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at DATETIME NULL,
+    locked_until DATETIME NULL,
+    published_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX outbox_status_index ON outbox (status, next_attempt_at);
+
+CREATE INDEX outbox_published_index ON outbox (published_at);
+
+CREATE TRIGGER outbox_updated_at AFTER UPDATE ON outbox FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE outbox SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
@@ -0,0 +1,10 @@
+CREATE TABLE task_events (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    event_type TEXT NOT NULL,
+    actor_id TEXT NULL,
+    data TEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    UNIQUE (task_id, version)
+);
@@ -0,0 +1,2 @@
+-- DATETIME columns hold the same text TEXT columns did and reverting the declared
+-- types would only stop the driver from scanning them, so they are kept.
@@ -0,0 +1,241 @@
+-- 000001 to 000010 declared timestamps as TEXT. Rebuild those tables with DATETIME
+-- columns so the driver scans them into time.Time; the stored text is unchanged.
+-- Foreign keys are off so dropping a table does not cascade to its children.
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE users_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL UNIQUE,
+    password TEXT NOT NULL,
+    calendar_token TEXT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    timezone TEXT NOT NULL DEFAULT 'UTC'
+);
+
+INSERT INTO users_new (id, name, email, password, calendar_token, created_at, updated_at, timezone) SELECT id, name, email, password, calendar_token, created_at, updated_at, timezone FROM users;
+
+DROP TABLE users;
+
+ALTER TABLE users_new RENAME TO users;
+
+CREATE TRIGGER users_updated_at AFTER UPDATE ON users FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE users SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TABLE projects_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+INSERT INTO projects_new (id, user_id, name, created_at, updated_at) SELECT id, user_id, name, created_at, updated_at FROM projects;
+
+DROP TABLE projects;
+
+ALTER TABLE projects_new RENAME TO projects;
+
+CREATE TRIGGER projects_updated_at AFTER UPDATE ON projects FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE projects SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TABLE tasks_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    description_html TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'TODO',
+    priority INTEGER NOT NULL,
+    due_date DATETIME NULL,
+    recurrence_rule TEXT NULL,
+    recurrence_timezone TEXT NULL,
+    series_id TEXT NULL,
+    project_id TEXT NULL REFERENCES projects (id) ON DELETE SET NULL,
+    external_id TEXT NULL,
+    estimate_minutes INTEGER NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, external_id)
+);
+
+INSERT INTO tasks_new (id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes, created_at, updated_at) SELECT id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes, created_at, updated_at FROM tasks;
+
+DROP TABLE tasks;
+
+ALTER TABLE tasks_new RENAME TO tasks;
+
+CREATE INDEX tasks_user_status_index ON tasks (user_id, status);
+
+CREATE INDEX tasks_user_due_date_index ON tasks (user_id, due_date);
+
+CREATE INDEX tasks_series_index ON tasks (series_id, due_date);
+
+CREATE INDEX tasks_recurrence_index ON tasks (recurrence_rule, due_date);
+
+CREATE TRIGGER tasks_updated_at AFTER UPDATE ON tasks FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE tasks SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TABLE task_status_history_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO task_status_history_new (id, task_id, user_id, from_status, to_status, changed_at) SELECT id, task_id, user_id, from_status, to_status, changed_at FROM task_status_history;
+
+DROP TABLE task_status_history;
+
+ALTER TABLE task_status_history_new RENAME TO task_status_history;
+
+CREATE INDEX task_status_history_task_index ON task_status_history (task_id, changed_at);
+
+CREATE INDEX task_status_history_user_index ON task_status_history (user_id, to_status, changed_at);
+
+CREATE TABLE reminders_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    remind_at DATETIME NULL,
+    offset_minutes INTEGER NULL,
+    channel TEXT NOT NULL,
+    target TEXT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at DATETIME NULL,
+    locked_until DATETIME NULL,
+    sent_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO reminders_new (id, task_id, user_id, remind_at, offset_minutes, channel, target, status, attempts, last_error, next_attempt_at, locked_until, sent_at, created_at, updated_at) SELECT id, task_id, user_id, remind_at, offset_minutes, channel, target, status, attempts, last_error, next_attempt_at, locked_until, sent_at, created_at, updated_at FROM reminders;
+
+DROP TABLE reminders;
+
+ALTER TABLE reminders_new RENAME TO reminders;
+
+CREATE INDEX reminders_task_index ON reminders (task_id);
+
+CREATE INDEX reminders_status_index ON reminders (status, next_attempt_at);
+
+CREATE TRIGGER reminders_updated_at AFTER UPDATE ON reminders FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE reminders SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TABLE notifications_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    task_id TEXT NULL REFERENCES tasks (id) ON DELETE SET NULL,
+    type TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    read_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO notifications_new (id, user_id, task_id, type, title, body, read_at, created_at) SELECT id, user_id, task_id, type, title, body, read_at, created_at FROM notifications;
+
+DROP TABLE notifications;
+
+ALTER TABLE notifications_new RENAME TO notifications;
+
+CREATE INDEX notifications_user_index ON notifications (user_id, read_at, created_at);
+
+CREATE TABLE views_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    query TEXT NOT NULL,
+    sort TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO views_new (id, user_id, name, query, sort, created_at, updated_at) SELECT id, user_id, name, query, sort, created_at, updated_at FROM views;
+
+DROP TABLE views;
+
+ALTER TABLE views_new RENAME TO views;
+
+CREATE INDEX views_user_index ON views (user_id);
+
+CREATE TRIGGER views_updated_at AFTER UPDATE ON views FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE views SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TABLE webhooks_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL,
+    active INTEGER NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO webhooks_new (id, user_id, url, secret, events, active, created_at, updated_at) SELECT id, user_id, url, secret, events, active, created_at, updated_at FROM webhooks;
+
+DROP TABLE webhooks;
+
+ALTER TABLE webhooks_new RENAME TO webhooks;
+
+CREATE INDEX webhooks_user_index ON webhooks (user_id, active);
+
+CREATE TRIGGER webhooks_updated_at AFTER UPDATE ON webhooks FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE webhooks SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TABLE webhook_deliveries_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    webhook_id TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER NULL,
+    last_error TEXT NULL,
+    next_attempt_at DATETIME NULL,
+    locked_until DATETIME NULL,
+    delivered_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO webhook_deliveries_new (id, webhook_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, locked_until, delivered_at, created_at, updated_at) SELECT id, webhook_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, locked_until, delivered_at, created_at, updated_at FROM webhook_deliveries;
+
+DROP TABLE webhook_deliveries;
+
+ALTER TABLE webhook_deliveries_new RENAME TO webhook_deliveries;
+
+CREATE INDEX webhook_deliveries_webhook_index ON webhook_deliveries (webhook_id, created_at);
+
+CREATE INDEX webhook_deliveries_status_index ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TRIGGER webhook_deliveries_updated_at AFTER UPDATE ON webhook_deliveries FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE webhook_deliveries SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TABLE time_entries_new (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME NULL,
+    duration_seconds INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO time_entries_new (id, task_id, user_id, started_at, ended_at, duration_seconds, note, created_at, updated_at) SELECT id, task_id, user_id, started_at, ended_at, duration_seconds, note, created_at, updated_at FROM time_entries;
+
+DROP TABLE time_entries;
+
+ALTER TABLE time_entries_new RENAME TO time_entries;
+
+CREATE UNIQUE INDEX time_entries_running_unique ON time_entries (user_id) WHERE ended_at IS NULL;
+
+CREATE INDEX time_entries_task_index ON time_entries (task_id, started_at);
+
+CREATE INDEX time_entries_user_index ON time_entries (user_id, started_at);
+
+CREATE TRIGGER time_entries_updated_at AFTER UPDATE ON time_entries FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE time_entries SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+PRAGMA foreign_keys = ON;
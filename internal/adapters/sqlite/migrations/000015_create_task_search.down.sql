@@ -0,0 +1,7 @@
+DROP TRIGGER task_search_delete;
+
+DROP TRIGGER task_search_update;
+
+DROP TRIGGER task_search_insert;
+
+DROP TABLE task_search;
@@ -0,0 +1,11 @@
+-- Full-text index over task titles and descriptions. Triggers keep it in step
+-- with every write to tasks, and existing tasks are indexed right away.
+CREATE VIRTUAL TABLE task_search USING fts5(task_id UNINDEXED, title, description);
+
+INSERT INTO task_search (task_id, title, description) SELECT id, title, description FROM tasks;
+
+CREATE TRIGGER task_search_insert AFTER INSERT ON tasks FOR EACH ROW BEGIN INSERT INTO task_search (task_id, title, description) VALUES (NEW.id, NEW.title, NEW.description); END;
+
+CREATE TRIGGER task_search_update AFTER UPDATE OF title, description ON tasks FOR EACH ROW BEGIN UPDATE task_search SET title = NEW.title, description = NEW.description WHERE task_id = NEW.id; END;
+
+CREATE TRIGGER task_search_delete AFTER DELETE ON tasks FOR EACH ROW BEGIN DELETE FROM task_search WHERE task_id = OLD.id; END;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_comments;
+
+DROP TABLE IF EXISTS task_followers;
+
+ALTER TABLE tasks DROP COLUMN assignee_id;
@@ -0,0 +1,21 @@
+-- No foreign key, SQLite cannot drop a column which has one
+ALTER TABLE tasks ADD COLUMN assignee_id TEXT NULL;
+
+CREATE TABLE task_followers (
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX task_followers_user_index ON task_followers (user_id);
+
+CREATE TABLE task_comments (
+    id TEXT NOT NULL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX task_comments_task_index ON task_comments (task_id, created_at);
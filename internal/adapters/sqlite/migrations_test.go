@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/GraphZC/sdd-task-management/internal/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+func TestDateTimeMigrationKeepsExistingRows(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlx.ConnectContext(ctx, "sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)", filepath.Join(t.TempDir(), "tasks.db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// A database migrated before timestamps were declared DATETIME
+	source, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	early := fstest.MapFS{}
+	for _, pattern := range []string{"00000*.sql", "00001[01]_*.sql"} {
+		names, err := fs.Glob(source, pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range names {
+			content, err := fs.ReadFile(source, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			early[name] = &fstest.MapFile{Data: content}
+		}
+	}
+
+	migrator, err := migrations.New(db, early, migrationDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	db.MustExecContext(ctx, "INSERT INTO users (id, name, email, password, created_at, updated_at) VALUES ('u1', 'User', 'user@example.com', 'hashed', '2024-01-02 03:04:05', '2024-01-02 03:04:05')")
+	db.MustExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, priority, due_date) VALUES ('t1', 'u1', 'Task', '', 1, '2024-02-03 04:05:06')")
+	db.MustExecContext(ctx, "INSERT INTO task_tags (task_id, tag) VALUES ('t1', 'work')")
+
+	// The rest of the migrations rebuild the tables in place
+	migrator, err = NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := database.New(db)
+	user, err := NewUserSQLiteRepository(pool).FindByEmail(ctx, "user@example.com")
+	if err != nil {
+		t.Fatalf("FindByEmail: %v", err)
+	}
+
+	if got := user.CreatedAt.UTC().Format("2006-01-02 15:04:05"); got != "2024-01-02 03:04:05" {
+		t.Errorf("CreatedAt = %s, want 2024-01-02 03:04:05", got)
+	}
+
+	task, err := NewTaskSQLiteRepository(pool).FindByID(ctx, "t1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+
+	if task.DueDate == nil || task.DueDate.UTC().Format("2006-01-02 15:04:05") != "2024-02-03 04:05:06" || len(task.Tags) != 1 {
+		t.Errorf("task = %+v, want its due date and tag kept", task)
+	}
+
+	// Tasks written before the search index existed are found too
+	if results, err := NewTaskSQLiteSearchIndex(pool).Search(ctx, "u1", "task", 10); err != nil || len(results) != 1 || results[0].Task.ID != "t1" {
+		t.Errorf("Search = %+v, %v, want the existing task", results, err)
+	}
+
+	// Foreign keys still point at the rebuilt tables
+	db.MustExecContext(ctx, "DELETE FROM users WHERE id = 'u1'")
+
+	var tags int
+	if err := db.GetContext(ctx, &tags, "SELECT COUNT(*) FROM task_tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if tags != 0 {
+		t.Errorf("%d tags left after deleting the user, want the delete to cascade", tags)
+	}
+}
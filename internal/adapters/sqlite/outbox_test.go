@@ -0,0 +1,122 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/mysql"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqlite"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/jmoiron/sqlx"
+)
+
+var errOutboxDown = errors.New("outbox down")
+
+// failingOutbox refuses new messages, as if the outbox insert failed
+type failingOutbox struct {
+	repositories.OutboxRepository
+}
+
+func (f failingOutbox) Create(ctx context.Context, eventType string, taskID string, payload string) (string, error) {
+	return "", errOutboxDown
+}
+
+type recordingPublisher struct {
+	messages []models.OutboxMessage
+}
+
+func (r *recordingPublisher) Publish(ctx context.Context, message *models.OutboxMessage) error {
+	r.messages = append(r.messages, *message)
+	return nil
+}
+
+func openPool(t *testing.T) *database.DB {
+	ctx := context.Background()
+
+	db, err := sqlx.ConnectContext(ctx, "sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate", filepath.Join(t.TempDir(), "tasks.db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := sqlite.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	return database.New(db)
+}
+
+func registerUser(t *testing.T, userRepo repositories.UserRepository) string {
+	ctx := context.Background()
+	if err := userRepo.Create(ctx, &requests.UserRegisterRequest{Name: "Test User", Email: "outbox@example.com", Password: "hashed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := userRepo.FindByEmail(ctx, "outbox@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user.ID
+}
+
+func TestOutboxMessageCommitsWithTask(t *testing.T) {
+	ctx := context.Background()
+	pool := openPool(t)
+	userRepo := sqlite.NewUserSQLiteRepository(pool)
+	outboxRepo := mysql.NewOutboxMySQLRepository(pool)
+	userID := registerUser(t, userRepo)
+
+	taskService := usecases.NewTaskService(sqlite.NewTaskSQLiteRepository(pool), nil, userRepo, outboxRepo, mysql.NewTaskEventMySQLStore(pool), database.NewUnitOfWork(pool), events.NewDispatcher())
+	task, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	publisher := &recordingPublisher{}
+	published, err := usecases.NewOutboxService(outboxRepo, publisher).RelayPending(ctx, time.Now().UTC())
+	if err != nil || published != 1 {
+		t.Fatalf("RelayPending = %d, %v, want 1, nil", published, err)
+	}
+
+	message := publisher.messages[0]
+	if message.EventType != models.TaskEventCreated || message.TaskID != task.ID {
+		t.Errorf("published %s for %s, want %s for %s", message.EventType, message.TaskID, models.TaskEventCreated, task.ID)
+	}
+}
+
+func TestTaskRollsBackWhenOutboxFails(t *testing.T) {
+	ctx := context.Background()
+	pool := openPool(t)
+	userRepo := sqlite.NewUserSQLiteRepository(pool)
+	taskRepo := sqlite.NewTaskSQLiteRepository(pool)
+	userID := registerUser(t, userRepo)
+
+	taskService := usecases.NewTaskService(taskRepo, nil, userRepo, failingOutbox{mysql.NewOutboxMySQLRepository(pool)}, mysql.NewTaskEventMySQLStore(pool), database.NewUnitOfWork(pool), events.NewDispatcher())
+	if _, err := taskService.CreateTask(ctx, &requests.TaskCreateRequest{Title: "Task", Description: "Description", Priority: models.TaskPriorityLow}, userID); !errors.Is(err, errOutboxDown) {
+		t.Fatalf("CreateTask = %v, want %v", err, errOutboxDown)
+	}
+
+	tasks, err := taskRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tasks) != 0 {
+		t.Errorf("FindByUserID returned %d tasks, want the task rolled back with its event", len(tasks))
+	}
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const reminderColumns = "r.id, r.task_id, r.user_id, r.remind_at, r.offset_minutes, r.channel, r.target, r.status, r.attempts, r.last_error, r.next_attempt_at, r.locked_until, r.sent_at, r.created_at, r.updated_at"
+
+type ReminderSQLiteRepository struct {
+	db *database.DB
+}
+
+func NewReminderSQLiteRepository(db *database.DB) repositories.ReminderRepository {
+	return &ReminderSQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *ReminderSQLiteRepository) Create(ctx context.Context, req *requests.ReminderCreateRequest, taskID string, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = r.db.ExecContext(ctx, "INSERT INTO reminders (id, task_id, user_id, remind_at, offset_minutes, channel, target, status) VALUES (?, ?, ?, ?, ?, ?, ?, ?)", id.String(), taskID, userID, nullDateTime(req.RemindAt), req.OffsetMinutes, req.Channel, nullString(req.Target), models.ReminderStatusPending)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (r *ReminderSQLiteRepository) FindByID(ctx context.Context, reminderID string) (*models.Reminder, error) {
+	var reminder models.Reminder
+	err := r.db.GetContext(ctx, &reminder, "SELECT "+reminderColumns+" FROM reminders r WHERE r.id = ?", reminderID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reminder, nil
+}
+
+func (r *ReminderSQLiteRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.Reminder, error) {
+	reminders := []models.Reminder{}
+	err := r.db.SelectContext(ctx, &reminders, "SELECT "+reminderColumns+" FROM reminders r WHERE r.task_id = ? ORDER BY r.created_at", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (r *ReminderSQLiteRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error) {
+	// Offset reminders follow the current due date of their task, and reminders
+	// left in SENDING by a crashed instance are picked up again once their lock expires
+	var reminders []models.Reminder
+	err := r.db.SelectContext(ctx, &reminders, "SELECT "+reminderColumns+" FROM reminders r JOIN tasks t ON t.id = r.task_id "+
+		"WHERE t.status <> ? "+
+		"AND (r.status = ? OR (r.status = ? AND r.locked_until < ?)) "+
+		"AND (r.next_attempt_at IS NULL OR r.next_attempt_at <= ?) "+
+		"AND COALESCE(r.remind_at, datetime(t.due_date, (-r.offset_minutes) || ' minutes')) <= ? "+
+		"ORDER BY r.created_at LIMIT ?",
+		models.TaskStatusCompleted, models.ReminderStatusPending, models.ReminderStatusSending, dateTime(now), dateTime(now), dateTime(now), limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (r *ReminderSQLiteRepository) DeleteByID(ctx context.Context, reminderID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM reminders WHERE id = ?", reminderID)
+
+	return err
+}
+
+func (r *ReminderSQLiteRepository) Claim(ctx context.Context, reminderID string, now time.Time, lockedUntil time.Time) (bool, error) {
+	// Only one instance wins the conditional update
+	result, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = ?, locked_until = ?, attempts = attempts + 1 WHERE id = ? AND (status = ? OR (status = ? AND locked_until < ?))", models.ReminderStatusSending, dateTime(lockedUntil), reminderID, models.ReminderStatusPending, models.ReminderStatusSending, dateTime(now))
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (r *ReminderSQLiteRepository) MarkSent(ctx context.Context, reminderID string, sentAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = ?, sent_at = ?, locked_until = NULL, last_error = NULL WHERE id = ?", models.ReminderStatusSent, dateTime(sentAt), reminderID)
+
+	return err
+}
+
+func (r *ReminderSQLiteRepository) Reschedule(ctx context.Context, reminderID string, lastError string, nextAttemptAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = ?, last_error = ?, next_attempt_at = ?, locked_until = NULL WHERE id = ?", models.ReminderStatusPending, lastError, dateTime(nextAttemptAt), reminderID)
+
+	return err
+}
+
+func (r *ReminderSQLiteRepository) MarkFailed(ctx context.Context, reminderID string, lastError string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE reminders SET status = ?, last_error = ?, locked_until = NULL WHERE id = ?", models.ReminderStatusFailed, lastError, reminderID)
+
+	return err
+}
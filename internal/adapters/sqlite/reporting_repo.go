@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+type ReportingSQLiteRepository struct {
+	db *database.DB
+}
+
+func NewReportingSQLiteRepository(db *database.DB) repositories.ReportingRepository {
+	return &ReportingSQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *ReportingSQLiteRepository) CountByPeriod(ctx context.Context, userID string, from time.Time, to time.Time, interval string) ([]models.StatsPeriod, error) {
+	created := periodExpression("created_at", interval, from)
+	completed := periodExpression("changed_at", interval, from)
+
+	periods := []models.StatsPeriod{}
+	err := r.db.SelectContext(ctx, &periods, fmt.Sprintf(`SELECT period, SUM(created) AS created, SUM(completed) AS completed FROM (
+		SELECT %s AS period, 1 AS created, 0 AS completed FROM tasks WHERE user_id = ? AND created_at >= ? AND created_at < ?
+		UNION ALL
+		SELECT %s AS period, 0 AS created, 1 AS completed FROM task_status_history WHERE user_id = ? AND to_status = ? AND changed_at >= ? AND changed_at < ?
+	) counts GROUP BY period ORDER BY period`, created, completed),
+		userID, dateTime(from), dateTime(to),
+		userID, models.TaskStatusCompleted, dateTime(from), dateTime(to),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (r *ReportingSQLiteRepository) AverageCycleTime(ctx context.Context, userID string, from time.Time, to time.Time) (*models.CycleTime, error) {
+	// A task reopened and completed again counts from its first completion
+	var cycleTime models.CycleTime
+	err := r.db.GetContext(ctx, &cycleTime, `SELECT COALESCE(AVG(unixepoch(h.completed_at) - unixepoch(t.created_at)), 0) AS average_seconds, COUNT(*) AS tasks
+		FROM tasks t
+		JOIN (SELECT task_id, MIN(changed_at) AS completed_at FROM task_status_history WHERE user_id = ? AND to_status = ? GROUP BY task_id) h ON h.task_id = t.id
+		WHERE h.completed_at >= ? AND h.completed_at < ?`,
+		userID, models.TaskStatusCompleted, dateTime(from), dateTime(to),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycleTime, nil
+}
+
+func (r *ReportingSQLiteRepository) CountOpenByPriority(ctx context.Context, userID string) ([]models.PriorityCount, error) {
+	counts := []models.PriorityCount{}
+	err := r.db.SelectContext(ctx, &counts, "SELECT priority, COUNT(*) AS count FROM tasks WHERE user_id = ? AND status <> ? GROUP BY priority ORDER BY priority DESC", userID, models.TaskStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *ReportingSQLiteRepository) CountOverdue(ctx context.Context, userID string, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM tasks WHERE user_id = ? AND status <> ? AND due_date IS NOT NULL AND due_date < ?", userID, models.TaskStatusCompleted, dateTime(now))
+
+	return count, err
+}
+
+// periodExpression buckets a datetime column by day or by ISO week (Monday)
+// in the location of from
+func periodExpression(column string, interval string, from time.Time) string {
+	if interval == models.StatsIntervalWeek {
+		// Step back six days, then forward to the first Monday
+		return fmt.Sprintf("date(%s, '-6 days', 'weekday 1')", localDate(column, from))
+	}
+
+	return localDate(column, from)
+}
+
+// localDate gives the date of a UTC datetime column in the location of at.
+// SQLite knows no time zones, so the offset in effect at at is used for the
+// whole range.
+func localDate(column string, at time.Time) string {
+	_, offset := at.Zone()
+
+	return fmt.Sprintf("date(%s, '%+d seconds')", column, offset)
+}
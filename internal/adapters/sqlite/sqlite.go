@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqltask"
+	"github.com/jmoiron/sqlx"
+)
+
+// taskDialect spells out the LIKE escape character, which SQLite has none of
+// by default, and compares times as the text they are stored as
+var taskDialect = sqltask.Dialect{
+	BindType:   sqlx.QUESTION,
+	LikeEscape: " ESCAPE '\\'",
+	Time: func(value time.Time) any {
+		return dateTime(value)
+	},
+	AddTag: "INSERT OR IGNORE INTO task_tags (task_id, tag) VALUES (?, ?)",
+}
+
+// dateTime formats times as "YYYY-MM-DD HH:MM:SS" UTC text, so stored values
+// sort as text and the driver parses DATETIME columns back into time.Time
+func dateTime(value time.Time) string {
+	return value.UTC().Format(time.DateTime)
+}
+
+func nullDateTime(value *time.Time) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: dateTime(*value), Valid: true}
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
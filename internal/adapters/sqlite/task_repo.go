@@ -0,0 +1,332 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/query"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqltask"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const taskColumns = "id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes, assignee_id, created_at, updated_at"
+
+type TaskSQLiteRepository struct {
+	db *database.DB
+}
+
+func NewTaskSQLiteRepository(db *database.DB) repositories.TaskRepository {
+	return &TaskSQLiteRepository{
+		db: db,
+	}
+}
+
+func (t *TaskSQLiteRepository) Create(ctx context.Context, req *requests.TaskCreateRequest, userID string) (*models.Task, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	// A recurring task starts its own series
+	var seriesID sql.NullString
+	if req.RecurrenceRule != "" {
+		seriesID = nullString(id.String())
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", id.String(), userID, req.Title, req.Description, req.DescriptionHTML, models.TaskStatusTodo, req.Priority, nullDateTime(req.DueDate), nullString(req.RecurrenceRule), nullString(req.RecurrenceTimezone), seriesID, req.ProjectID, req.ExternalID, req.EstimateMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := taskDialect.ReplaceTags(ctx, tx, id.String(), req.Tags); err != nil {
+		return nil, err
+	}
+
+	// Read the row back in the transaction to get the generated timestamps
+	var task models.Task
+	if err := tx.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE id = ?", id.String()); err != nil {
+		return nil, err
+	}
+
+	if err := taskDialect.LoadTags(ctx, tx, []*models.Task{&task}); err != nil {
+		return nil, err
+	}
+
+	return &task, tx.Commit()
+}
+
+func (t *TaskSQLiteRepository) CreateOccurrence(ctx context.Context, task *models.Task, dueDate time.Time) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, estimate_minutes) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", id.String(), task.UserID, task.Title, task.Description, task.DescriptionHTML, models.TaskStatusTodo, task.Priority, dateTime(dueDate), task.RecurrenceRule, task.RecurrenceTimezone, task.SeriesID, task.ProjectID, task.EstimateMinutes)
+	if err != nil {
+		return "", err
+	}
+
+	if err := taskDialect.ReplaceTags(ctx, tx, id.String(), task.Tags); err != nil {
+		return "", err
+	}
+
+	return id.String(), tx.Commit()
+}
+
+// Save writes the task as given, inserting it or replacing the stored row and
+// its tags. The projector restores tasks from their event streams with it.
+func (t *TaskSQLiteRepository) Save(ctx context.Context, task *models.Task) error {
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO tasks (id, user_id, title, description, description_html, status, priority, due_date, recurrence_rule, recurrence_timezone, series_id, project_id, external_id, estimate_minutes, assignee_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+		"ON CONFLICT (id) DO UPDATE SET user_id = excluded.user_id, title = excluded.title, description = excluded.description, description_html = excluded.description_html, status = excluded.status, priority = excluded.priority, due_date = excluded.due_date, recurrence_rule = excluded.recurrence_rule, recurrence_timezone = excluded.recurrence_timezone, series_id = excluded.series_id, project_id = excluded.project_id, external_id = excluded.external_id, estimate_minutes = excluded.estimate_minutes, assignee_id = excluded.assignee_id, created_at = excluded.created_at, updated_at = excluded.updated_at",
+		task.ID, task.UserID, task.Title, task.Description, task.DescriptionHTML, task.Status, task.Priority, nullDateTime(task.DueDate), task.RecurrenceRule, task.RecurrenceTimezone, task.SeriesID, task.ProjectID, task.ExternalID, task.EstimateMinutes, task.AssigneeID, dateTime(task.CreatedAt), dateTime(task.UpdatedAt))
+	if err != nil {
+		return err
+	}
+
+	if err := taskDialect.ReplaceTags(ctx, tx, task.ID, task.Tags); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (t *TaskSQLiteRepository) FindByID(ctx context.Context, taskID string) (*models.Task, error) {
+	var task models.Task
+	err := t.db.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE id = ?", taskID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, taskDialect.LoadTags(ctx, t.db, []*models.Task{&task})
+}
+
+func (t *TaskSQLiteRepository) FindByUserID(ctx context.Context, userID string) ([]models.Task, error) {
+	var tasks []models.Task
+	err := t.db.SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ?", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskSQLiteRepository) FindByUserIDAfter(ctx context.Context, userID string, afterID string, limit int) ([]models.Task, error) {
+	// Ids are UUIDv7 so paging by id follows creation order
+	tasks := []models.Task{}
+	err := t.db.SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ? AND id > ? ORDER BY id LIMIT ?", userID, afterID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskSQLiteRepository) FindByExternalID(ctx context.Context, userID string, externalID string) (*models.Task, error) {
+	var task models.Task
+	err := t.db.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ? AND external_id = ?", userID, externalID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, taskDialect.LoadTags(ctx, t.db, []*models.Task{&task})
+}
+
+func (t *TaskSQLiteRepository) FindByQuery(ctx context.Context, userID string, q *query.Query, now time.Time) ([]models.Task, error) {
+	where, args := taskDialect.CompileConditions(q.Conditions, now)
+
+	statement := "SELECT " + taskColumns + " FROM tasks WHERE user_id = ?"
+	if where != "" {
+		statement += " AND " + where
+	}
+	statement += " ORDER BY " + sqltask.CompileSort(q.Sort)
+
+	tasks := []models.Task{}
+	err := t.db.SelectContext(ctx, &tasks, statement, append([]any{userID}, args...)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskSQLiteRepository) FindLatestBySeriesID(ctx context.Context, seriesID string) (*models.Task, error) {
+	var task models.Task
+	err := t.db.GetContext(ctx, &task, "SELECT "+taskColumns+" FROM tasks WHERE series_id = ? ORDER BY due_date DESC LIMIT 1", seriesID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, taskDialect.LoadTags(ctx, t.db, []*models.Task{&task})
+}
+
+func (t *TaskSQLiteRepository) FindRecurringDueBefore(ctx context.Context, before time.Time) ([]models.Task, error) {
+	// Only the latest occurrence of each series rolls forward, until the series ends
+	var tasks []models.Task
+	err := t.db.SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks t WHERE t.recurrence_rule IS NOT NULL AND t.due_date < ? AND (t.recurrence_ended_rule IS NULL OR t.recurrence_ended_rule <> t.recurrence_rule) AND NOT EXISTS (SELECT 1 FROM tasks n WHERE n.series_id = t.series_id AND n.due_date > t.due_date)", dateTime(before))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, taskDialect.LoadTaskListTags(ctx, t.db, tasks)
+}
+
+func (t *TaskSQLiteRepository) EndRecurrenceByID(ctx context.Context, taskID string) error {
+	_, err := t.db.ExecContext(ctx, "UPDATE tasks SET recurrence_ended_rule = recurrence_rule WHERE id = ?", taskID)
+
+	return err
+}
+
+func (t *TaskSQLiteRepository) AssignByID(ctx context.Context, taskID string, assigneeID *string) error {
+	_, err := t.db.ExecContext(ctx, "UPDATE tasks SET assignee_id = ? WHERE id = ?", assigneeID, taskID)
+
+	return err
+}
+
+func (t *TaskSQLiteRepository) DeleteByID(ctx context.Context, taskID string) error {
+	_, err := t.db.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", taskID)
+
+	return err
+}
+
+func (t *TaskSQLiteRepository) UpdateByUD(ctx context.Context, taskID string, req *requests.TaskCreateRequest) error {
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Keep the existing series when a rule is kept or added, drop it when the rule is removed
+	if req.RecurrenceRule != "" {
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET title = ?, description = ?, description_html = ?, priority = ?, due_date = ?, recurrence_rule = ?, recurrence_timezone = ?, series_id = COALESCE(series_id, id), project_id = ?, estimate_minutes = ? WHERE id = ?", req.Title, req.Description, req.DescriptionHTML, req.Priority, nullDateTime(req.DueDate), req.RecurrenceRule, nullString(req.RecurrenceTimezone), req.ProjectID, req.EstimateMinutes, taskID)
+	} else {
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET title = ?, description = ?, description_html = ?, priority = ?, due_date = ?, recurrence_rule = NULL, recurrence_timezone = NULL, project_id = ?, estimate_minutes = ? WHERE id = ?", req.Title, req.Description, req.DescriptionHTML, req.Priority, nullDateTime(req.DueDate), req.ProjectID, req.EstimateMinutes, taskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Tags are left alone when the request doesn't mention them
+	if req.Tags != nil {
+		if err := taskDialect.ReplaceTags(ctx, tx, taskID, req.Tags); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (t *TaskSQLiteRepository) PatchByID(ctx context.Context, taskID string, req *requests.TaskUpdateRequest, fields []string) error {
+	// Only the columns behind the given fields are written
+	sets := []string{}
+	args := []any{}
+	updateTags := false
+	for _, field := range fields {
+		switch field {
+		case "title":
+			sets = append(sets, "title = ?")
+			args = append(args, req.Title)
+		case "description":
+			sets = append(sets, "description = ?", "description_html = ?")
+			args = append(args, req.Description, req.DescriptionHTML)
+		case "priority":
+			sets = append(sets, "priority = ?")
+			args = append(args, req.Priority)
+		case "dueDate":
+			sets = append(sets, "due_date = ?")
+			args = append(args, nullDateTime(req.DueDate))
+		case "recurrenceRule":
+			if req.RecurrenceRule != "" {
+				sets = append(sets, "recurrence_rule = ?", "recurrence_timezone = ?", "series_id = COALESCE(series_id, id)")
+				args = append(args, req.RecurrenceRule, nullString(req.RecurrenceTimezone))
+			} else {
+				sets = append(sets, "recurrence_rule = NULL", "recurrence_timezone = NULL")
+			}
+		case "projectId":
+			sets = append(sets, "project_id = ?")
+			args = append(args, req.ProjectID)
+		case "estimateMinutes":
+			sets = append(sets, "estimate_minutes = ?")
+			args = append(args, req.EstimateMinutes)
+		case "tags":
+			updateTags = true
+		}
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(sets) > 0 {
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET "+strings.Join(sets, ", ")+" WHERE id = ?", append(args, taskID)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if updateTags {
+		if err := taskDialect.ReplaceTags(ctx, tx, taskID, req.Tags); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (t *TaskSQLiteRepository) UpdateStatusByID(ctx context.Context, taskID string, status string) error {
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := taskDialect.UpdateStatus(ctx, tx, taskID, status); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
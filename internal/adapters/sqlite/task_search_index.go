@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/search"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+const snippetLength = 160
+
+type taskSearchRow struct {
+	models.Task
+	Score float64 `db:"score"`
+}
+
+// TaskSQLiteSearchIndex reads the task_search FTS5 table, which triggers keep
+// up to date with every write to tasks
+type TaskSQLiteSearchIndex struct {
+	db *database.DB
+}
+
+func NewTaskSQLiteSearchIndex(db *database.DB) repositories.TaskSearchIndex {
+	return &TaskSQLiteSearchIndex{
+		db: db,
+	}
+}
+
+func (t *TaskSQLiteSearchIndex) Index(ctx context.Context, task *models.Task) error {
+	return nil
+}
+
+func (t *TaskSQLiteSearchIndex) Remove(ctx context.Context, taskID string) error {
+	return nil
+}
+
+func (t *TaskSQLiteSearchIndex) Search(ctx context.Context, userID string, query string, limit int) ([]models.TaskSearchResult, error) {
+	terms := search.Tokenize(query)
+	if len(terms) == 0 {
+		return []models.TaskSearchResult{}, nil
+	}
+
+	// Any term may match as a prefix. bm25 is lower for better matches and
+	// weighs title matches twice as much as description matches.
+	phrases := make([]string, len(terms))
+	for i, term := range terms {
+		phrases[i] = `"` + term + `"*`
+	}
+	match := strings.Join(phrases, " OR ")
+
+	var rows []taskSearchRow
+	err := t.db.SelectContext(ctx, &rows, "SELECT "+taskColumns+", matches.score FROM tasks "+
+		"JOIN (SELECT task_id, -bm25(task_search, 0, 2, 1) AS score FROM task_search WHERE task_search MATCH ?) matches ON matches.task_id = tasks.id "+
+		"WHERE user_id = ? ORDER BY matches.score DESC LIMIT ?",
+		match, userID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, len(rows))
+	for i := range rows {
+		tasks[i] = &rows[i].Task
+	}
+
+	if err := taskDialect.LoadTags(ctx, t.db, tasks); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.TaskSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, models.TaskSearchResult{
+			Task:           row.Task,
+			Score:          row.Score,
+			TitleHighlight: search.Highlight(row.Title, terms, 0),
+			Snippet:        search.Highlight(row.Description, terms, snippetLength),
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,60 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqlite"
+)
+
+func TestSearchIndexFollowsTaskWrites(t *testing.T) {
+	ctx := context.Background()
+	pool := openPool(t)
+	userRepo := sqlite.NewUserSQLiteRepository(pool)
+	taskRepo := sqlite.NewTaskSQLiteRepository(pool)
+	searchIndex := sqlite.NewTaskSQLiteSearchIndex(pool)
+	userID := registerUser(t, userRepo)
+
+	report, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Quarterly report", Description: "Collect the numbers", Priority: models.TaskPriorityLow, Tags: []string{"work"}}, userID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	groceries, err := taskRepo.Create(ctx, &requests.TaskCreateRequest{Title: "Groceries", Description: "Milk for the report meeting", Priority: models.TaskPriorityLow}, userID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Terms match as prefixes, and title matches rank above description matches
+	results, err := searchIndex.Search(ctx, userID, "repo", 10)
+	if err != nil || len(results) != 2 || results[0].Task.ID != report.ID || results[1].Task.ID != groceries.ID {
+		t.Fatalf("Search(repo) = %+v, %v, want the report before the groceries", results, err)
+	}
+
+	if results[0].TitleHighlight != "Quarterly <mark>report</mark>" || len(results[0].Task.Tags) != 1 || results[0].Task.Tags[0] != "work" {
+		t.Errorf("Search(repo)[0] = %+v, want the title highlighted and the tags loaded", results[0])
+	}
+
+	if results, err := searchIndex.Search(ctx, "someone-else", "report", 10); err != nil || len(results) != 0 {
+		t.Errorf("Search(other user) = %+v, %v, want none", results, err)
+	}
+
+	report.Title = "Annual summary"
+	if err := taskRepo.Save(ctx, report); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if results, err := searchIndex.Search(ctx, userID, "annual", 10); err != nil || len(results) != 1 || results[0].Task.ID != report.ID {
+		t.Errorf("Search(annual) after renaming = %+v, %v, want the renamed task", results, err)
+	}
+
+	if err := taskRepo.DeleteByID(ctx, groceries.ID); err != nil {
+		t.Fatalf("DeleteByID: %v", err)
+	}
+
+	if results, err := searchIndex.Search(ctx, userID, "milk", 10); err != nil || len(results) != 0 {
+		t.Errorf("Search(milk) after deleting = %+v, %v, want none", results, err)
+	}
+}
@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+	driver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const timeEntryColumns = "id, task_id, user_id, started_at, ended_at, duration_seconds, note, created_at, updated_at"
+
+type TimeEntrySQLiteRepository struct {
+	db *database.DB
+}
+
+func NewTimeEntrySQLiteRepository(db *database.DB) repositories.TimeEntryRepository {
+	return &TimeEntrySQLiteRepository{
+		db: db,
+	}
+}
+
+func (t *TimeEntrySQLiteRepository) Create(ctx context.Context, req *requests.TimeEntryCreateRequest, taskID string, userID string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	duration := int64(req.EndedAt.Sub(*req.StartedAt) / time.Second)
+
+	_, err = t.db.ExecContext(ctx, "INSERT INTO time_entries (id, task_id, user_id, started_at, ended_at, duration_seconds, note) VALUES (?, ?, ?, ?, ?, ?, ?)", id.String(), taskID, userID, dateTime(*req.StartedAt), dateTime(*req.EndedAt), duration, req.Note)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TimeEntrySQLiteRepository) Start(ctx context.Context, taskID string, userID string, note string, startedAt time.Time) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	// The unique index on running timers settles two starts racing each other
+	_, err = t.db.ExecContext(ctx, "INSERT INTO time_entries (id, task_id, user_id, started_at, duration_seconds, note) VALUES (?, ?, ?, ?, 0, ?)", id.String(), taskID, userID, dateTime(startedAt), note)
+	var sqliteErr *driver.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE && strings.Contains(sqliteErr.Error(), "time_entries.user_id") {
+		return "", exceptions.ErrTimerAlreadyRunning
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (t *TimeEntrySQLiteRepository) Stop(ctx context.Context, entryID string, endedAt time.Time) error {
+	_, err := t.db.ExecContext(ctx, "UPDATE time_entries SET ended_at = ?, duration_seconds = MAX(unixepoch(?) - unixepoch(started_at), 0) WHERE id = ? AND ended_at IS NULL", dateTime(endedAt), dateTime(endedAt), entryID)
+
+	return err
+}
+
+func (t *TimeEntrySQLiteRepository) FindByID(ctx context.Context, entryID string) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := t.db.GetContext(ctx, &entry, "SELECT "+timeEntryColumns+" FROM time_entries WHERE id = ?", entryID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (t *TimeEntrySQLiteRepository) FindByTaskID(ctx context.Context, taskID string) ([]models.TimeEntry, error) {
+	entries := []models.TimeEntry{}
+	err := t.db.SelectContext(ctx, &entries, "SELECT "+timeEntryColumns+" FROM time_entries WHERE task_id = ? ORDER BY started_at", taskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (t *TimeEntrySQLiteRepository) FindRunningByUserID(ctx context.Context, userID string) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := t.db.GetContext(ctx, &entry, "SELECT "+timeEntryColumns+" FROM time_entries WHERE user_id = ? AND ended_at IS NULL", userID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (t *TimeEntrySQLiteRepository) DeleteByID(ctx context.Context, entryID string) error {
+	_, err := t.db.ExecContext(ctx, "DELETE FROM time_entries WHERE id = ?", entryID)
+
+	return err
+}
+
+func (t *TimeEntrySQLiteRepository) Timesheet(ctx context.Context, userID string, from time.Time, to time.Time, groupBy string) ([]models.TimesheetRow, error) {
+	// Only finished entries count, grouped by the day they started on in from's location
+	var groupKey, joins string
+	switch groupBy {
+	case models.TimesheetGroupByDay:
+		groupKey = localDate("e.started_at", from)
+	case models.TimesheetGroupByProject:
+		groupKey = "COALESCE(p.name, '')"
+		joins = "JOIN tasks t ON t.id = e.task_id LEFT JOIN projects p ON p.id = t.project_id"
+	case models.TimesheetGroupByTag:
+		groupKey = "COALESCE(tt.tag, '')"
+		joins = "LEFT JOIN task_tags tt ON tt.task_id = e.task_id"
+	default:
+		return nil, fmt.Errorf("unknown timesheet group %q", groupBy)
+	}
+
+	statement := "SELECT " + groupKey + " AS group_key, SUM(e.duration_seconds) AS seconds, COUNT(*) AS entries FROM time_entries e " + joins + " WHERE e.user_id = ? AND e.ended_at IS NOT NULL AND e.started_at >= ? AND e.started_at < ? GROUP BY group_key ORDER BY group_key"
+
+	rows := []models.TimesheetRow{}
+	err := t.db.SelectContext(ctx, &rows, statement, userID, dateTime(from), dateTime(to))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
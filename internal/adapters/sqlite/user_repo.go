@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const userColumns = "id, name, email, password, calendar_token, timezone, created_at, updated_at"
+
+type UserSQLiteRepository struct {
+	db *database.DB
+}
+
+func NewUserSQLiteRepository(db *database.DB) repositories.UserRepository {
+	return &UserSQLiteRepository{
+		db: db,
+	}
+}
+
+func (u *UserSQLiteRepository) Create(ctx context.Context, req *requests.UserRegisterRequest) error {
+	// Generate UUID
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	_, err = u.db.ExecContext(ctx, "INSERT INTO users (id, name, email, password) VALUES (?, ?, ?, ?)", id.String(), req.Name, req.Email, req.Password)
+
+	return err
+}
+
+func (u *UserSQLiteRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	return u.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE id = ?", userID)
+}
+
+func (u *UserSQLiteRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return u.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE email = ?", email)
+}
+
+func (u *UserSQLiteRepository) FindByCalendarToken(ctx context.Context, token string) (*models.User, error) {
+	return u.findOne(ctx, "SELECT "+userColumns+" FROM users WHERE calendar_token = ?", token)
+}
+
+func (u *UserSQLiteRepository) UpdateCalendarToken(ctx context.Context, userID string, token *string) error {
+	_, err := u.db.ExecContext(ctx, "UPDATE users SET calendar_token = ? WHERE id = ?", token, userID)
+
+	return err
+}
+
+func (u *UserSQLiteRepository) UpdateTimezone(ctx context.Context, userID string, timezone string) error {
+	_, err := u.db.ExecContext(ctx, "UPDATE users SET timezone = ? WHERE id = ?", timezone, userID)
+
+	return err
+}
+
+func (u *UserSQLiteRepository) findOne(ctx context.Context, statement string, args ...any) (*models.User, error) {
+	var user models.User
+	err := u.db.GetContext(ctx, &user, statement, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	webhookColumns         = "id, user_id, url, secret, events, active, created_at, updated_at"
+	webhookDeliveryColumns = "id, webhook_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, locked_until, delivered_at, created_at, updated_at"
+)
+
+type WebhookSQLiteRepository struct {
+	db *database.DB
+}
+
+func NewWebhookSQLiteRepository(db *database.DB) repositories.WebhookRepository {
+	return &WebhookSQLiteRepository{
+		db: db,
+	}
+}
+
+func (w *WebhookSQLiteRepository) Create(ctx context.Context, req *requests.WebhookCreateRequest, userID string, secret string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = w.db.ExecContext(ctx, "INSERT INTO webhooks (id, user_id, url, secret, events, active) VALUES (?, ?, ?, ?, ?, TRUE)", id.String(), userID, req.URL, secret, models.StringList(req.Events))
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (w *WebhookSQLiteRepository) FindByID(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := w.db.GetContext(ctx, &webhook, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", webhookID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *WebhookSQLiteRepository) FindByUserID(ctx context.Context, userID string) ([]models.Webhook, error) {
+	webhooks := []models.Webhook{}
+	err := w.db.SelectContext(ctx, &webhooks, "SELECT "+webhookColumns+" FROM webhooks WHERE user_id = ? ORDER BY created_at", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookSQLiteRepository) FindActiveByUserIDAndEvent(ctx context.Context, userID string, eventType string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := w.db.SelectContext(ctx, &webhooks, "SELECT "+webhookColumns+" FROM webhooks WHERE user_id = ? AND active = TRUE AND instr(',' || events || ',', ',' || ? || ',') > 0", userID, eventType)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookSQLiteRepository) DeleteByID(ctx context.Context, webhookID string) error {
+	_, err := w.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", webhookID)
+
+	return err
+}
+
+func (w *WebhookSQLiteRepository) CreateDelivery(ctx context.Context, webhookID string, eventType string, payload string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = w.db.ExecContext(ctx, "INSERT INTO webhook_deliveries (id, webhook_id, event_type, payload, status) VALUES (?, ?, ?, ?, ?)", id.String(), webhookID, eventType, payload, models.WebhookDeliveryStatusPending)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (w *WebhookSQLiteRepository) FindDeliveryByID(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := w.db.GetContext(ctx, &delivery, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE id = ?", deliveryID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (w *WebhookSQLiteRepository) FindDeliveriesByWebhookID(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	deliveries := []models.WebhookDelivery{}
+	err := w.db.SelectContext(ctx, &deliveries, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC, id DESC LIMIT ?", webhookID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookSQLiteRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	// Deliveries left in SENDING by a crashed instance are picked up again once their lock expires
+	var deliveries []models.WebhookDelivery
+	err := w.db.SelectContext(ctx, &deliveries, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries "+
+		"WHERE (status = ? OR (status = ? AND locked_until < ?)) "+
+		"AND (next_attempt_at IS NULL OR next_attempt_at <= ?) "+
+		"ORDER BY created_at LIMIT ?",
+		models.WebhookDeliveryStatusPending, models.WebhookDeliveryStatusSending, dateTime(now), dateTime(now), limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookSQLiteRepository) ClaimDelivery(ctx context.Context, deliveryID string, now time.Time, lockedUntil time.Time) (bool, error) {
+	// Only one instance wins the conditional update
+	result, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = ?, locked_until = ?, attempts = attempts + 1 WHERE id = ? AND (status = ? OR (status = ? AND locked_until < ?))", models.WebhookDeliveryStatusSending, dateTime(lockedUntil), deliveryID, models.WebhookDeliveryStatusPending, models.WebhookDeliveryStatusSending, dateTime(now))
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (w *WebhookSQLiteRepository) MarkDeliverySucceeded(ctx context.Context, deliveryID string, responseStatus int, deliveredAt time.Time) error {
+	_, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = ?, response_status = ?, delivered_at = ?, last_error = NULL, locked_until = NULL WHERE id = ?", models.WebhookDeliveryStatusSucceeded, responseStatus, dateTime(deliveredAt), deliveryID)
+
+	return err
+}
+
+func (w *WebhookSQLiteRepository) RescheduleDelivery(ctx context.Context, deliveryID string, responseStatus *int, lastError string, nextAttemptAt time.Time) error {
+	_, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = ?, response_status = ?, last_error = ?, next_attempt_at = ?, locked_until = NULL WHERE id = ?", models.WebhookDeliveryStatusPending, responseStatus, lastError, dateTime(nextAttemptAt), deliveryID)
+
+	return err
+}
+
+func (w *WebhookSQLiteRepository) MarkDeliveryFailed(ctx context.Context, deliveryID string, responseStatus *int, lastError string) error {
+	_, err := w.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status = ?, response_status = ?, last_error = ?, locked_until = NULL WHERE id = ?", models.WebhookDeliveryStatusFailed, responseStatus, lastError, deliveryID)
+
+	return err
+}
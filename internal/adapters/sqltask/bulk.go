@@ -0,0 +1,117 @@
+package sqltask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// FindByIDs selects columns of the tasks with the given ids
+func (d Dialect) FindByIDs(ctx context.Context, db *database.DB, columns string, taskIDs []string) ([]models.Task, error) {
+	// Malformed ids can't match the id column
+	ids := []string{}
+	for _, taskID := range taskIDs {
+		if d.ValidID == nil || d.ValidID(taskID) {
+			ids = append(ids, taskID)
+		}
+	}
+
+	tasks := []models.Task{}
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+
+	statement, args, err := sqlx.In("SELECT "+columns+" FROM tasks WHERE id IN (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.SelectContext(ctx, &tasks, d.Rebind(statement), args...); err != nil {
+		return nil, err
+	}
+
+	return tasks, d.LoadTaskListTags(ctx, db, tasks)
+}
+
+// ApplyBulk runs every operation in one transaction. In atomic mode the first
+// failure rolls everything back; otherwise each operation runs behind a
+// savepoint so a failing one is undone on its own and reported in its slot.
+func (d Dialect) ApplyBulk(ctx context.Context, db *database.DB, operations []requests.TaskBulkOperation, atomic bool) ([]error, error) {
+	errs := make([]error, len(operations))
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errs, err
+	}
+	defer tx.Rollback()
+
+	for i := range operations {
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_operation"); err != nil {
+				return errs, err
+			}
+		}
+
+		err := d.applyBulkOperation(ctx, tx, &operations[i])
+		if err == nil {
+			continue
+		}
+
+		errs[i] = err
+		if atomic {
+			return errs, err
+		}
+
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_operation"); err != nil {
+			return errs, err
+		}
+	}
+
+	return errs, tx.Commit()
+}
+
+func (d Dialect) applyBulkOperation(ctx context.Context, tx *database.Tx, operation *requests.TaskBulkOperation) error {
+	var err error
+
+	switch operation.Action {
+	case models.TaskBulkActionUpdateStatus:
+		err = d.UpdateStatus(ctx, tx, operation.TaskID, operation.Status)
+	case models.TaskBulkActionChangePriority:
+		_, err = tx.ExecContext(ctx, d.Rebind("UPDATE tasks SET priority = ? WHERE id = ?"), operation.Priority, operation.TaskID)
+	case models.TaskBulkActionAddTag:
+		_, err = tx.ExecContext(ctx, d.Rebind(d.AddTag), operation.TaskID, operation.Tag)
+	case models.TaskBulkActionRemoveTag:
+		_, err = tx.ExecContext(ctx, d.Rebind("DELETE FROM task_tags WHERE task_id = ? AND tag = ?"), operation.TaskID, operation.Tag)
+	case models.TaskBulkActionMoveProject:
+		_, err = tx.ExecContext(ctx, d.Rebind("UPDATE tasks SET project_id = ? WHERE id = ?"), operation.ProjectID, operation.TaskID)
+	case models.TaskBulkActionDelete:
+		_, err = tx.ExecContext(ctx, d.Rebind("DELETE FROM tasks WHERE id = ?"), operation.TaskID)
+	default:
+		err = fmt.Errorf("unknown bulk action %q", operation.Action)
+	}
+
+	return err
+}
+
+// UpdateStatus records the transition in the status history before changing
+// the task, so the history only holds real changes
+func (d Dialect) UpdateStatus(ctx context.Context, tx *database.Tx, taskID string, status string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, d.Rebind("INSERT INTO task_status_history (id, task_id, user_id, from_status, to_status) SELECT ?, id, user_id, status, ? FROM tasks WHERE id = ? AND status <> ?"), id.String(), status, taskID, status)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, d.Rebind("UPDATE tasks SET status = ? WHERE id = ?"), status, taskID)
+
+	return err
+}
@@ -0,0 +1,42 @@
+// Package sqltask holds the task SQL shared by the MySQL, SQLite and Postgres
+// repositories. Statements are written with "?" placeholders and a Dialect
+// covers what differs between the databases.
+package sqltask
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Dialect struct {
+	// BindType is the placeholder style statements are rebound to
+	BindType int
+
+	// LikeEscape follows each LIKE pattern where the backslash is not already
+	// the escape character
+	LikeEscape string
+
+	// Time converts a time argument to what the columns are compared with,
+	// nil passes it through
+	Time func(time.Time) any
+
+	// AddTag inserts a task_id and tag pair unless the task already has it
+	AddTag string
+
+	// ValidID reports whether an id can match the id column, nil accepts any
+	ValidID func(string) bool
+}
+
+// Rebind turns the "?" placeholders of statement into the dialect's
+func (d Dialect) Rebind(statement string) string {
+	return sqlx.Rebind(d.BindType, statement)
+}
+
+func (d Dialect) time(value time.Time) any {
+	if d.Time == nil {
+		return value
+	}
+
+	return d.Time(value)
+}
@@ -0,0 +1,126 @@
+package sqltask
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/query"
+)
+
+// Sort columns are qualified so ORDER BY reads the stored columns even where
+// the select list formats them under the same names
+var sortColumns = map[string]string{
+	query.FieldPriority: "tasks.priority",
+	query.FieldDue:      "tasks.due_date",
+	query.FieldCreated:  "tasks.created_at",
+	query.FieldUpdated:  "tasks.updated_at",
+	query.FieldTitle:    "tasks.title",
+	query.FieldStatus:   "tasks.status",
+}
+
+var timeColumns = map[string]string{
+	query.FieldDue:     "due_date",
+	query.FieldCreated: "created_at",
+	query.FieldUpdated: "updated_at",
+}
+
+// CompileConditions turns parsed conditions into a WHERE clause with "?"
+// placeholders, values are only ever passed as arguments. Every expression is
+// NULL-free so negating it never drops rows silently.
+func (d Dialect) CompileConditions(conditions []query.Condition, now time.Time) (string, []any) {
+	var clauses []string
+	var args []any
+
+	for i := range conditions {
+		condition := &conditions[i]
+
+		clause, clauseArgs := d.compileCondition(condition, now)
+		if condition.Negated() {
+			clause = "NOT " + clause
+		}
+
+		clauses = append(clauses, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	return strings.Join(clauses, " AND "), args
+}
+
+func (d Dialect) compileCondition(condition *query.Condition, now time.Time) (string, []any) {
+	like := "LIKE ?" + d.LikeEscape
+
+	switch condition.Field {
+	case query.FieldText:
+		pattern := likePattern(condition.Value)
+		return "(LOWER(title) " + like + " OR LOWER(description) " + like + ")", []any{pattern, pattern}
+	case query.FieldTitle:
+		return "(LOWER(title) " + like + ")", []any{likePattern(condition.Value)}
+	case query.FieldStatus:
+		return "(status = ?)", []any{condition.Value}
+	case query.FieldTag:
+		return "EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag = ?)", []any{condition.Value}
+	case query.FieldRecurring:
+		if condition.Bool() {
+			return "(recurrence_rule IS NOT NULL)", nil
+		}
+		return "(recurrence_rule IS NULL)", nil
+	case query.FieldPriority:
+		return fmt.Sprintf("(priority %s ?)", sqlOperator(condition.Operator)), []any{condition.Int()}
+	}
+
+	// Date fields
+	column := timeColumns[condition.Field]
+	switch condition.Value {
+	case query.ValueNone:
+		return "(" + column + " IS NULL)", nil
+	case query.ValueAny:
+		return "(" + column + " IS NOT NULL)", nil
+	}
+
+	clauses := []string{column + " IS NOT NULL"}
+	var args []any
+	for _, comparison := range condition.TimeComparisons(now) {
+		clauses = append(clauses, fmt.Sprintf("%s %s ?", column, sqlOperator(comparison.Operator)))
+		args = append(args, d.time(comparison.Time))
+	}
+
+	return "(" + strings.Join(clauses, " AND ") + ")", args
+}
+
+// CompileSort turns parsed sort fields into an ORDER BY list, ties fall back
+// to the creation order
+func CompileSort(fields []query.SortField) string {
+	var clauses []string
+	for _, field := range fields {
+		column := sortColumns[field.Field]
+
+		// Tasks without a due date come last either way
+		if field.Field == query.FieldDue {
+			clauses = append(clauses, column+" IS NULL")
+		}
+
+		direction := "ASC"
+		if field.Descending {
+			direction = "DESC"
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+
+	return strings.Join(append(clauses, "tasks.created_at ASC", "tasks.id ASC"), ", ")
+}
+
+func sqlOperator(operator query.Operator) string {
+	switch operator {
+	case query.OperatorGreater, query.OperatorGreaterEqual, query.OperatorLess, query.OperatorLessEqual:
+		return string(operator)
+	default:
+		return "="
+	}
+}
+
+// likePattern escapes the wildcards with a backslash
+func likePattern(value string) string {
+	escaped := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+	return "%" + escaped + "%"
+}
@@ -0,0 +1,138 @@
+package sqltask_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/query"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqltask"
+	"github.com/jmoiron/sqlx"
+)
+
+var now = time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+func compile(t *testing.T, dialect sqltask.Dialect, input string) (string, []any) {
+	t.Helper()
+
+	q, err := query.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", input, err)
+	}
+
+	return dialect.CompileConditions(q.Conditions, now)
+}
+
+func TestCompileConditions(t *testing.T) {
+	dayStart := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name      string
+		input     string
+		wantWhere string
+		wantArgs  []any
+	}{
+		{
+			name:  "Empty",
+			input: "",
+		},
+		{
+			name:      "Text",
+			input:     "notes",
+			wantWhere: "(LOWER(title) LIKE ? OR LOWER(description) LIKE ?)",
+			wantArgs:  []any{"%notes%", "%notes%"},
+		},
+		{
+			name:      "Fields",
+			input:     "title:plan status:todo tag:api priority>=1 recurring:false",
+			wantWhere: "(LOWER(title) LIKE ?) AND (status = ?) AND EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag = ?) AND (priority >= ?) AND (recurrence_rule IS NULL)",
+			wantArgs:  []any{"%plan%", "TODO", "api", 1},
+		},
+		{
+			name:      "Negation",
+			input:     "-tag:done status!=TODO -priority!=2",
+			wantWhere: "NOT EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag = ?) AND NOT (status = ?) AND (priority = ?)",
+			wantArgs:  []any{"done", "TODO", 2},
+		},
+		{
+			name:      "Dates",
+			input:     "due:2030-01-02 created:none updated:any",
+			wantWhere: "(due_date IS NOT NULL AND due_date >= ? AND due_date < ?) AND (created_at IS NULL) AND (updated_at IS NOT NULL)",
+			wantArgs:  []any{dayStart, dayEnd},
+		},
+		{
+			name:      "LikeWildcards",
+			input:     `title:"100%_\"`,
+			wantWhere: "(LOWER(title) LIKE ?)",
+			wantArgs:  []any{`%100\%\_\\%`},
+		},
+		{
+			// Quotes, comments and statements only ever reach the arguments
+			name:      "InjectionShaped",
+			input:     `"x' OR 1=1 --" tag:a');DROP status:todo`,
+			wantWhere: "(LOWER(title) LIKE ? OR LOWER(description) LIKE ?) AND EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag = ?) AND (status = ?)",
+			wantArgs:  []any{"%x' or 1=1 --%", "%x' or 1=1 --%", "a');drop", "TODO"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := compile(t, sqltask.Dialect{BindType: sqlx.QUESTION}, tt.input)
+			if where != tt.wantWhere || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("CompileConditions(%q) = %q, %v, want %q, %v", tt.input, where, args, tt.wantWhere, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCompileConditionsDialect(t *testing.T) {
+	dialect := sqltask.Dialect{
+		BindType:   sqlx.DOLLAR,
+		LikeEscape: " ESCAPE '\\'",
+		Time: func(value time.Time) any {
+			return value.Format(time.DateTime)
+		},
+	}
+
+	where, args := compile(t, dialect, "title:plan due<2030-01-02")
+	if want := `(LOWER(title) LIKE ? ESCAPE '\') AND (due_date IS NOT NULL AND due_date < ?)`; where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+
+	if want := []any{"%plan%", "2030-01-02 00:00:00"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+
+	if got, want := dialect.Rebind("user_id = ? AND "+where), `user_id = $1 AND (LOWER(title) LIKE $2 ESCAPE '\') AND (due_date IS NOT NULL AND due_date < $3)`; got != want {
+		t.Errorf("Rebind = %q, want %q", got, want)
+	}
+}
+
+func TestCompileSort(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: "tasks.created_at ASC, tasks.id ASC"},
+		{input: "-priority,title", want: "tasks.priority DESC, tasks.title ASC, tasks.created_at ASC, tasks.id ASC"},
+		{input: "-due", want: "tasks.due_date IS NULL, tasks.due_date DESC, tasks.created_at ASC, tasks.id ASC"},
+	}
+
+	for _, tt := range tests {
+		fields, err := query.ParseSort(tt.input)
+		if err != nil {
+			t.Fatalf("ParseSort(%q): %v", tt.input, err)
+		}
+
+		if got := sqltask.CompileSort(fields); got != tt.want {
+			t.Errorf("CompileSort(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	// Only known fields reach the compiler, anything else fails to parse
+	if fields, err := query.ParseSort("priority DESC; DROP TABLE tasks"); err == nil || strings.Contains(sqltask.CompileSort(fields), "DROP") {
+		t.Errorf("ParseSort accepted an injection-shaped sort")
+	}
+}
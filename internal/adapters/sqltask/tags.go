@@ -0,0 +1,69 @@
+package sqltask
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/jmoiron/sqlx"
+)
+
+type taskTag struct {
+	TaskID string `db:"task_id"`
+	Tag    string `db:"tag"`
+}
+
+// ReplaceTags sets the tags of a task to tags
+func (d Dialect) ReplaceTags(ctx context.Context, tx *database.Tx, taskID string, tags []string) error {
+	if _, err := tx.ExecContext(ctx, d.Rebind("DELETE FROM task_tags WHERE task_id = ?"), taskID); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, d.Rebind("INSERT INTO task_tags (task_id, tag) VALUES (?, ?)"), taskID, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d Dialect) LoadTaskListTags(ctx context.Context, db sqlx.QueryerContext, tasks []models.Task) error {
+	pointers := make([]*models.Task, len(tasks))
+	for i := range tasks {
+		pointers[i] = &tasks[i]
+	}
+
+	return d.LoadTags(ctx, db, pointers)
+}
+
+// LoadTags fills in the tags of tasks with one query, in tag order
+func (d Dialect) LoadTags(ctx context.Context, db sqlx.QueryerContext, tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Task, len(tasks))
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		task.Tags = []string{}
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	statement, args, err := sqlx.In("SELECT task_id, tag FROM task_tags WHERE task_id IN (?) ORDER BY tag", ids)
+	if err != nil {
+		return err
+	}
+
+	var rows []taskTag
+	if err := sqlx.SelectContext(ctx, db, &rows, d.Rebind(statement), args...); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		byID[row.TaskID].Tags = append(byID[row.TaskID].Tags, row.Tag)
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package todoist
+
+import (
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/importers"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/transfer"
+)
+
+// The CSV export doesn't carry the project name
+const defaultProject = "Todoist"
+
+// Absolute date formats Todoist writes into the DATE column
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+type TodoistImporter struct{}
+
+func NewTodoistImporter() importers.Importer {
+	return &TodoistImporter{}
+}
+
+func (t *TodoistImporter) Parse(r io.Reader, options *importers.Options) (*importers.Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	if _, ok := columns["CONTENT"]; !ok {
+		return nil, fmt.Errorf("missing CONTENT column")
+	}
+
+	project := defaultProject
+	if options.Project != "" {
+		project = options.Project
+	}
+
+	result := &importers.Result{}
+	section := ""
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			result.Rows = append(result.Rows, transfer.Row{Line: line, Err: err})
+			continue
+		}
+
+		value := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(values) {
+				return ""
+			}
+			return strings.TrimSpace(values[i])
+		}
+
+		content := value("CONTENT")
+		switch strings.ToLower(value("TYPE")) {
+		case "section":
+			// Sections become a tag on the tasks below them
+			section = content
+			continue
+		case "note":
+			if content != "" {
+				result.Unmapped = append(result.Unmapped, fmt.Sprintf("line %d: comment not imported", line))
+			}
+			continue
+		case "task":
+		default:
+			continue
+		}
+
+		record := &requests.TaskImportRecord{
+			ExternalID:  externalID(project, section, content),
+			Title:       content,
+			Description: value("DESCRIPTION"),
+			Status:      models.TaskStatusTodo,
+			Priority:    priority(value("PRIORITY")),
+			Project:     project,
+		}
+
+		if record.Description == "" {
+			record.Description = content
+		}
+
+		if section != "" {
+			record.Tags = []string{section}
+		}
+
+		dueDate, ok := parseDate(value("DATE"), value("TIMEZONE"))
+		if ok {
+			record.DueDate = dueDate
+		} else {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("line %d: date %q not understood", line, value("DATE")))
+		}
+
+		if indent, _ := strconv.Atoi(value("INDENT")); indent > 1 {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("line %d: sub-task imported as a task", line))
+		}
+
+		if responsible := value("RESPONSIBLE"); responsible != "" {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("line %d: assignee %q not imported", line, responsible))
+		}
+
+		result.Rows = append(result.Rows, transfer.Row{Line: line, Record: record})
+	}
+
+	return result, nil
+}
+
+// Todoist priority 1 is the most urgent and 4 means no priority
+func priority(value string) int {
+	switch value {
+	case "1":
+		return models.TaskPriorityHigh
+	case "2":
+		return models.TaskPriorityMedium
+	default:
+		return models.TaskPriorityLow
+	}
+}
+
+func parseDate(value string, timezone string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	location := time.UTC
+	if timezone != "" {
+		if loaded, err := time.LoadLocation(timezone); err == nil {
+			location = loaded
+		}
+	}
+
+	for _, layout := range dateLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, location); err == nil {
+			return &parsed, true
+		}
+	}
+
+	return nil, false
+}
+
+// Rows have no id, so the same task gets the same id on every import
+func externalID(project string, section string, content string) string {
+	sum := sha1.Sum([]byte(project + "\x00" + section + "\x00" + content))
+	return "todoist:" + hex.EncodeToString(sum[:10])
+}
@@ -0,0 +1,192 @@
+package trello
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/importers"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/requests"
+	"github.com/GraphZC/sdd-task-management/domain/transfer"
+)
+
+// Lists with one of these names hold finished cards
+var doneLists = []string{"done", "complete", "completed", "closed", "finished"}
+
+type board struct {
+	Name       string      `json:"name"`
+	Lists      []list      `json:"lists"`
+	Cards      []card      `json:"cards"`
+	Checklists []checklist `json:"checklists"`
+}
+
+type list struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Closed bool   `json:"closed"`
+}
+
+type label struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type card struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Desc             string            `json:"desc"`
+	IDList           string            `json:"idList"`
+	Labels           []label           `json:"labels"`
+	Due              *time.Time        `json:"due"`
+	DueComplete      bool              `json:"dueComplete"`
+	Closed           bool              `json:"closed"`
+	IDMembers        []string          `json:"idMembers"`
+	CustomFieldItems []json.RawMessage `json:"customFieldItems"`
+	Badges           struct {
+		Attachments int `json:"attachments"`
+		Comments    int `json:"comments"`
+	} `json:"badges"`
+}
+
+type checklist struct {
+	IDCard     string  `json:"idCard"`
+	Name       string  `json:"name"`
+	Pos        float64 `json:"pos"`
+	CheckItems []struct {
+		Name  string  `json:"name"`
+		State string  `json:"state"`
+		Pos   float64 `json:"pos"`
+	} `json:"checkItems"`
+}
+
+type TrelloImporter struct{}
+
+func NewTrelloImporter() importers.Importer {
+	return &TrelloImporter{}
+}
+
+func (t *TrelloImporter) Parse(r io.Reader, options *importers.Options) (*importers.Result, error) {
+	var b board
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, err
+	}
+
+	// The board becomes the project
+	project := b.Name
+	if options.Project != "" {
+		project = options.Project
+	}
+
+	lists := map[string]list{}
+	for _, l := range b.Lists {
+		lists[l.ID] = l
+	}
+
+	checklists := map[string][]checklist{}
+	for _, c := range b.Checklists {
+		checklists[c.IDCard] = append(checklists[c.IDCard], c)
+	}
+
+	result := &importers.Result{}
+	for i, c := range b.Cards {
+		// Archived cards are neither open nor done
+		l := lists[c.IDList]
+		if c.Closed || l.Closed {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("card %q: archived card skipped", c.Name))
+			continue
+		}
+
+		record := &requests.TaskImportRecord{
+			ExternalID:  "trello:" + c.ID,
+			Title:       c.Name,
+			Description: description(&c, checklists[c.ID]),
+			Status:      models.TaskStatusTodo,
+			Priority:    models.TaskPriorityMedium,
+			DueDate:     c.Due,
+			Project:     project,
+		}
+
+		if c.DueComplete || isDoneList(l.Name) {
+			record.Status = models.TaskStatusCompleted
+		}
+
+		// The list and the labels become tags
+		if l.Name != "" {
+			record.Tags = append(record.Tags, l.Name)
+		}
+		for _, lb := range c.Labels {
+			name := lb.Name
+			if name == "" {
+				name = lb.Color
+			}
+			if name != "" {
+				record.Tags = append(record.Tags, name)
+			}
+		}
+
+		result.Rows = append(result.Rows, transfer.Row{Line: i + 1, Record: record})
+
+		if missing := unmapped(&c); len(missing) > 0 {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("card %q: %s not imported", c.Name, strings.Join(missing, ", ")))
+		}
+	}
+
+	return result, nil
+}
+
+func description(c *card, checklists []checklist) string {
+	parts := []string{}
+	if strings.TrimSpace(c.Desc) != "" {
+		parts = append(parts, strings.TrimSpace(c.Desc))
+	}
+
+	// Checklists become Markdown task lists
+	sort.Slice(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+	for _, cl := range checklists {
+		items := cl.CheckItems
+		sort.Slice(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+
+		lines := []string{"### " + cl.Name}
+		for _, item := range items {
+			mark := " "
+			if item.State == "complete" {
+				mark = "x"
+			}
+			lines = append(lines, fmt.Sprintf("- [%s] %s", mark, item.Name))
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+
+	if len(parts) == 0 {
+		return c.Name
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+func unmapped(c *card) []string {
+	missing := []string{}
+	if len(c.IDMembers) > 0 {
+		missing = append(missing, fmt.Sprintf("%d members", len(c.IDMembers)))
+	}
+	if c.Badges.Attachments > 0 {
+		missing = append(missing, fmt.Sprintf("%d attachments", c.Badges.Attachments))
+	}
+	if c.Badges.Comments > 0 {
+		missing = append(missing, fmt.Sprintf("%d comments", c.Badges.Comments))
+	}
+	if len(c.CustomFieldItems) > 0 {
+		missing = append(missing, fmt.Sprintf("%d custom fields", len(c.CustomFieldItems)))
+	}
+
+	return missing
+}
+
+func isDoneList(name string) bool {
+	return slices.Contains(doneLists, strings.ToLower(strings.TrimSpace(name)))
+}
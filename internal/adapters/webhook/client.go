@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// newClient returns a client which refuses to connect to private addresses.
+// The check runs on the resolved address as each connection is made, so it
+// also covers redirects and names which resolve somewhere else after the
+// webhook was registered
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: denyPrivateDestinations,
+	}
+
+	// A proxy would be dialled instead of the destination, skipping the check
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+	}
+}
+
+func denyPrivateDestinations(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !notifiers.PublicAddr(addrPort.Addr()) {
+		return exceptions.ErrBlockedDestination
+	}
+
+	return nil
+}
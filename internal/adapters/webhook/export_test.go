@@ -0,0 +1,14 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+)
+
+// NewLocalHMACWebhookSender sends to any address, for test servers on loopback
+func NewLocalHMACWebhookSender() notifiers.WebhookSender {
+	return &HMACWebhookSender{
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
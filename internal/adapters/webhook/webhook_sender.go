@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	TimestampHeader = "X-Webhook-Timestamp"
+)
+
+type HMACWebhookSender struct {
+	client *http.Client
+}
+
+func NewHMACWebhookSender() notifiers.WebhookSender {
+	return &HMACWebhookSender{
+		client: newClient(),
+	}
+}
+
+func (h *HMACWebhookSender) Send(ctx context.Context, url string, secret string, eventType string, deliveryID string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, payload))
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp, a dot and the
+// payload. Receivers compute the same over the X-Webhook-Timestamp header and
+// the raw request body, compare it in constant time, and reject requests whose
+// timestamp is more than a few minutes (five is a good default) away from
+// their clock so a captured delivery cannot be replayed later.
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
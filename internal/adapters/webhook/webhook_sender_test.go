@@ -0,0 +1,73 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/exceptions"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/webhook"
+)
+
+func TestSendSignsTimestampAndPayload(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	payload := []byte(`{"id":"1"}`)
+	status, err := webhook.NewLocalHMACWebhookSender().Send(context.Background(), server.URL, "secret", "task.created", "delivery-1", payload)
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("Send = %d, %v, want 204, nil", status, err)
+	}
+
+	timestamp := header.Get(webhook.TimestampHeader)
+	if want := "sha256=" + webhook.Sign("secret", timestamp, body); timestamp == "" || header.Get(webhook.SignatureHeader) != want {
+		t.Errorf("signature = %q for timestamp %q, want %q", header.Get(webhook.SignatureHeader), timestamp, want)
+	}
+
+	// Replaying the body under another timestamp no longer verifies
+	if webhook.Sign("secret", "0", body) == webhook.Sign("secret", timestamp, body) {
+		t.Error("Sign ignores the timestamp")
+	}
+}
+
+func TestSendRefusesPrivateDestinations(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// Resolving the name happens after registration, the dialer still refuses it
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	for _, url := range []string{
+		server.URL,
+		"http://localhost:" + port,
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.1/",
+		"http://[::1]:" + port,
+	} {
+		if _, err := webhook.NewHMACWebhookSender().Send(context.Background(), url, "secret", "task.created", "delivery-1", []byte(`{}`)); !errors.Is(err, exceptions.ErrBlockedDestination) {
+			t.Errorf("Send(%s) = %v, want %v", url, err, exceptions.ErrBlockedDestination)
+		}
+	}
+
+	msg := &models.NotificationMessage{Recipient: server.URL}
+	if err := webhook.NewWebhookNotifier().Notify(context.Background(), msg); !errors.Is(err, exceptions.ErrBlockedDestination) {
+		t.Errorf("Notify(%s) = %v, want %v", server.URL, err, exceptions.ErrBlockedDestination)
+	}
+
+	if called {
+		t.Error("the private destination was called")
+	}
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type transactionKey struct{}
+
+// transaction is the unit of work's transaction, carried by the context
+type transaction struct {
+	db          *sqlx.DB
+	tx          *sqlx.Tx
+	savepoints  int
+	afterCommit []func()
+}
+
+// InTransaction reports whether ctx carries a unit of work
+func InTransaction(ctx context.Context) bool {
+	current, _ := ctx.Value(transactionKey{}).(*transaction)
+
+	return current != nil
+}
+
+// AfterCommit runs fn once the unit of work carried by ctx commits, or right
+// away outside one. Nothing runs when the unit of work rolls back.
+func AfterCommit(ctx context.Context, fn func()) {
+	current, _ := ctx.Value(transactionKey{}).(*transaction)
+	if current == nil {
+		fn()
+		return
+	}
+
+	current.afterCommit = append(current.afterCommit, fn)
+}
+
+// DB wraps the connection pool so statements run in the transaction carried by
+// the context when there is one. Repositories hold a DB instead of the pool and
+// join a unit of work without knowing about it.
+//
+// SELECT statements outside a transaction are spread over the read replicas,
+// everything else goes to the primary. Once a session has written, its reads
+// stay on the primary so it sees its own writes despite replication lag.
+type DB struct {
+	*sqlx.DB
+	replicas []*sqlx.DB
+	next     atomic.Uint64
+}
+
+func New(primary *sqlx.DB, replicas ...*sqlx.DB) *DB {
+	return &DB{
+		DB:       primary,
+		replicas: replicas,
+	}
+}
+
+// BeginTxx starts a transaction, or a savepoint when the context already
+// carries one, so a repository's own transaction nests inside a unit of work
+func (d *DB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	markWritten(ctx)
+
+	current := d.transaction(ctx)
+	if current == nil {
+		tx, err := d.DB.BeginTxx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Tx{Tx: tx, ctx: ctx}, nil
+	}
+
+	current.savepoints++
+	savepoint := fmt.Sprintf("unit_of_work_%d", current.savepoints)
+	if _, err := current.tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return nil, err
+	}
+
+	return &Tx{Tx: current.tx, ctx: ctx, savepoint: savepoint}, nil
+}
+
+func (d *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return d.conn(ctx, query).ExecContext(ctx, query, args...)
+}
+
+func (d *DB) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	return sqlx.GetContext(ctx, d.conn(ctx, query), dest, query, args...)
+}
+
+func (d *DB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return sqlx.SelectContext(ctx, d.conn(ctx, query), dest, query, args...)
+}
+
+func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return d.conn(ctx, query).QueryContext(ctx, query, args...)
+}
+
+func (d *DB) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	return d.conn(ctx, query).QueryxContext(ctx, query, args...)
+}
+
+func (d *DB) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	return d.conn(ctx, query).QueryRowxContext(ctx, query, args...)
+}
+
+func (d *DB) conn(ctx context.Context, query string) sqlx.ExtContext {
+	if current := d.transaction(ctx); current != nil {
+		return current.tx
+	}
+
+	if !isRead(query) {
+		markWritten(ctx)
+		return d.DB
+	}
+
+	if len(d.replicas) == 0 || hasWritten(ctx) || onPrimary(ctx) {
+		return d.DB
+	}
+
+	return d.replicas[d.next.Add(1)%uint64(len(d.replicas))]
+}
+
+// isRead reports whether a statement only reads, so a replica can serve it
+func isRead(query string) bool {
+	statement := strings.ToUpper(strings.TrimSpace(query))
+
+	return strings.HasPrefix(statement, "SELECT") && !strings.Contains(statement, "FOR UPDATE")
+}
+
+// transaction only returns a transaction opened on this pool
+func (d *DB) transaction(ctx context.Context) *transaction {
+	current, _ := ctx.Value(transactionKey{}).(*transaction)
+	if current == nil || current.db != d.DB {
+		return nil
+	}
+
+	return current
+}
+
+// Tx is a transaction, or a savepoint inside the unit of work's transaction
+// where Commit releases the savepoint and Rollback undoes only its statements
+type Tx struct {
+	*sqlx.Tx
+	ctx       context.Context
+	savepoint string
+	done      bool
+}
+
+func (t *Tx) Commit() error {
+	if t.savepoint == "" {
+		return t.Tx.Commit()
+	}
+
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	_, err := t.Tx.ExecContext(t.ctx, "RELEASE SAVEPOINT "+t.savepoint)
+
+	return err
+}
+
+func (t *Tx) Rollback() error {
+	if t.savepoint == "" {
+		return t.Tx.Rollback()
+	}
+
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	_, err := t.Tx.ExecContext(t.ctx, "ROLLBACK TO SAVEPOINT "+t.savepoint)
+
+	return err
+}
@@ -0,0 +1,80 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+// openNamed opens a database whose only row names it, so a read shows which pool served it
+func openNamed(t *testing.T, name string) *sqlx.DB {
+	db, err := sqlx.Connect("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_txlock=immediate", filepath.Join(t.TempDir(), name+".db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	db.MustExec("CREATE TABLE pools (name TEXT NOT NULL)")
+	db.MustExec("INSERT INTO pools (name) VALUES (?)", name)
+
+	return db
+}
+
+func servedBy(t *testing.T, ctx context.Context, db *database.DB) string {
+	var name string
+	if err := db.GetContext(ctx, &name, "SELECT name FROM pools LIMIT 1"); err != nil {
+		t.Fatal(err)
+	}
+
+	return name
+}
+
+func TestReadsGoToReplicasUntilTheSessionWrites(t *testing.T) {
+	db := database.New(openNamed(t, "primary"), openNamed(t, "replica"))
+	ctx := database.WithSession(context.Background())
+
+	if name := servedBy(t, ctx, db); name != "replica" {
+		t.Fatalf("read before writing served by %s, want replica", name)
+	}
+
+	if _, err := db.ExecContext(ctx, "UPDATE pools SET name = name"); err != nil {
+		t.Fatal(err)
+	}
+
+	if name := servedBy(t, ctx, db); name != "primary" {
+		t.Errorf("read after writing served by %s, want primary", name)
+	}
+
+	// Other sessions keep reading from the replica
+	if name := servedBy(t, database.WithSession(context.Background()), db); name != "replica" {
+		t.Errorf("read in another session served by %s, want replica", name)
+	}
+}
+
+func TestUnitOfWorkReadsFromPrimary(t *testing.T) {
+	db := database.New(openNamed(t, "primary"), openNamed(t, "replica"))
+
+	err := database.NewUnitOfWork(db).Do(context.Background(), func(ctx context.Context) error {
+		if name := servedBy(t, ctx, db); name != "primary" {
+			t.Errorf("read in a unit of work served by %s, want primary", name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOnPrimaryReadsFromPrimary(t *testing.T) {
+	db := database.New(openNamed(t, "primary"), openNamed(t, "replica"))
+
+	if name := servedBy(t, database.OnPrimary(context.Background()), db); name != "primary" {
+		t.Errorf("read on the primary served by %s, want primary", name)
+	}
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// SessionKey carries a Session in a context. Fiber hands handlers the request
+// context, so the middleware stores the session as a request user value under it.
+type SessionKey struct{}
+
+// Session tracks whether the work done under one context, such as an HTTP
+// request or a worker run, has written to the primary yet
+type Session struct {
+	written atomic.Bool
+}
+
+func NewSession() *Session {
+	return &Session{}
+}
+
+// WithSession starts a session, reads made with the returned context follow
+// its writes to the primary
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SessionKey{}, NewSession())
+}
+
+func markWritten(ctx context.Context) {
+	if session, ok := ctx.Value(SessionKey{}).(*Session); ok {
+		session.written.Store(true)
+	}
+}
+
+func hasWritten(ctx context.Context) bool {
+	session, ok := ctx.Value(SessionKey{}).(*Session)
+
+	return ok && session.written.Load()
+}
+
+type primaryKey struct{}
+
+// OnPrimary returns a context whose reads skip the replicas, for results that
+// outlive the request such as cache entries
+func OnPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func onPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+
+	return primary
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
+)
+
+// UnitOfWork runs a function in one transaction on the pool. Repositories built
+// on the same pool pick the transaction up from the context given to the function;
+// calls made with any other context run outside it, and on SQLite wait for it.
+type UnitOfWork struct {
+	db *DB
+}
+
+func NewUnitOfWork(db *DB) repositories.UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// A nested unit of work runs behind a savepoint of the outer one
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The outermost unit of work owns the transaction and its commit hooks
+	var owned *transaction
+	if u.db.transaction(ctx) == nil {
+		owned = &transaction{db: u.db.DB, tx: tx.Tx}
+		ctx = context.WithValue(ctx, transactionKey{}, owned)
+	}
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if owned != nil {
+		for _, hook := range owned.afterCommit {
+			hook()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int64   `db:"version"`
+	Name      string  `db:"name"`
+	AppliedAt *string `db:"applied_at"`
+}
+
+// Dialect holds the statements that differ between databases. Lock must
+// block until the advisory lock is held on conn and Unlock releases it.
+// Transactional runs each migration and its schema_migrations row in one
+// transaction, for databases whose DDL can be rolled back.
+type Dialect struct {
+	CreateTable   string
+	Lock          func(ctx context.Context, conn *sqlx.Conn) error
+	Unlock        func(ctx context.Context, conn *sqlx.Conn) error
+	Transactional bool
+}
+
+type Migrator struct {
+	db         *sqlx.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// New loads every "<version>_<name>.up.sql" and matching ".down.sql" file
+// from the root of source
+func New(db *sqlx.DB, source fs.FS, dialect Dialect) (*Migrator, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(source, path.Join(".", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in order and returns how many ran
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+
+	err := m.locked(ctx, func(conn *sqlx.Conn, versions map[int64]bool) error {
+		for _, migration := range m.migrations {
+			if versions[migration.Version] {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration.Up, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+
+	err := m.locked(ctx, func(conn *sqlx.Conn, versions map[int64]bool) error {
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if !versions[migration.Version] {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s cannot be reverted", migration.Version, migration.Name)
+			}
+
+			if err := m.apply(ctx, conn, migration.Down, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted++
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists every known migration with the time it was applied, if it was
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	statuses := []Status{}
+
+	err := m.locked(ctx, func(conn *sqlx.Conn, versions map[int64]bool) error {
+		appliedAt := map[int64]string{}
+
+		rows, err := conn.QueryxContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var version int64
+			var at string
+			if err := rows.Scan(&version, &at); err != nil {
+				return err
+			}
+			appliedAt[version] = at
+		}
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if at, ok := appliedAt[migration.Version]; ok {
+				status.AppliedAt = &at
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// locked runs fn on a single connection holding the advisory lock, so two
+// instances starting together never apply the same migration twice
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sqlx.Conn, versions map[int64]bool) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.dialect.Lock(ctx, conn); err != nil {
+		return err
+	}
+	defer m.dialect.Unlock(context.Background(), conn)
+
+	if _, err := conn.ExecContext(ctx, m.dialect.CreateTable); err != nil {
+		return err
+	}
+
+	// Read the applied versions only after the lock is held
+	var applied []int64
+	if err := sqlx.SelectContext(ctx, conn, &applied, "SELECT version FROM schema_migrations"); err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	versions := map[int64]bool{}
+	for _, version := range applied {
+		versions[version] = true
+	}
+
+	return fn(conn, versions)
+}
+
+// apply runs script and then statement, which records the version. With a
+// transactional dialect a failure leaves neither behind; otherwise a failing
+// script leaves the version unrecorded so the migration runs again once fixed.
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, script string, statement string, args ...any) error {
+	if !m.dialect.Transactional {
+		if err := execScript(ctx, conn, script); err != nil {
+			return err
+		}
+
+		_, err := conn.ExecContext(ctx, conn.Rebind(statement), args...)
+		return err
+	}
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := execScript(ctx, tx, script); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(statement), args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// execScript runs each statement of a script in turn, since drivers do not
+// accept several statements in one call by default
+func execScript(ctx context.Context, db sqlx.ExecerContext, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitStatements splits on semicolons ending a line and drops comment lines
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+
+	return statements
+}
@@ -0,0 +1,145 @@
+package migrations_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GraphZC/sdd-task-management/internal/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+func dialect(transactional bool) migrations.Dialect {
+	noLock := func(ctx context.Context, conn *sqlx.Conn) error {
+		return nil
+	}
+
+	return migrations.Dialect{
+		CreateTable:   "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY, name TEXT NOT NULL, applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP)",
+		Lock:          noLock,
+		Unlock:        noLock,
+		Transactional: transactional,
+	}
+}
+
+func open(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := sqlx.Connect("sqlite", fmt.Sprintf("file:%s", filepath.Join(t.TempDir(), "migrations.db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// tables returns whether each table exists
+func tables(t *testing.T, db *sqlx.DB, names ...string) map[string]bool {
+	t.Helper()
+
+	found := map[string]bool{}
+	for _, name := range names {
+		var count int
+		if err := db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name); err != nil {
+			t.Fatal(err)
+		}
+		found[name] = count == 1
+	}
+
+	return found
+}
+
+func applied(t *testing.T, db *sqlx.DB) []int64 {
+	t.Helper()
+
+	versions := []int64{}
+	if err := db.Select(&versions, "SELECT version FROM schema_migrations ORDER BY version"); err != nil {
+		t.Fatal(err)
+	}
+
+	return versions
+}
+
+var failing = fstest.MapFS{
+	"000001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id INTEGER);")},
+	"000001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+	"000002_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id INTEGER);\n\nINSERT INTO missing VALUES (1);")},
+	"000002_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+}
+
+func TestTransactionalMigrationRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	db := open(t)
+
+	migrator, err := migrations.New(db, failing, dialect(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ran, err := migrator.Up(ctx); err == nil || ran != 1 {
+		t.Fatalf("Up = %d, %v, want 1 and an error", ran, err)
+	}
+
+	// The failed migration left neither its table nor its version behind
+	if got := tables(t, db, "a", "b"); !got["a"] || got["b"] {
+		t.Errorf("tables = %v, want only a", got)
+	}
+
+	if versions := applied(t, db); len(versions) != 1 || versions[0] != 1 {
+		t.Errorf("applied = %v, want [1]", versions)
+	}
+
+	// Fixed, it applies from a clean slate
+	fixed := fstest.MapFS{}
+	for name, file := range failing {
+		fixed[name] = file
+	}
+	fixed["000002_create_b.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE b (id INTEGER);")}
+
+	if migrator, err = migrations.New(db, fixed, dialect(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if ran, err := migrator.Up(ctx); err != nil || ran != 1 {
+		t.Fatalf("Up = %d, %v, want 1, nil", ran, err)
+	}
+
+	if ran, err := migrator.Down(ctx, 2); err != nil || ran != 2 {
+		t.Fatalf("Down = %d, %v, want 2, nil", ran, err)
+	}
+
+	if got := tables(t, db, "a", "b"); got["a"] || got["b"] {
+		t.Errorf("tables = %v, want none", got)
+	}
+
+	if versions := applied(t, db); len(versions) != 0 {
+		t.Errorf("applied = %v, want none", versions)
+	}
+}
+
+func TestMigrationWithoutTransactionLeavesVersionUnrecorded(t *testing.T) {
+	ctx := context.Background()
+	db := open(t)
+
+	migrator, err := migrations.New(db, failing, dialect(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(ctx); err == nil {
+		t.Fatal("Up = nil, want an error")
+	}
+
+	// Statements before the failure stay, the version is not recorded
+	if got := tables(t, db, "a", "b"); !got["a"] || !got["b"] {
+		t.Errorf("tables = %v, want a and b", got)
+	}
+
+	if versions := applied(t, db); len(versions) != 1 || versions[0] != 1 {
+		t.Errorf("applied = %v, want [1]", versions)
+	}
+}
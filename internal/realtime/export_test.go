@@ -0,0 +1,4 @@
+package realtime
+
+// SubscriberBuffer is how many events a subscriber can fall behind
+const SubscriberBuffer = subscriberBuffer
@@ -0,0 +1,99 @@
+package realtime
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+)
+
+// Events buffered per connection before a slow client is disconnected
+const subscriberBuffer = 64
+
+// Hub closes a subscriber's channel when it falls behind, the client has
+// missed events and should reload before subscribing again
+type Hub interface {
+	Start(ctx context.Context) error
+	Subscribe(userID string) (<-chan *models.TaskEvent, func())
+	HandleTaskEvent(ctx context.Context, event *models.TaskEvent)
+}
+
+type subscriber struct {
+	events chan *models.TaskEvent
+}
+
+type hub struct {
+	broker      events.Broker
+	mu          sync.RWMutex
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+func NewHub(broker events.Broker) Hub {
+	return &hub{
+		broker:      broker,
+		subscribers: map[string]map[*subscriber]struct{}{},
+	}
+}
+
+func (h *hub) Start(ctx context.Context) error {
+	// Events from every instance come back through the broker
+	return h.broker.Subscribe(ctx, h.broadcast)
+}
+
+func (h *hub) Subscribe(userID string) (<-chan *models.TaskEvent, func()) {
+	sub := &subscriber{events: make(chan *models.TaskEvent, subscriberBuffer)}
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = map[*subscriber]struct{}{}
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.remove(userID, sub)
+	}
+
+	return sub.events, unsubscribe
+}
+
+func (h *hub) HandleTaskEvent(ctx context.Context, event *models.TaskEvent) {
+	if err := h.broker.Publish(ctx, event); err != nil {
+		log.Println("❌ Error publishing task event", err)
+	}
+}
+
+func (h *hub) broadcast(ctx context.Context, event *models.TaskEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Users only see events for their own tasks
+	for sub := range h.subscribers[event.UserID] {
+		select {
+		case sub.events <- event:
+		default:
+			// Skipping the event would leave the client silently out of date
+			log.Println("⚠️ Disconnecting slow subscriber", event.UserID)
+			h.remove(event.UserID, sub)
+		}
+	}
+}
+
+// remove closes the subscriber's channel once, the caller holds the lock
+func (h *hub) remove(userID string, sub *subscriber) {
+	if _, ok := h.subscribers[userID][sub]; !ok {
+		return
+	}
+
+	delete(h.subscribers[userID], sub)
+	if len(h.subscribers[userID]) == 0 {
+		delete(h.subscribers, userID)
+	}
+
+	close(sub.events)
+}
@@ -0,0 +1,81 @@
+package realtime_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/internal/realtime"
+)
+
+func newHub(t *testing.T) realtime.Hub {
+	hub := realtime.NewHub(events.NewLocalBroker())
+	if err := hub.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	return hub
+}
+
+func TestHubDeliversOnlyTheUsersEvents(t *testing.T) {
+	ctx := context.Background()
+	hub := newHub(t)
+
+	mine, unsubscribe := hub.Subscribe("user")
+	defer unsubscribe()
+
+	other, unsubscribeOther := hub.Subscribe("other")
+	defer unsubscribeOther()
+
+	hub.HandleTaskEvent(ctx, &models.TaskEvent{Type: models.TaskEventCreated, TaskID: "task", UserID: "user"})
+
+	select {
+	case event := <-mine:
+		if event.TaskID != "task" {
+			t.Errorf("event = %+v, want task", event)
+		}
+	default:
+		t.Fatal("no event for the task owner")
+	}
+
+	select {
+	case event := <-other:
+		t.Errorf("other user received %+v", event)
+	default:
+	}
+}
+
+func TestHubClosesSlowSubscribers(t *testing.T) {
+	ctx := context.Background()
+	hub := newHub(t)
+
+	slow, unsubscribe := hub.Subscribe("user")
+	defer unsubscribe()
+
+	for range realtime.SubscriberBuffer + 1 {
+		hub.HandleTaskEvent(ctx, &models.TaskEvent{Type: models.TaskEventUpdated, TaskID: "task", UserID: "user"})
+	}
+
+	// The buffered events are still delivered before the channel closes
+	received := 0
+	for range slow {
+		received++
+	}
+
+	if received != realtime.SubscriberBuffer {
+		t.Errorf("received %d events, want %d", received, realtime.SubscriberBuffer)
+	}
+
+	// Unsubscribing after the hub dropped the subscriber is a no-op
+	unsubscribe()
+
+	// Subscribing again starts over with an empty buffer
+	again, unsubscribeAgain := hub.Subscribe("user")
+	defer unsubscribeAgain()
+
+	hub.HandleTaskEvent(ctx, &models.TaskEvent{Type: models.TaskEventUpdated, TaskID: "task", UserID: "user"})
+	if event, ok := <-again; !ok || event.TaskID != "task" {
+		t.Errorf("event = %+v, %v, want task", event, ok)
+	}
+}
@@ -0,0 +1,52 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+type outboxWorker struct {
+	service   usecases.OutboxUseCase
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewOutboxWorker(service usecases.OutboxUseCase, interval time.Duration, retention time.Duration) Worker {
+	return &outboxWorker{
+		service:   service,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+func (o *outboxWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		// Committed task events wake the worker, the ticker picks up retries and other instances' writes
+		now := time.Now().UTC()
+		published, err := o.service.RelayPending(database.WithSession(ctx), now)
+		if err != nil {
+			log.Println("❌ Error relaying outbox messages", err)
+		} else if published > 0 {
+			log.Printf("📤 Relayed %d outbox messages\n", published)
+		}
+
+		// Published messages are only kept for a while to trace deliveries
+		if _, err := o.service.PrunePublished(database.WithSession(ctx), now.Add(-o.retention)); err != nil {
+			log.Println("❌ Error pruning outbox messages", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.service.Wakeup():
+		case <-ticker.C:
+		}
+	}
+}
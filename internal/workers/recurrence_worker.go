@@ -0,0 +1,43 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+type recurrenceWorker struct {
+	service  usecases.TaskUseCase
+	interval time.Duration
+}
+
+func NewRecurrenceWorker(service usecases.TaskUseCase, interval time.Duration) Worker {
+	return &recurrenceWorker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (r *recurrenceWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// Materialise occurrences missed while the server was down or idle
+		created, err := r.service.MaterializeMissedOccurrences(database.WithSession(ctx), time.Now().UTC())
+		if err != nil {
+			log.Println("❌ Error materialising recurring tasks", err)
+		} else if created > 0 {
+			log.Printf("🔁 Materialised %d recurring task occurrences\n", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+type reminderWorker struct {
+	service  usecases.ReminderUseCase
+	interval time.Duration
+}
+
+func NewReminderWorker(service usecases.ReminderUseCase, interval time.Duration) Worker {
+	return &reminderWorker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (r *reminderWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// Pending reminders live in the database, so anything due while the server was down is sent now
+		sent, err := r.service.DispatchDueReminders(database.WithSession(ctx), time.Now().UTC())
+		if err != nil {
+			log.Println("❌ Error dispatching reminders", err)
+		} else if sent > 0 {
+			log.Printf("🔔 Sent %d reminders\n", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+)
+
+type webhookWorker struct {
+	service  usecases.WebhookUseCase
+	interval time.Duration
+}
+
+func NewWebhookWorker(service usecases.WebhookUseCase, interval time.Duration) Worker {
+	return &webhookWorker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (w *webhookWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		// New deliveries wake the worker, the ticker picks up retries
+		delivered, err := w.service.DeliverPending(database.WithSession(ctx), time.Now().UTC())
+		if err != nil {
+			log.Println("❌ Error delivering webhooks", err)
+		} else if delivered > 0 {
+			log.Printf("📨 Delivered %d webhooks\n", delivered)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.service.Wakeup():
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package workers
+
+import "context"
+
+// Worker runs a job on an interval. Each run is its own database session, so
+// a run reads back what it wrote from the primary instead of a lagging replica.
+type Worker interface {
+	Start(ctx context.Context)
+}
@@ -4,52 +4,391 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
+	"os"
 
 	"github.com/GraphZC/sdd-task-management/configs"
+	"github.com/GraphZC/sdd-task-management/domain/events"
+	"github.com/GraphZC/sdd-task-management/domain/importers"
+	"github.com/GraphZC/sdd-task-management/domain/models"
+	"github.com/GraphZC/sdd-task-management/domain/notifiers"
+	"github.com/GraphZC/sdd-task-management/domain/repositories"
 	"github.com/GraphZC/sdd-task-management/domain/usecases"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/cache"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/email"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/github"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/inapp"
 	"github.com/GraphZC/sdd-task-management/internal/adapters/mysql"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/postgres"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/publisher"
 	"github.com/GraphZC/sdd-task-management/internal/adapters/rest"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqlite"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/todoist"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/trello"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/webhook"
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/GraphZC/sdd-task-management/internal/realtime"
+	"github.com/GraphZC/sdd-task-management/internal/workers"
 	"github.com/GraphZC/sdd-task-management/middlewares"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gofiber/fiber/v2"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	_ "modernc.org/sqlite"
 )
 
 func main() {
 	app := fiber.New()
+	app.Use(middlewares.ReadYourWritesMiddleware())
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	cfg := configs.NewConfig()
 
-	db, err := sqlx.ConnectContext(ctx, "mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", cfg.DBUsername, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName))
+	var driverName, dsn string
+	switch cfg.DBDriver {
+	case "mysql":
+		driverName, dsn = "mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=UTC&time_zone=%%27%%2B00%%3A00%%27", cfg.DBUsername, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
+	case "postgres":
+		driverName, dsn = "pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s", url.QueryEscape(cfg.DBUsername), url.QueryEscape(cfg.DBPassword), cfg.DBHost, cfg.DBPort, cfg.DBName)
+	case "sqlite":
+		driverName, dsn = "sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate", cfg.DBPath)
+	default:
+		log.Fatalf("unknown DB_DRIVER %q", cfg.DBDriver)
+	}
 
+	db, err := connect(ctx, driverName, dsn, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	defer db.Close()
 
-	userRepo := mysql.NewUserMySQLRepository(db)
+	// Reads are spread over the replicas, writes and transactions go to db
+	replicas := []*sqlx.DB{}
+	for _, replicaDSN := range cfg.DBReplicas {
+		replica, err := connect(ctx, driverName, replicaDSN, cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		defer replica.Close()
+		replicas = append(replicas, replica)
+	}
+
+	pool := database.New(db, replicas...)
+
+	migrator, err := newMigrator(cfg.DBDriver, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Migration subcommands run instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, migrator, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if cfg.DBAutoMigrate {
+		if err := runMigrate(ctx, migrator, []string{"up"}); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Postgres backs every store, SQLite every store whose SQL differs from MySQL
+	var userRepo repositories.UserRepository
+	var taskRepo repositories.TaskRepository
+	var searchIndex repositories.TaskSearchIndex
+	var projectRepo repositories.ProjectRepository
+	var viewRepo repositories.ViewRepository
+	var notificationRepo repositories.NotificationRepository
+	var reminderRepo repositories.ReminderRepository
+	var timeEntryRepo repositories.TimeEntryRepository
+	var reportingRepo repositories.ReportingRepository
+	var webhookRepo repositories.WebhookRepository
+	var outboxRepo repositories.OutboxRepository
+	var eventStore repositories.TaskEventStore
+	var commentRepo repositories.TaskCommentRepository
+	var followerRepo repositories.TaskFollowerRepository
+	switch cfg.DBDriver {
+	case "postgres":
+		userRepo = postgres.NewUserPostgresRepository(pool)
+		taskRepo = postgres.NewTaskPostgresRepository(pool)
+		searchIndex = postgres.NewTaskPostgresSearchIndex(pool)
+		projectRepo = postgres.NewProjectPostgresRepository(pool)
+		viewRepo = postgres.NewViewPostgresRepository(pool)
+		notificationRepo = postgres.NewNotificationPostgresRepository(pool)
+		reminderRepo = postgres.NewReminderPostgresRepository(pool)
+		timeEntryRepo = postgres.NewTimeEntryPostgresRepository(pool)
+		reportingRepo = postgres.NewReportingPostgresRepository(pool)
+		webhookRepo = postgres.NewWebhookPostgresRepository(pool)
+		outboxRepo = postgres.NewOutboxPostgresRepository(pool)
+		eventStore = postgres.NewTaskEventPostgresStore(pool)
+		commentRepo = postgres.NewTaskCommentPostgresRepository(pool)
+		followerRepo = postgres.NewTaskFollowerPostgresRepository(pool)
+	case "sqlite":
+		userRepo = sqlite.NewUserSQLiteRepository(pool)
+		taskRepo = sqlite.NewTaskSQLiteRepository(pool)
+		searchIndex = sqlite.NewTaskSQLiteSearchIndex(pool)
+		notificationRepo = sqlite.NewNotificationSQLiteRepository(pool)
+		reminderRepo = sqlite.NewReminderSQLiteRepository(pool)
+		timeEntryRepo = sqlite.NewTimeEntrySQLiteRepository(pool)
+		reportingRepo = sqlite.NewReportingSQLiteRepository(pool)
+		webhookRepo = sqlite.NewWebhookSQLiteRepository(pool)
+	default:
+		userRepo = mysql.NewUserMySQLRepository(pool)
+		taskRepo = mysql.NewTaskMySQLRepository(pool)
+		searchIndex = mysql.NewTaskMySQLSearchIndex(pool)
+		notificationRepo = mysql.NewNotificationMySQLRepository(pool)
+		reminderRepo = mysql.NewReminderMySQLRepository(pool)
+		timeEntryRepo = mysql.NewTimeEntryMySQLRepository(pool)
+		reportingRepo = mysql.NewReportingMySQLRepository(pool)
+		webhookRepo = mysql.NewWebhookMySQLRepository(pool)
+	}
+
+	// The remaining stores run the same SQL on MySQL and SQLite
+	if cfg.DBDriver != "postgres" {
+		projectRepo = mysql.NewProjectMySQLRepository(pool)
+		viewRepo = mysql.NewViewMySQLRepository(pool)
+		outboxRepo = mysql.NewOutboxMySQLRepository(pool)
+		eventStore = mysql.NewTaskEventMySQLStore(pool)
+		commentRepo = mysql.NewTaskCommentMySQLRepository(pool)
+		followerRepo = mysql.NewTaskFollowerMySQLRepository(pool)
+	}
+
+	// Task reads are served from the cache when one is configured
+	switch cfg.CacheDriver {
+	case "memory":
+		taskRepo = cache.NewTaskCachedRepository(taskRepo, cache.NewLRUCache(cfg.CacheSize), cfg.CacheTTL)
+	case "redis":
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		})
+		defer redisClient.Close()
+
+		taskRepo = cache.NewTaskCachedRepository(taskRepo, cache.NewRedisCache(redisClient, "task-management:"), cfg.CacheTTL)
+	case "none":
+	default:
+		log.Fatalf("unknown CACHE_DRIVER %q", cfg.CacheDriver)
+	}
+
 	userService := usecases.NewUserService(userRepo, cfg)
 	userHandler := rest.NewUserHandler(userService)
 
-	taskRepo := mysql.NewTaskMySQLRepository(db)
-	taskService := usecases.NewTaskService(taskRepo)
+	dispatcher := events.NewDispatcher()
+
+	projectService := usecases.NewProjectService(projectRepo)
+	projectHandler := rest.NewProjectHandler(projectService)
+
+	taskService := usecases.NewTaskService(taskRepo, projectRepo, userRepo, outboxRepo, eventStore, database.NewUnitOfWork(pool), dispatcher)
 	taskHandler := rest.NewTaskHandler(taskService)
 
+	// Rebuilding the tasks table from the event streams runs instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "rebuild-tasks" {
+		rebuilt, err := usecases.NewProjectionService(eventStore, taskRepo, projectRepo).RebuildTasks(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("✅ Rebuilt %d task(s) from their event streams", rebuilt)
+		return
+	}
+
+	searchService := usecases.NewSearchService(searchIndex)
+	searchHandler := rest.NewSearchHandler(searchService)
+	dispatcher.Subscribe(searchService.HandleTaskEvent)
+
+	importService := usecases.NewImportService(taskService, map[string]importers.Importer{
+		"trello":  trello.NewTrelloImporter(),
+		"todoist": todoist.NewTodoistImporter(),
+		"github":  github.NewGitHubImporter(),
+	})
+	importHandler := rest.NewImportHandler(importService)
+
+	calendarService := usecases.NewCalendarService(userRepo, taskRepo)
+	calendarHandler := rest.NewCalendarHandler(calendarService)
+
+	viewService := usecases.NewViewService(viewRepo, taskRepo, userRepo)
+	viewHandler := rest.NewViewHandler(viewService)
+
+	commentService := usecases.NewCommentService(commentRepo, taskRepo, userRepo, dispatcher)
+	commentHandler := rest.NewCommentHandler(commentService)
+
+	followerService := usecases.NewFollowerService(followerRepo, taskRepo, userRepo)
+	followerHandler := rest.NewFollowerHandler(followerService)
+
+	notificationService := usecases.NewNotificationService(notificationRepo, followerRepo)
+	notificationHandler := rest.NewNotificationHandler(notificationService)
+	dispatcher.Subscribe(notificationService.HandleTaskEvent)
+
+	reminderService := usecases.NewReminderService(reminderRepo, taskRepo, userRepo, map[string]notifiers.Notifier{
+		models.ReminderChannelEmail:   email.NewSMTPNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom),
+		models.ReminderChannelWebhook: webhook.NewWebhookNotifier(),
+		models.ReminderChannelInApp:   inapp.NewInAppNotifier(notificationService),
+	})
+	reminderHandler := rest.NewReminderHandler(reminderService)
+
+	timeEntryService := usecases.NewTimeEntryService(timeEntryRepo, taskRepo, userRepo)
+	timeEntryHandler := rest.NewTimeEntryHandler(timeEntryService)
+
+	statsService := usecases.NewStatsService(reportingRepo, userRepo)
+	statsHandler := rest.NewStatsHandler(statsService)
+
+	webhookService := usecases.NewWebhookService(webhookRepo, webhook.NewHMACWebhookSender())
+	webhookHandler := rest.NewWebhookHandler(webhookService)
+	dispatcher.Subscribe(webhookService.HandleTaskEvent)
+
+	// Task events written to the outbox are relayed to other systems
+	var outboxPublisher events.Publisher
+	switch cfg.OutboxPublisher {
+	case "log":
+		outboxPublisher = publisher.NewLogPublisher()
+	case "http":
+		outboxPublisher = publisher.NewHTTPPublisher(cfg.OutboxHTTPURL)
+	case "nats":
+		nc, err := nats.Connect(cfg.NATSURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer nc.Close()
+
+		js, err := jetstream.New(nc)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// The stream keeps the events until consumers read them
+		_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+			Name:     "TASK_EVENTS",
+			Subjects: []string{cfg.NATSSubject + ".>"},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		outboxPublisher = publisher.NewNATSPublisher(js, cfg.NATSSubject)
+	case "kafka":
+		writer := &kafka.Writer{
+			Addr:         kafka.TCP(cfg.KafkaBrokers...),
+			Topic:        cfg.KafkaTopic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		}
+		defer writer.Close()
+
+		outboxPublisher = publisher.NewKafkaPublisher(writer)
+	default:
+		log.Fatalf("unknown OUTBOX_PUBLISHER %q", cfg.OutboxPublisher)
+	}
+
+	outboxService := usecases.NewOutboxService(outboxRepo, outboxPublisher)
+	dispatcher.Subscribe(outboxService.HandleTaskEvent)
+
+	hub := realtime.NewHub(events.NewLocalBroker())
+	if err := hub.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
+	eventHandler := rest.NewEventHandler(hub)
+	dispatcher.Subscribe(hub.HandleTaskEvent)
+
+	recurrenceWorker := workers.NewRecurrenceWorker(taskService, cfg.RecurrenceJobInterval)
+	go recurrenceWorker.Start(ctx)
+
+	reminderWorker := workers.NewReminderWorker(reminderService, cfg.ReminderJobInterval)
+	go reminderWorker.Start(ctx)
+
+	webhookWorker := workers.NewWebhookWorker(webhookService, cfg.WebhookJobInterval)
+	go webhookWorker.Start(ctx)
+
+	outboxWorker := workers.NewOutboxWorker(outboxService, cfg.OutboxJobInterval, cfg.OutboxRetention)
+	go outboxWorker.Start(ctx)
+
 	app.Post("/register", userHandler.Register)
 	app.Post("/login", userHandler.Login)
+	app.Get("/calendar/:token.ics", calendarHandler.Feed)
+
+	app.Get("/events", middlewares.JwtStreamMiddleware(cfg.JWTSecret), eventHandler.Stream)
+	app.Get("/events/ws", middlewares.JwtStreamMiddleware(cfg.JWTSecret), eventHandler.WebSocket)
 
 	app.Use(middlewares.JwtMiddleware(cfg.JWTSecret))
 	app.Post("/task", taskHandler.CreateTask)
 	app.Get("/task", taskHandler.FindTaskByUserID)
+	app.Get("/task/search", searchHandler.SearchTasks)
+	app.Post("/task/bulk", taskHandler.BulkUpdateTasks)
+	app.Get("/task/export", taskHandler.ExportTasks)
+	app.Post("/task/import", taskHandler.ImportTasks)
+	app.Post("/task/import/:source", importHandler.ImportFromSource)
 	app.Get("/task/:taskID", taskHandler.FindTaskByID)
 	app.Delete("/task/:taskID", taskHandler.DeleteTaskByID)
 	app.Put("/task/:taskID", taskHandler.UpdateTaskByID)
+	app.Patch("/task/:taskID", taskHandler.PatchTaskByID)
 	app.Post("/task/:taskID/status", taskHandler.UpdateTaskStatusByID)
+	app.Put("/task/:taskID/checklist/:index", taskHandler.UpdateChecklistItem)
+	app.Put("/task/:taskID/assignee", taskHandler.AssignTask)
+	app.Post("/task/:taskID/comments", commentHandler.CreateComment)
+	app.Get("/task/:taskID/comments", commentHandler.FindComments)
+	app.Post("/task/:taskID/followers", followerHandler.AddFollower)
+	app.Get("/task/:taskID/followers", followerHandler.FindFollowers)
+	app.Delete("/task/:taskID/followers/:userID", followerHandler.RemoveFollower)
+	app.Post("/task/:taskID/reminders", reminderHandler.CreateReminder)
+	app.Get("/task/:taskID/reminders", reminderHandler.FindRemindersByTaskID)
+	app.Delete("/task/:taskID/reminders/:reminderID", reminderHandler.DeleteReminderByID)
+	app.Post("/task/:taskID/timer/start", timeEntryHandler.StartTimer)
+	app.Post("/task/:taskID/timer/stop", timeEntryHandler.StopTimer)
+	app.Post("/task/:taskID/time-entries", timeEntryHandler.CreateTimeEntry)
+	app.Get("/task/:taskID/time-entries", timeEntryHandler.FindTimeEntriesByTaskID)
+	app.Delete("/task/:taskID/time-entries/:entryID", timeEntryHandler.DeleteTimeEntryByID)
+	app.Get("/reports/timesheet", timeEntryHandler.Timesheet)
+	app.Get("/stats", statsHandler.FindStats)
+	app.Get("/notifications", notificationHandler.FindNotifications)
+	app.Post("/notifications/read-all", notificationHandler.MarkAllNotificationsRead)
+	app.Get("/notifications/preferences", notificationHandler.FindPreferences)
+	app.Put("/notifications/preferences", notificationHandler.UpdatePreferences)
+	app.Post("/notifications/:notificationID/read", notificationHandler.MarkNotificationRead)
+	app.Get("/settings", userHandler.FindSettings)
+	app.Put("/settings", userHandler.UpdateSettings)
+	app.Post("/settings/calendar-token", userHandler.RegenerateCalendarToken)
+	app.Delete("/settings/calendar-token", userHandler.RevokeCalendarToken)
+	app.Post("/projects", projectHandler.CreateProject)
+	app.Get("/projects", projectHandler.FindProjectsByUserID)
+	app.Delete("/projects/:projectID", projectHandler.DeleteProjectByID)
+	app.Post("/views", viewHandler.CreateView)
+	app.Get("/views", viewHandler.FindViewsByUserID)
+	app.Get("/views/:viewID", viewHandler.FindViewByID)
+	app.Put("/views/:viewID", viewHandler.UpdateViewByID)
+	app.Delete("/views/:viewID", viewHandler.DeleteViewByID)
+	app.Get("/views/:viewID/tasks", viewHandler.FindViewTasks)
+	app.Post("/webhooks", webhookHandler.CreateWebhook)
+	app.Get("/webhooks", webhookHandler.FindWebhooks)
+	app.Delete("/webhooks/:webhookID", webhookHandler.DeleteWebhookByID)
+	app.Get("/webhooks/:webhookID/deliveries", webhookHandler.FindDeliveries)
+	app.Post("/webhooks/:webhookID/deliveries/:deliveryID/redeliver", webhookHandler.Redeliver)
 
 	if err := app.Listen(":9000"); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// connect opens a pool with the configured limits
+func connect(ctx context.Context, driverName string, dsn string, cfg *configs.Config) (*sqlx.DB, error) {
+	db, err := sqlx.ConnectContext(ctx, driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+
+	return db, nil
+}
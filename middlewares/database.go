@@ -0,0 +1,16 @@
+package middlewares
+
+import (
+	"github.com/GraphZC/sdd-task-management/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Each request is a database session, once it writes its reads go to the
+// primary so it never reads back older data than it just wrote
+func ReadYourWritesMiddleware() func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		c.Context().SetUserValue(database.SessionKey{}, database.NewSession())
+
+		return c.Next()
+	}
+}
@@ -13,3 +13,15 @@ func JwtMiddleware(jwtSecret string) func(*fiber.Ctx) error {
 		},
 	})
 }
+
+// Browsers can't set headers on EventSource and WebSocket, so streams also accept ?token=
+func JwtStreamMiddleware(jwtSecret string) func(*fiber.Ctx) error {
+	return jwtware.New(jwtware.Config{
+		SigningKey: jwtware.SigningKey{
+			JWTAlg: jwtware.HS256,
+			Key:    []byte(jwtSecret),
+		},
+		TokenLookup: "header:Authorization,query:token",
+		AuthScheme:  "Bearer",
+	})
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/GraphZC/sdd-task-management/internal/adapters/mysql"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/postgres"
+	"github.com/GraphZC/sdd-task-management/internal/adapters/sqlite"
+	"github.com/GraphZC/sdd-task-management/internal/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up | down [steps|all] | status")
+
+// newMigrator picks the migrations written for the configured driver
+func newMigrator(driver string, db *sqlx.DB) (*migrations.Migrator, error) {
+	switch driver {
+	case "sqlite":
+		return sqlite.NewMigrator(db)
+	case "postgres":
+		return postgres.NewMigrator(db)
+	default:
+		return mysql.NewMigrator(db)
+	}
+}
+
+// runMigrate handles "migrate up", "migrate down [steps|all]" and "migrate status"
+func runMigrate(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	var err error
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("✅ Applied %d migration(s)", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if args[1] == "all" {
+				steps = math.MaxInt
+			} else if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errMigrateUsage
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("✅ Reverted %d migration(s)", reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = *status.AppliedAt
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return w.Flush()
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
@@ -15,10 +15,26 @@ type ValidateError struct {
 }
 
 func ValidateStruct[T any](payload T) *ValidateError {
-	err := validate.Struct(payload)
+	return validationError(validate.Struct(payload))
+}
+
+func ValidateStructPartial[T any](payload T, fields ...string) *ValidateError {
+	return validationError(validate.StructPartial(payload, fields...))
+}
+
+func validationError(err error) *ValidateError {
 	errMsg := ""
 	if err != nil {
-		for _, err := range err.(valid.ValidationErrors) {
+		// A nil or non-struct payload is not a field error
+		validationErrors, ok := err.(valid.ValidationErrors)
+		if !ok {
+			return &ValidateError{
+				Error:   "Invalid request",
+				Message: err.Error(),
+			}
+		}
+
+		for _, err := range validationErrors {
 			tmp := strings.Split(err.StructNamespace(), ".")
 			msg := fmt.Sprintf("%s is %s", tmp[len(tmp)-1], err.Tag())
 			msg = strings.ToLower(string(msg[0])) + msg[1:]